@@ -0,0 +1,40 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# This terraform DataSource is used to query the existing CU images of the FICON splits from PowerMax array.
+# The information fetched from this data source can be used for getting the details / for further processing in resource block.
+
+# List all CU images of all splits.
+data "powermax_cu_image" "all_cu_images" {
+}
+
+# List specific CU images.
+data "powermax_cu_image" "cu_images" {
+  filter {
+    # Optional the splits to list the CU images of, all the splits when not set
+    split_ids = ["0"]
+    # Optional the SSIDs of the CU images to list
+    ssids = ["F0A0"]
+  }
+}
+
+output "cu_images" {
+  value = data.powermax_cu_image.all_cu_images
+}
+
+# After the successful execution of above said block, We can see the output value by executing 'terraform output' command.
+# Also, we can use the fetched information by the variable data.powermax_cu_image.all_cu_images
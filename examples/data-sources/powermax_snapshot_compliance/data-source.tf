@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# This terraform DataSource is used to query the SnapVX snapshot compliance of storage groups from PowerMax array.
+# The compliance is GREEN when a storage group has enough good snapshots for its snapshot policies, YELLOW once the
+# count drops to the policy compliance_count_warning and RED once it drops to the compliance_count_critical.
+
+# Report the compliance of all the storage groups associated with a snapshot policy.
+data "powermax_snapshot_compliance" "all_storage_groups" {
+}
+
+# Report the compliance of specific storage groups.
+data "powermax_snapshot_compliance" "app_storage_groups" {
+  # Optional filter to report the compliance of the specified storage groups
+  filter {
+    storage_group_names = ["app_sg_1", "app_sg_2"]
+  }
+
+  # Block the deployment when the protection of a storage group is out of compliance
+  lifecycle {
+    postcondition {
+      condition     = alltrue([for sg in self.storage_groups : sg.compliance != "RED"])
+      error_message = "The snapshot protection of a storage group is out of compliance."
+    }
+  }
+}
+
+output "snapshot_compliance" {
+  value = data.powermax_snapshot_compliance.all_storage_groups
+}
+
+# After the successful execution of above said block, We can see the output value by executing 'terraform output' command.
+# Also, we can use the fetched information by the variable data.powermax_snapshot_compliance.all_storage_groups
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# This terraform DataSource is used to query the SnapVX snapshot generation history of a storage group or volume from PowerMax array.
+# Every generation is listed with its snap ID, age, expiry, secure state and link targets.
+
+# List all the snapshot generations of a storage group.
+data "powermax_snapshot_history" "storage_group" {
+  storage_group_name = "app_sg"
+}
+
+# List the snapshot generations of all the storage groups of a volume, which include the volume.
+data "powermax_snapshot_history" "volume" {
+  volume_id = "0008F"
+}
+
+# List the cleanup candidates: the daily backups older than a week which are not secure.
+data "powermax_snapshot_history" "cleanup" {
+  storage_group_name = "app_sg"
+
+  # Optional filter on the generations
+  filter {
+    # Only the snapshots whose name starts with the prefix
+    name_prefix = "daily_"
+    # Only the generations at least this number of hours old
+    older_than_hours = 168
+    # Only the generations which have not expired yet
+    expired = false
+  }
+}
+
+output "snapshot_cleanup_snapids" {
+  value = [for generation in data.powermax_snapshot_history.cleanup.generations : generation.snapid if !generation.secure && !generation.linked]
+}
+
+# After the successful execution of above said block, We can see the output value by executing 'terraform output' command.
+# Also, we can use the fetched information by the variable data.powermax_snapshot_history.storage_group
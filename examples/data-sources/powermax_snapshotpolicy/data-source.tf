@@ -36,5 +36,27 @@ output "SnapshotPolicyFiltered" {
   value = data.powermax_snapshotpolicy.SnapshotPolicyFiltered
 }
 
+# Returns the active local snapshot policies which run at least hourly, with the storage groups they protect
+data "powermax_snapshotpolicy" "SnapshotPolicyExpanded" {
+  # Optional, set to list the storage groups of each policy with their compliance and last snapshot time
+  expand_storage_groups = true
+
+  filter {
+    # Optional, local or cloud
+    type = "local"
+    # Optional, true for the suspended policies, false for the active ones
+    suspended = false
+    # Optional range of the interval between each policy execution
+    min_interval_minutes = 10
+    max_interval_minutes = 60
+    # Optional only list the policies associated with a storage group carrying any of the tags
+    tags = ["chargeback_finance"]
+  }
+}
+
+output "SnapshotPolicyExpanded" {
+  value = data.powermax_snapshotpolicy.SnapshotPolicyExpanded
+}
+
 # After the successful execution of above said block, We can see the output value by executing 'terraform output' command.
 # Also, we can use the fetched information by the variable data.powermax_snapshotpolicy.example
\ No newline at end of file
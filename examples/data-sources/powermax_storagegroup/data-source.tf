@@ -34,6 +34,13 @@ data "powermax_storagegroup" "test" {
   }
 }
 
+# Returns the PowerMax storage groups carrying any of the tags provided in the `tags` filter block and their details
+data "powermax_storagegroup" "tagged" {
+  filter {
+    tags = ["chargeback_finance"]
+  }
+}
+
 output "storagegroup_data" {
   value = data.powermax_storagegroup.test
 }
@@ -0,0 +1,32 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+terraform {
+  required_providers {
+    powermax = {
+      source = "dell/powermax"
+    }
+  }
+}
+
+provider "powermax" {
+  username      = var.username
+  password      = var.password
+  endpoint      = var.endpoint
+  serial_number = var.serial_number
+  pmax_version  = var.pmax_version
+  insecure      = true
+}
\ No newline at end of file
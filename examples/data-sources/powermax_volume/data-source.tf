@@ -158,6 +158,9 @@ data "powermax_volume" "volume_datasource_test" {
 
     # Optional Volumes Ids that correspond to Namespace Globally Unique Identifier that uses the EUI64 16-byte designator format. Used in conjunction with NVMe volumes
     nguid = "nguid"
+
+    # Optional Volume ids in the storage groups carrying any of the tags
+    tags = ["chargeback_finance"]
   }
 }
 
@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (name and connection details), Delete and Import an existing cloud provider from the PowerMax Array.
+# After `terraform apply` of this example file it will add a new cloud provider with the name set in `name` attribute on the PowerMax
+
+# A cloud provider holds the connection details of the object store used for cloud snapshots and cloud enabled snapshot policies.
+# Exactly one of `ecs`, `azure` and `amazon` must be set. The credentials are sensitive and are never read back from the array.
+# The bucket, container, storage class and region of a cloud provider cannot be updated after creation.
+resource "powermax_cloud_provider" "cloud_provider_1" {
+
+  # Attributes which are able to be modified after create (name, key, secret, node, secure, port)
+
+  # Required The name of the cloud provider. Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed.
+  name = "ecs_provider_1"
+
+  # Connection details of an ECS cloud provider
+  ecs = {
+    # Required The access key of the ECS object user
+    key = var.cloud_provider_key
+    # Required The secret key of the ECS object user
+    secret = var.cloud_provider_secret
+    # Required The IP address or hostname of the ECS node
+    node = "10.0.0.10"
+    # Optional Whether a secure connection is used
+    secure = true
+    # Optional The port used to connect to the cloud provider
+    port = 9021
+    # Optional The ECS bucket used by the cloud provider
+    bucket = "powermax-bucket"
+  }
+
+  # Connection details of an Azure cloud provider
+  # azure = {
+  #   storage_account = "powermaxaccount"
+  #   managed_key     = var.cloud_provider_secret
+  #   secure          = true
+  #   url             = "https://powermaxaccount.blob.core.windows.net"
+  #   container       = "powermax-container"
+  # }
+
+  # Connection details of an Amazon cloud provider
+  # amazon = {
+  #   key           = var.cloud_provider_key
+  #   secret        = var.cloud_provider_secret
+  #   secure        = true
+  #   bucket        = "powermax-bucket"
+  #   storage_class = "STANDARD"
+  #   region        = "us-east-1"
+  # }
+}
+
+# After the execution of above resource block, a cloud provider has been added to the PowerMax array.
+# For more information about the newly created resource use the `terraform show` command to review the current state
@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (restore), Delete and Import an existing cloud snapshot from the PowerMax Array.
+# After `terraform apply` of this example file it will create a cloud snapshot of the storage group set in `storage_group` on the cloud provider set in `cloud_provider_id`
+
+# The name, cloud provider and expiry of a cloud snapshot cannot be changed after it has been created.
+resource "powermax_cloud_snapshot" "cloud_snapshot_1" {
+  # Required The storage group to take the cloud snapshot of
+  storage_group {
+    name = "cloud_snapshot_sg"
+  }
+
+  # Required The name of the cloud snapshot
+  name = "cloud_snapshot_1"
+
+  # Required The name of the cloud provider where the snapshot will reside
+  cloud_provider_id = "cloud_provider_1"
+
+  # Optional The number of days the cloud snapshot is to live for
+  expiry_time_days = 30
+
+  # Optional Restore the cloud snapshot to a new storage group
+  # Can only be set once the cloud snapshot has been archived, the restore is performed each time the target storage group changes
+  # restore = {
+  #   target_storage_group = "cloud_snapshot_sg_restored"
+  #   srp_id               = "SRP_1"
+  #   slo_id               = "Diamond"
+  # }
+}
+
+# After the execution of above resource block, a cloud snapshot has been created at PowerMax array.
+# The archive progress is reported in the `state` and `protected_percent` attributes, use the `terraform show` command to review the current state
@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (destination_ip_address, prefix, gateway_ip_address, interface_id), Delete and Import an existing route of the cloud system from the PowerMax Array.
+# After `terraform apply` of this example file it will add a new static route to the cloud system of the PowerMax
+
+resource "powermax_cloud_system_route" "route_1" {
+
+  # Attributes which are able to be modified after create (destination_ip_address, prefix, gateway_ip_address, interface_id)
+
+  # Required The destination IP address of the route
+  destination_ip_address = "10.10.0.0"
+
+  # Required The netmask prefix number of the route
+  prefix = "16"
+
+  # Required The gateway IP address of the route
+  gateway_ip_address = "10.0.0.1"
+
+  # Optional The ID of the interface the route is associated with
+  # interface_id = "eth2"
+}
+
+# After the execution of above resource block, a route has been added to the cloud system of the PowerMax array.
+# For more information about the newly created resource use the `terraform show` command to review the current state
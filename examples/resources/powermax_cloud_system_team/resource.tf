@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (ip_address, prefix, interface_ids), Delete and Import an existing NIC team of the cloud system from the PowerMax Array.
+# After `terraform apply` of this example file it will create a new NIC team on the cloud system of the PowerMax
+
+resource "powermax_cloud_system_team" "team_1" {
+
+  # Attributes which are able to be modified after create (ip_address, prefix, interface_ids)
+
+  # Required The IP address of the NIC team
+  ip_address = "10.0.1.10"
+
+  # Required The netmask prefix number of the NIC team
+  prefix = 24
+
+  # Required The IDs of the interfaces in the NIC team
+  interface_ids = ["eth2", "eth3"]
+}
+
+# After the execution of above resource block, a NIC team has been created on the cloud system of the PowerMax array.
+# For more information about the newly created resource use the `terraform show` command to review the current state
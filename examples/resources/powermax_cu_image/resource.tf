@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (volume_ids, start_alias_address, end_alias_address), Delete and Import a CU image of a FICON split from the PowerMax Array.
+# After `terraform apply` of this example file it will create the CU image under the split set in `split_id` with the CKD volumes set in `volume_ids` mapped to it.
+# Destroying this resource unmaps all the volumes of the CU image, which removes the CU image from the array.
+
+# The split, SSID and number of the CU image cannot be changed after it has been created.
+resource "powermax_volume" "ckd_volume" {
+  vol_name  = "zos_vol_1"
+  sg_name   = "zos_sg"
+  size      = 1113
+  cap_unit  = "CYL"
+  emulation = "CKD-3390"
+}
+
+resource "powermax_cu_image" "cu_image_1" {
+  # Required The ID of the FICON split, use the powermax_split data source to list them
+  split_id = "0"
+
+  # Required The SSID of the CU image
+  cu_image_ssid = "F0A0"
+
+  # Required The number of the CU image
+  cu_image_number = "A0"
+
+  # Required The starting base address of the volumes mapped on creation, volumes added later use the next available base address
+  start_base_address = "00"
+
+  # Required The CKD volumes mapped to the CU image
+  volume_ids = [powermax_volume.ckd_volume.id]
+
+  # Optional The alias range of the CU image, both addresses must be set together
+  start_alias_address = "F0"
+  end_alias_address   = "FF"
+}
+
+# After the execution of above resource block, the CU image would have been created in the PowerMax array.
+# Use the `terraform show` command to review the current state
@@ -14,7 +14,12 @@
 # limitations under the License.
 
 # The command is
-# terraform import powermax_snapshot.snapshot_test storage_group.snapshot_name
-# Example: must be storage_group.snapshot_name
+# terraform import powermax_snapshot.snapshot_test <storage_group_name>.<snapshot_name>
+# Example: imports the newest generation of the snapshot
 terraform import powermax_snapshot.snapshot_test storage_group.snapshot_name
-# after running this command, populate the name field in the config file to start managing this resource
\ No newline at end of file
+# A specific generation or snap ID of the snapshot can be imported with
+terraform import powermax_snapshot.snapshot_test storage_group.snapshot_name.generation:1
+terraform import powermax_snapshot.snapshot_test storage_group.snapshot_name.snapid:123456789
+# The resource ID <symmetrix_id>/<storage_group_name>/<snapshot_name>/<snapid> is accepted as well
+terraform import powermax_snapshot.snapshot_test 000000000001/storage_group/snapshot_name/123456789
+# after running this command, populate the name field in the config file to start managing this resource
@@ -17,11 +17,18 @@ limitations under the License.
 
 # Available actions: Create, Update (name, secure, time_to_live, link, restore), Delete and Import an existing snapshot from the PowerMax Array.
 # After `terraform apply` of this example file it will create a new snapshot with the name set in `name` attribute on the PowerMax for the storage group set in the storage group `name` attribute
-# NOTE: that all of the PowerMax `snapshot_actions` are only available during modify of the snapshot after it has been created.
+# NOTE: that all of the PowerMax `snapshot_actions` except `link` are only available during modify of the snapshot after it has been created.
 
 # PowerMax Snaphots is a local replication solution that is designed to nondisruptively create point-in-time copies (snapshots) of critical data.
 resource "powermax_snapshot" "snapshot_1" {
 
+  # Optional, how the snapshot is deleted, defaults to "standard"
+  # "standard" fails when the snapshot is still linked
+  # "unlink" unlinks all the linked storage groups before deleting the snapshot
+  # "unlink_after_copy" waits for the copy to the linked storage groups to complete before unlinking them
+  # An active restore session is always terminated and a secure snapshot cannot be deleted before its secure time expires
+  delete_behavior = "standard"
+
   # Attributes which are able to be modified after create (secure, time_to_live, link, restore)
 
   # Required The storage group that the snapshot will be taken upon 
@@ -74,6 +81,14 @@ resource "powermax_snapshot" "snapshot_1" {
       remote = false
       # Sets the link copy mode to perform background copy to the target volume(s).
       copy = false
+      # Relinks the target storage group to this snapshot generation without unlinking it first.
+      relink = false
+      # Waits until the background copy to the target volume(s) has completed. Only allowed when copy is true.
+      wait_for_copy = false
+      # Waits until the link to the target volume(s) has been fully defined.
+      wait_for_defined = false
+      # Number of minutes to wait for the copy or define to complete, defaults to 60.
+      wait_timeout_minutes = 60
     }
 
     # Optional this is only available for modify after the resource is created
@@ -0,0 +1,22 @@
+# Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+# Licensed under the Mozilla Public License Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+
+#     http://mozilla.org/MPL/2.0/
+
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+
+# The command is
+# terraform import powermax_snapshot_policy_attachment.attachment_1 <storage_group_name>.<snapshot_policy_name>
+# Example:
+terraform import powermax_snapshot_policy_attachment.attachment_1 app_sg.daily_snapshots
+# after running this command, populate the storage_group_name and snapshot_policy_name fields in the config file to start managing this resource
@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (suspended), Delete and Import an existing storage group snapshot policy association from the PowerMax Array.
+# After `terraform apply` of this example file it will attach the storage group set in `storage_group_name` to the snapshot policy set in `snapshot_policy_name`
+# The association is made from the storage group side, so the snapshot policy can be managed by another team or configuration.
+# Leave `storage_groups` unset on the powermax_snapshotpolicy resource of a policy which storage groups are attached to with this resource.
+
+# The storage group and snapshot policy cannot be changed after the attachment has been created.
+resource "powermax_snapshot_policy_attachment" "attachment_1" {
+  # Required The storage group to protect
+  storage_group_name = "app_sg"
+
+  # Required The snapshot policy to attach the storage group to
+  snapshot_policy_name = "daily_snapshots"
+
+  # Optional Suspend the snapshot policy for this storage group only
+  suspended = false
+}
+
+# After the execution of above resource block, the compliance of the storage group for the policy is in the `compliance` attribute.
+# Use the `terraform show` command to review the current state
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (confirm, require_unmasked, restore_window, wait_timeout_minutes, terminate_after_restore) and Delete.
+# After `terraform apply` of this example file it will restore the snapshot generation set in `snapid` over the data of the storage group set in `storage_group_name`
+# The restore is done once, when the resource is created. Replace the resource to restore again.
+
+# Changing the storage group, snapshot name, snapid or remote replaces the resource and restores again.
+resource "powermax_snapshot_restore" "restore_1" {
+  # Required The storage group to restore to
+  storage_group_name = "snapshot_restore_sg"
+
+  # Required The snapshot and generation to restore
+  snapshot_name = "daily_backup"
+  snapid        = 123456789
+
+  # Required Set to the storage group name to confirm that its data will be overwritten
+  confirm = "snapshot_restore_sg"
+
+  # Optional, defaults to false. Acknowledges that the data is propagated to the remote mirror of SRDF volumes
+  remote = false
+
+  # Optional, defaults to true. Refuse the restore while the storage group is in a masking view
+  require_unmasked = true
+
+  # Optional Only restore inside this UTC window, the window wraps around midnight when end is before start
+  restore_window = {
+    start = "22:00"
+    end   = "02:00"
+  }
+
+  # Optional, defaults to 60. The number of minutes to wait for the restore to complete
+  wait_timeout_minutes = 60
+
+  # Optional, defaults to true. Terminate the restore session once the restore has completed
+  terminate_after_restore = true
+}
+
+# After the execution of above resource block, the restore completion time is in the `restore_completed_at` attribute.
+# Use the `terraform show` command to review the current state
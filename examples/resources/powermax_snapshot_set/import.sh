@@ -0,0 +1,22 @@
+# Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+# Licensed under the Mozilla Public License Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+
+#     http://mozilla.org/MPL/2.0/
+
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+# The command is
+# terraform import powermax_snapshot_set.snapshot_set_1 <storage_group_name>.<snapshot_name>
+# Example:
+terraform import powermax_snapshot_set.snapshot_set_1 snapshot_set_sg.daily_backup
+# after running this command, the retention_count is set to the number of existing generations
+# populate the storage_group_name, snapshot_name and retention_count fields in the config file to start managing this resource
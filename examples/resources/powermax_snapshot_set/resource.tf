@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (retention_count, time_to_live, time_in_hours, rotate_on_apply, triggers), Delete and Import existing snapshot generations from the PowerMax Array.
+# After `terraform apply` of this example file it will create a new generation of the snapshot set in `snapshot_name` on the storage group set in `storage_group_name`
+# Every later `terraform apply` creates another generation and terminates the oldest generations beyond `retention_count`
+
+# The storage group and snapshot name cannot be changed after the snapshot set has been created.
+resource "powermax_snapshot_set" "snapshot_set_1" {
+  # Required The storage group to take the snapshot generations of
+  storage_group_name = "snapshot_set_sg"
+
+  # Required The name of the snapshot
+  # Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed.
+  snapshot_name = "daily_backup"
+
+  # Required The number of generations to keep
+  retention_count = 7
+
+  # Optional The time to live of the new generations, in days unless time_in_hours is true
+  time_to_live  = 14
+  time_in_hours = false
+
+  # Optional, defaults to true. Set to false to only create a new generation when triggers change
+  rotate_on_apply = true
+
+  # Optional Arbitrary values which create a new generation when they change
+  # For example, take a backup before each application upgrade
+  triggers = {
+    app_version = "1.2.3"
+  }
+}
+
+# After the execution of above resource block, the generations of the snapshot are listed newest first in the `generations` attribute.
+# Use the `terraform show` command to review the current state
@@ -11,7 +11,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-# Available actions: Create, Update (snapshot_policy_name, storage_groups, interval, snapshot_count, compliance_count_critical, compliance_count_warning, offset_minutes, secure), Delete and Import an existing snapshot policy from the PowerMax Array.
+# Available actions: Create, Update (snapshot_policy_name, storage_groups, interval, snapshot_count, compliance_count_critical, compliance_count_warning, offset_minutes, suspended), Delete and Import an existing snapshot policy from the PowerMax Array.
 # After `terraform apply` of this example file it will create a new snapshot policy with the name set in `snapshot_policy_name` attribute on the PowerMax
 
 # PowerMax snapshot policy feature provides snapshot orchestration at scale (1,024 snaps per storage group).
@@ -20,7 +20,7 @@ limitations under the License.
 
 resource "powermax_snapshotpolicy" "terraform_sp" {
 
-  # Attributes which are able to be modified after create (snapshot_policy_name, storage_groups, interval, snapshot_count, compliance_count_critical, compliance_count_warning, offset_minutes, secure)
+  # Attributes which are able to be modified after create (snapshot_policy_name, storage_groups, interval, snapshot_count, compliance_count_critical, compliance_count_warning, offset_minutes, suspended)
 
   # Required Field will become the name of the snapshot policy
   snapshot_policy_name = "terraform_sp"
@@ -47,8 +47,17 @@ resource "powermax_snapshotpolicy" "terraform_sp" {
   # offset_minutes            = 420
 
   # The snapshot policy will create secure snapshots
+  # Changing secure replaces the snapshot policy
   # secure = false
 
+  # Suspend the snapshot policy, no snapshots are taken while it is suspended
+  # Set it back to false to resume the snapshot policy, for example at the end of a maintenance window
+  # suspended = false
+
+  # Cloud snapshot policies only, changing them replaces the snapshot policy
+  # provider_name  = "cloud_provider_1"
+  # retention_days = 30
+
 }
 
 # After the execution of above resource block, a PowerMax snapshot policy has been created at PowerMax array.
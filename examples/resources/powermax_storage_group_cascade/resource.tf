@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (child_storage_group_names, enable_compliance_alerts, force_remove), Delete and Import the child storage groups of a cascaded storage group from the PowerMax Array.
+# After `terraform apply` of this example file the storage groups set in `child_storage_group_names` will be the children of the storage group set in `parent_storage_group_name`
+# The parent storage group must not contain volumes or have a service level, set the service level on the children and mask the parent.
+# Destroying this resource removes the children from the parent, the storage groups themselves are kept.
+
+# The parent storage group cannot be changed after the cascade has been created.
+resource "powermax_storage_group_cascade" "cascade_1" {
+  # Required The storage group to cascade
+  parent_storage_group_name = "app_parent_sg"
+
+  # Required The child storage groups, children added outside of Terraform are removed on the next apply
+  child_storage_group_names = ["app_data_sg", "app_log_sg"]
+
+  # Optional Enable compliance alerts on the child storage groups when they are added
+  enable_compliance_alerts = false
+
+  # Optional Remove child storage groups even when the parent is in a masking view
+  force_remove = false
+}
+
+# After the execution of above resource block, the children of the storage group would have been updated in the PowerMax array.
+# Use the `terraform show` command to review the current state
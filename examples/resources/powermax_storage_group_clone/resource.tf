@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (action, force, star, skip), Delete and Import an existing clone session from the PowerMax Array.
+# After `terraform apply` of this example file it will establish a clone session from the storage group set in `storage_group_name` to the storage group set in `target_storage_group_name`
+
+# The target storage group must exist and contain volumes matching the volumes of the source storage group.
+# Changing the source storage group, the target storage group, consistent or vse will terminate the clone session and establish a new one.
+resource "powermax_storage_group_clone" "clone_1" {
+
+  # Required The name of the source storage group
+  storage_group_name = "clone_source_sg"
+
+  # Required The name of the target storage group
+  target_storage_group_name = "clone_target_sg"
+
+  # Optional The action to perform on the clone session, the action is performed when its value changes
+  # Activate: set the pairs to copy mode so the background copy runs
+  # Recreate: incrementally re-establish the pairs to refresh the target
+  # Restore: copy the target back to the source
+  # Split: split the pairs
+  # action = "Split"
+
+  # Optional Establish options, changing them requires a new clone session
+  # consistent = false
+  # vse        = false
+
+  # Optional Options used by every clone operation
+  # force = false
+  # star  = false
+  # skip  = false
+}
+
+# After the execution of above resource block, the clone session has been established at PowerMax array.
+# The copy progress is reported in the `state`, `modified_tracks` and `volume_pairs` attributes, use the `terraform show` command to review the current state
@@ -15,14 +15,14 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-# Available actions: Create, Update (name, compression, host_io_limit, workload, slo, srp_id, volume_ids), Delete and Import an existing storage group from the PowerMax Array.
+# Available actions: Create, Update (name, compression, host_io_limit, workload, slo, srp_id, volume_ids, tags), Delete and Import an existing storage group from the PowerMax Array.
 # After `terraform apply` of this example file it will create a new storage group with the name set in `name` attribute on the PowerMax
 
 # PowerMax storage groups are a collection of devices that are stored on the array.
 # An application, a server, or a collection of servers use them.
 resource "powermax_storagegroup" "test" {
 
-  # Attributes which are able to be modified after create (name, compression, host_io_limit, workload, slo, srp_id, volume_ids)
+  # Attributes which are able to be modified after create (name, compression, host_io_limit, workload, slo, srp_id, volume_ids, tags)
 
   # Required the name of the new storage group
   name = "terraform_sg"
@@ -39,18 +39,39 @@ resource "powermax_storagegroup" "test" {
   # Optional the workload of the storage group
   workload = "workload"
 
-  # Optional Set Host I/O limits for the specified storage sroup
+  # Optional Set Host I/O limits for the specified storage sroup, removing it removes the limits from the storage group
   host_io_limit = {
-    # The MBs per Second Host IO limit for the specified storage group, NOLIMIT means no limits
+    # The IOs per Second Host IO limit for the specified storage group, a multiple of 100, NOLIMIT means no limits
     host_io_limit_io_sec = "1000"
-    # The IOs per Second Host IO limit for the specified storage group , NOLIMIT means no limits
+    # The MBs per Second Host IO limit for the specified storage group, NOLIMIT means no limits
     host_io_limit_mb_sec = "1000"
     # The dynamic distribution type which can be "Never","Always" or "OnFailure"
     dynamic_distribution = "Never"
   }
 
+  # Optional the tags of the storage group, when not set the tags on the array are left as they are
+  tags = ["chargeback_finance"]
+
   # Optional a list of volume ids to be added to the storage groups
   volume_ids = ["0008F"]
+
+  # Optional create new volumes together with the storage group, it can not be set with volume_ids
+  # It is only used on create, later changes are ignored with a warning
+  # volume_attributes = [
+  #   {
+  #     # Required the size of each volume
+  #     size = 10
+  #     # Optional the capacity unit of the size, can be MB, GB, TB or CYL (Default to GB)
+  #     cap_unit = "GB"
+  #     # Required the number of volumes to create
+  #     num_of_vols = 2
+  #     # Optional the identifier of the created volumes
+  #     volume_identifier = "terraform_sg_vol"
+  #   }
+  # ]
+
+  # Optional allocate the full capacity of the volumes created with the storage group (thick volumes), only used on create
+  # allocate_capacity_for_each_vol = true
 }
 
 # After the execution of above resource block, a PowerMax storage group has been created at PowerMax array.
@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (enabled), Delete and Import an existing virtual witness from the PowerMax Array.
+# After `terraform apply` of this example file it will add a new virtual witness with the name set in `name` attribute on the PowerMax
+
+# A virtual witness (vWitness) is a witness instance running outside of the arrays that acts as the arbiter for SRDF/Metro sessions when the arrays lose contact with each other.
+# Changing the name, location or port of a virtual witness will remove it and add it again with the new values.
+resource "powermax_virtual_witness" "virtual_witness_1" {
+
+  # Attributes which are able to be modified after create (enabled)
+
+  # Required The name of the virtual witness. Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed.
+  name = "vwitness_1"
+
+  # Required The IP address or fully qualified domain name of the virtual witness
+  location = "10.0.0.1"
+
+  # Optional The port number the virtual witness listens on
+  # port = 10123
+
+  # Optional Whether the virtual witness is enabled, defaults to true
+  enabled = true
+}
+
+# After the execution of above resource block, a virtual witness has been added to the PowerMax array.
+# For more information about the newly created resource use the `terraform show` command to review the current state
@@ -15,13 +15,13 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-# Available actions: Create, Update (vol_name, mobility_id_enabled, size, cap_unit), Delete and Import an existing volume from the PowerMax Array.
+# Available actions: Create, Update (vol_name, mobility_id_enabled, size, cap_unit, sg_name, additional_sg_names, force_move, free_before_delete, free_timeout_minutes), Delete and Import an existing volume from the PowerMax Array.
 # After `terraform apply` of this example file it will create a new volume with the name set in `vol_name` attribute on the PowerMax
 
 # PowerMax volumes is an identifiable unit of data storage. Storage groups are sets of volumes.
 resource "powermax_volume" "test" {
 
-  # Attributes which are able to be modified after create (vol_name, mobility_id_enabled, size, cap_unit)
+  # Attributes which are able to be modified after create (vol_name, mobility_id_enabled, size, cap_unit, sg_name, additional_sg_names, force_move, free_before_delete, free_timeout_minutes)
 
   # Required name of the volume to be created
   vol_name = "terraform_volume"
@@ -30,8 +30,20 @@ resource "powermax_volume" "test" {
   size = 2.45
 
   # Required name of the storage group which the volume will be created with
+  # Changing it moves the volume to the new storage group, the volume is not recreated
   sg_name = "terraform_sg"
 
+  # Optional other storage groups the volume is also a member of, for example a quorum disk shared by the hosts of a cluster
+  # Removing a storage group from the list only removes the volume from it
+  additional_sg_names = ["terraform_sg_node2"]
+
+  # Optional force moving the volume when the storage groups are in masking views
+  force_move = false
+
+  # Optional Default emulation is FBA
+  # Possible emulations are FBA, CKD-3390 and CKD-3380, CKD volumes must use the CYL unit
+  emulation = "FBA"
+
   # Optional Default Unit is GB
   # Possible units are MB, GB, TB, and CYL
   cap_unit = "GB"
@@ -39,6 +51,20 @@ resource "powermax_volume" "test" {
   # Optional enable the mobility id 
   mobility_id_enabled = false
 
+  # Optional free the allocations of the volume on destroy, and wait until they are freed before deleting it
+  # The volume is kept when it could not be freed within free_timeout_minutes, default 30
+  free_before_delete   = true
+  free_timeout_minutes = 30
+
+  # Optional storage groups of the SRDF remote arrays to create the paired volumes in when sg_name is protected by SRDF
+  # At most two remote arrays, only used when the volume is created
+  # remote_storage_groups = [
+  #   {
+  #     symmetrix_id        = "000000000001"
+  #     storage_group_names = ["terraform_sg_r2"]
+  #   }
+  # ]
+
 }
 
 # After the execution of above resource block, a PowerMax volume has been created at PowerMax array.
@@ -0,0 +1,50 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (volume_count, size, cap_unit, mobility_id_enabled), Delete and Import a set of identical volumes from the PowerMax Array.
+# After `terraform apply` of this example file it will create `volume_count` volumes in the storage group set in `sg_name` with a single request,
+# named after `name_pattern`: ora_data_001, ora_data_002 and so on.
+# Growing the set adds volumes at the end, shrinking it deletes the volumes at the end. Changing the size expands all the volumes of the set.
+
+# The storage group, name pattern and start index cannot be changed after the set has been created.
+resource "powermax_volume_set" "ora_data" {
+  # Required The storage group to create the volumes in
+  sg_name = "ora_sg"
+
+  # Required The naming pattern of the volumes, with one integer verb for the index of the volume
+  # A pattern ending with a plain %d, such as ora_data_%d, lets the array name the volumes when it creates them,
+  # other patterns take one rename request per new volume
+  name_pattern = "ora_data_%03d"
+
+  # Required The number of volumes
+  volume_count = 200
+
+  # Required The size of each volume, volumes can only be expanded
+  size = 50
+
+  # Optional The unit of the size, one of MB, GB, TB or CYL, default GB
+  cap_unit = "GB"
+
+  # Optional The index of the first volume, default 1
+  start_index = 1
+
+  # Optional Enable mobility ID on the volumes
+  mobility_id_enabled = false
+}
+
+# After the execution of above resource block, the IDs of the volumes are in the `volumes` attribute, ordered by index.
+# Use the `terraform show` command to review the current state
@@ -179,4 +179,175 @@ const (
 
 	// UpdateSnapshotPolicy specifies error while updating snapshot policy.
 	UpdateSnapshotPolicy = "Could not update the snapshot policy"
+
+	// CreateVirtualWitnessDetailErrorMsg specifies error details occurred while creating virtual witness.
+	CreateVirtualWitnessDetailErrorMsg = "Could not create virtual witness "
+
+	// ReadVirtualWitnessDetailsErrorMsg specifies error details occurred while reading virtual witness.
+	ReadVirtualWitnessDetailsErrorMsg = "Could not read virtual witness "
+
+	// UpdateVirtualWitnessDetailsErrorMsg specifies error details occurred while updating virtual witness.
+	UpdateVirtualWitnessDetailsErrorMsg = "Could not update virtual witness "
+
+	// DeleteVirtualWitnessDetailsErrorMsg specifies error details occurred while deleting virtual witness.
+	DeleteVirtualWitnessDetailsErrorMsg = "Could not delete virtual witness "
+
+	// CreateSgCloneDetailErrorMsg specifies error details occurred while creating storage group clone.
+	CreateSgCloneDetailErrorMsg = "Could not create clone of storage group "
+
+	// ReadSgCloneDetailsErrorMsg specifies error details occurred while reading storage group clone.
+	ReadSgCloneDetailsErrorMsg = "Could not read clone of storage group "
+
+	// UpdateSgCloneDetailsErrorMsg specifies error details occurred while updating storage group clone.
+	UpdateSgCloneDetailsErrorMsg = "Could not update clone of storage group "
+
+	// DeleteSgCloneDetailsErrorMsg specifies error details occurred while deleting storage group clone.
+	DeleteSgCloneDetailsErrorMsg = "Could not terminate clone of storage group "
+
+	// ImportSgCloneDetailsErrorMsg specifies error details occurred while importing storage group clone.
+	ImportSgCloneDetailsErrorMsg = "Could not import storage group clone "
+
+	// CreateCloudSnapshotDetailErrorMsg specifies error details occurred while creating cloud snapshot.
+	CreateCloudSnapshotDetailErrorMsg = "Could not create cloud snapshot "
+
+	// ReadCloudSnapshotDetailsErrorMsg specifies error details occurred while reading cloud snapshot.
+	ReadCloudSnapshotDetailsErrorMsg = "Could not read cloud snapshot "
+
+	// UpdateCloudSnapshotDetailsErrorMsg specifies error details occurred while updating cloud snapshot.
+	UpdateCloudSnapshotDetailsErrorMsg = "Could not update cloud snapshot "
+
+	// DeleteCloudSnapshotDetailsErrorMsg specifies error details occurred while deleting cloud snapshot.
+	DeleteCloudSnapshotDetailsErrorMsg = "Could not delete cloud snapshot "
+
+	// ReadCloudSnapshots specifies error while reading cloud snapshots.
+	ReadCloudSnapshots = "Could not read cloud snapshots"
+
+	// CreateCloudProviderDetailErrorMsg specifies error details occurred while creating cloud provider.
+	CreateCloudProviderDetailErrorMsg = "Could not create cloud provider "
+
+	// ReadCloudProviderDetailsErrorMsg specifies error details occurred while reading cloud provider.
+	ReadCloudProviderDetailsErrorMsg = "Could not read cloud provider "
+
+	// UpdateCloudProviderDetailsErrorMsg specifies error details occurred while updating cloud provider.
+	UpdateCloudProviderDetailsErrorMsg = "Could not update cloud provider "
+
+	// DeleteCloudProviderDetailsErrorMsg specifies error details occurred while deleting cloud provider.
+	DeleteCloudProviderDetailsErrorMsg = "Could not delete cloud provider "
+
+	// CreateCloudSystemDNSServerDetailErrorMsg specifies error details occurred while creating cloud system DNS server.
+	CreateCloudSystemDNSServerDetailErrorMsg = "Could not create cloud system DNS server "
+
+	// ReadCloudSystemDNSServerDetailsErrorMsg specifies error details occurred while reading cloud system DNS server.
+	ReadCloudSystemDNSServerDetailsErrorMsg = "Could not read cloud system DNS server "
+
+	// UpdateCloudSystemDNSServerDetailsErrorMsg specifies error details occurred while updating cloud system DNS server.
+	UpdateCloudSystemDNSServerDetailsErrorMsg = "Could not update cloud system DNS server "
+
+	// DeleteCloudSystemDNSServerDetailsErrorMsg specifies error details occurred while deleting cloud system DNS server.
+	DeleteCloudSystemDNSServerDetailsErrorMsg = "Could not delete cloud system DNS server "
+
+	// CreateCloudSystemRouteDetailErrorMsg specifies error details occurred while creating cloud system route.
+	CreateCloudSystemRouteDetailErrorMsg = "Could not create cloud system route "
+
+	// ReadCloudSystemRouteDetailsErrorMsg specifies error details occurred while reading cloud system route.
+	ReadCloudSystemRouteDetailsErrorMsg = "Could not read cloud system route "
+
+	// UpdateCloudSystemRouteDetailsErrorMsg specifies error details occurred while updating cloud system route.
+	UpdateCloudSystemRouteDetailsErrorMsg = "Could not update cloud system route "
+
+	// DeleteCloudSystemRouteDetailsErrorMsg specifies error details occurred while deleting cloud system route.
+	DeleteCloudSystemRouteDetailsErrorMsg = "Could not delete cloud system route "
+
+	// CreateCloudSystemTeamDetailErrorMsg specifies error details occurred while creating cloud system team.
+	CreateCloudSystemTeamDetailErrorMsg = "Could not create cloud system team "
+
+	// ReadCloudSystemTeamDetailsErrorMsg specifies error details occurred while reading cloud system team.
+	ReadCloudSystemTeamDetailsErrorMsg = "Could not read cloud system team "
+
+	// UpdateCloudSystemTeamDetailsErrorMsg specifies error details occurred while updating cloud system team.
+	UpdateCloudSystemTeamDetailsErrorMsg = "Could not update cloud system team "
+
+	// DeleteCloudSystemTeamDetailsErrorMsg specifies error details occurred while deleting cloud system team.
+	DeleteCloudSystemTeamDetailsErrorMsg = "Could not delete cloud system team "
+
+	// ReadCloudProviders specifies error while reading cloud providers.
+	ReadCloudProviders = "Could not read cloud providers"
+
+	// ReadSnapshotCompliance specifies error while reading snapshot compliance.
+	ReadSnapshotCompliance = "Could not read snapshot compliance"
+
+	// CreateSnapshotSetDetailErrorMsg specifies error details occurred while creating a snapshot set generation.
+	CreateSnapshotSetDetailErrorMsg = "Could not create snapshot set generation "
+
+	// ReadSnapshotSetDetailsErrorMsg specifies error details occurred while reading snapshot set.
+	ReadSnapshotSetDetailsErrorMsg = "Could not read snapshot set "
+
+	// TerminateSnapshotSetDetailsErrorMsg specifies error details occurred while terminating the generations beyond the retention count.
+	TerminateSnapshotSetDetailsErrorMsg = "Could not terminate old generations of snapshot set "
+
+	// DeleteSnapshotSetDetailsErrorMsg specifies error details occurred while deleting snapshot set.
+	DeleteSnapshotSetDetailsErrorMsg = "Could not delete snapshot set "
+
+	// RestoreSnapshotDetailErrorMsg specifies error details occurred while restoring a snapshot.
+	RestoreSnapshotDetailErrorMsg = "Could not restore snapshot "
+
+	// TerminateSnapshotRestoreDetailErrorMsg specifies error details occurred while terminating a snapshot restore session.
+	TerminateSnapshotRestoreDetailErrorMsg = "Could not terminate the restore session of snapshot "
+
+	// ReadSnapshotHistory specifies error while reading the snapshot history.
+	ReadSnapshotHistory = "Could not read the snapshot history"
+
+	// ReadSnapshotPolicyStorageGroupsErrorMsg specifies error details occurred while reading the storage groups of a snapshot policy.
+	ReadSnapshotPolicyStorageGroupsErrorMsg = "Could not read the storage groups of snapshot policy "
+
+	// AttachSnapshotPolicyDetailErrorMsg specifies error details occurred while attaching a snapshot policy to a storage group.
+	AttachSnapshotPolicyDetailErrorMsg = "Could not attach snapshot policy "
+
+	// DetachSnapshotPolicyDetailErrorMsg specifies error details occurred while detaching a snapshot policy from a storage group.
+	DetachSnapshotPolicyDetailErrorMsg = "Could not detach snapshot policy "
+
+	// ReadSnapshotPolicyAttachmentDetailErrorMsg specifies error details occurred while reading a snapshot policy attachment.
+	ReadSnapshotPolicyAttachmentDetailErrorMsg = "Could not read the attachment of snapshot policy "
+
+	// AddChildStorageGroupsDetailErrorMsg specifies error details occurred while adding child storage groups to a parent storage group.
+	AddChildStorageGroupsDetailErrorMsg = "Could not add child storage groups to storage group "
+
+	// RemoveChildStorageGroupsDetailErrorMsg specifies error details occurred while removing child storage groups from a parent storage group.
+	RemoveChildStorageGroupsDetailErrorMsg = "Could not remove child storage groups from storage group "
+
+	// ReadStorageGroupCascadeDetailErrorMsg specifies error details occurred while reading the child storage groups of a parent storage group.
+	ReadStorageGroupCascadeDetailErrorMsg = "Could not read the child storage groups of storage group "
+
+	// CreateVolumeSetDetailErrorMsg specifies error details occurred while creating a volume set.
+	CreateVolumeSetDetailErrorMsg = "Could not create volume set "
+
+	// UpdateVolumeSetDetailErrorMsg specifies error details occurred while updating a volume set.
+	UpdateVolumeSetDetailErrorMsg = "Could not update volume set "
+
+	// ReadVolumeSetDetailErrorMsg specifies error details occurred while reading a volume set.
+	ReadVolumeSetDetailErrorMsg = "Could not read volume set "
+
+	// DeleteVolumeSetDetailErrorMsg specifies error details occurred while deleting a volume set.
+	DeleteVolumeSetDetailErrorMsg = "Could not delete volume set "
+
+	// CreateCuImageDetailErrorMsg specifies error details occurred while creating a CU image.
+	CreateCuImageDetailErrorMsg = "Could not create CU image "
+
+	// UpdateCuImageDetailErrorMsg specifies error details occurred while updating a CU image.
+	UpdateCuImageDetailErrorMsg = "Could not update CU image "
+
+	// ReadCuImageDetailErrorMsg specifies error details occurred while reading a CU image.
+	ReadCuImageDetailErrorMsg = "Could not read CU image "
+
+	// DeleteCuImageDetailErrorMsg specifies error details occurred while deleting a CU image.
+	DeleteCuImageDetailErrorMsg = "Could not delete CU image "
+
+	// ReadCuImages specifies error while reading CU images.
+	ReadCuImages = "Could not read CU images"
+
+	// ReadSplits specifies error while reading FICON splits.
+	ReadSplits = "Could not read splits"
+
+	// ReadTags specifies error while reading tags.
+	ReadTags = "Could not read tags"
 )
@@ -0,0 +1,267 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"net/http"
+	"strconv"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// int32Pointer converts an optional int64 attribute to an int32 pointer.
+func int32Pointer(value types.Int64) *int32 {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	v := int32(value.ValueInt64())
+	return &v
+}
+
+// portStringPointer converts an optional port attribute to the string pointer used by the modify API.
+func portStringPointer(value types.Int64) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	v := strconv.FormatInt(value.ValueInt64(), 10)
+	return &v
+}
+
+// changedString returns the plan value only when it differs from the state value.
+func changedString(plan types.String, state types.String) *string {
+	if plan.Equal(state) {
+		return nil
+	}
+	return plan.ValueStringPointer()
+}
+
+// changedBool returns the plan value only when it differs from the state value.
+func changedBool(plan types.Bool, state types.Bool) *bool {
+	if plan.Equal(state) {
+		return nil
+	}
+	return plan.ValueBoolPointer()
+}
+
+// changedPort returns the plan port only when it differs from the state port.
+func changedPort(plan types.Int64, state types.Int64) *string {
+	if plan.Equal(state) {
+		return nil
+	}
+	return portStringPointer(plan)
+}
+
+// CreateCloudProvider creates a new cloud provider.
+func CreateCloudProvider(ctx context.Context, client client.Client, plan models.CloudProviderResourceModel) (*http.Response, error) {
+	connectionDetails := pmax.CloudProviderConnectionDetails{}
+	if plan.Ecs != nil {
+		connectionDetails.EcsConnectionDetails = &pmax.EcsConnectionDetails{
+			Key:    plan.Ecs.Key.ValueStringPointer(),
+			Secret: plan.Ecs.Secret.ValueStringPointer(),
+			Node:   plan.Ecs.Node.ValueStringPointer(),
+			Secure: plan.Ecs.Secure.ValueBoolPointer(),
+			Port:   int32Pointer(plan.Ecs.Port),
+			Bucket: plan.Ecs.Bucket.ValueStringPointer(),
+		}
+	}
+	if plan.Azure != nil {
+		connectionDetails.AzureConnectionDetails = &pmax.AzureConnectionDetails{
+			StorageAccount: plan.Azure.StorageAccount.ValueStringPointer(),
+			ManagedKey:     plan.Azure.ManagedKey.ValueStringPointer(),
+			Secure:         plan.Azure.Secure.ValueBoolPointer(),
+			Url:            plan.Azure.URL.ValueStringPointer(),
+			Port:           int32Pointer(plan.Azure.Port),
+			Container:      plan.Azure.Container.ValueStringPointer(),
+		}
+	}
+	if plan.Amazon != nil {
+		connectionDetails.AmazonConnectionDetails = &pmax.AmazonConnectionDetails{
+			Key:          plan.Amazon.Key.ValueStringPointer(),
+			Secret:       plan.Amazon.Secret.ValueStringPointer(),
+			Secure:       plan.Amazon.Secure.ValueBoolPointer(),
+			Bucket:       plan.Amazon.Bucket.ValueStringPointer(),
+			Node:         plan.Amazon.Node.ValueStringPointer(),
+			Port:         int32Pointer(plan.Amazon.Port),
+			StorageClass: plan.Amazon.StorageClass.ValueStringPointer(),
+			Region:       plan.Amazon.Region.ValueStringPointer(),
+		}
+	}
+	createParam := pmax.NewCreateCloudProviderParam(plan.Name.ValueString(), connectionDetails)
+	return client.PmaxOpenapiClient.SystemApi.CreateCloudProvider(ctx, client.SymmetrixID).CreateCloudProviderParam(*createParam).Execute()
+}
+
+// GetCloudProvider gets the details of a cloud provider.
+func GetCloudProvider(ctx context.Context, client client.Client, name string) (*pmax.CloudProvider, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.GetCloudProvider(ctx, client.SymmetrixID, name).Execute()
+}
+
+// ListCloudProviders gets the names of all cloud providers on the array.
+func ListCloudProviders(ctx context.Context, client client.Client) (*pmax.CloudProviderList, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.ListCloudProviders(ctx, client.SymmetrixID).Execute()
+}
+
+// ModifyCloudProvider renames a cloud provider and updates the connection details which changed between the state and the plan.
+func ModifyCloudProvider(ctx context.Context, client client.Client, plan models.CloudProviderResourceModel, state models.CloudProviderResourceModel) (*pmax.CloudSystem, *http.Response, error) {
+	modifyParam := pmax.ModifyCloudProviderParam{
+		NewCloudProviderName: changedString(plan.Name, state.Name),
+	}
+	if plan.Ecs != nil && state.Ecs != nil {
+		modifyParam.ModifyCloudProviderConnectionDetails.ModifyEcsConnectionDetails = &pmax.ModifyEcsConnectionDetails{
+			Key:    changedString(plan.Ecs.Key, state.Ecs.Key),
+			Secret: changedString(plan.Ecs.Secret, state.Ecs.Secret),
+			Node:   changedString(plan.Ecs.Node, state.Ecs.Node),
+			Secure: changedBool(plan.Ecs.Secure, state.Ecs.Secure),
+			Port:   changedPort(plan.Ecs.Port, state.Ecs.Port),
+		}
+	}
+	if plan.Azure != nil && state.Azure != nil {
+		modifyParam.ModifyCloudProviderConnectionDetails.ModifyAzureConnectionDetails = &pmax.ModifyAzureConnectionDetails{
+			StorageAccount: changedString(plan.Azure.StorageAccount, state.Azure.StorageAccount),
+			ManagedKey:     changedString(plan.Azure.ManagedKey, state.Azure.ManagedKey),
+			Secure:         changedBool(plan.Azure.Secure, state.Azure.Secure),
+			Url:            changedString(plan.Azure.URL, state.Azure.URL),
+			Port:           changedPort(plan.Azure.Port, state.Azure.Port),
+		}
+	}
+	if plan.Amazon != nil && state.Amazon != nil {
+		modifyParam.ModifyCloudProviderConnectionDetails.ModifyAmazonConnectionDetails = &pmax.ModifyAmazonConnectionDetails{
+			Key:    changedString(plan.Amazon.Key, state.Amazon.Key),
+			Secret: changedString(plan.Amazon.Secret, state.Amazon.Secret),
+			Secure: changedBool(plan.Amazon.Secure, state.Amazon.Secure),
+			Port:   changedPort(plan.Amazon.Port, state.Amazon.Port),
+		}
+	}
+	editParam := pmax.NewEditCloudProviderParam(pmax.EditCloudProviderActionParam{
+		ModifyCloudProvider: &modifyParam,
+	})
+	return client.PmaxOpenapiClient.SystemApi.ModifyCloudProvider(ctx, client.SymmetrixID, state.Name.ValueString()).EditCloudProviderParam(*editParam).Execute()
+}
+
+// DeleteCloudProvider deletes a cloud provider.
+func DeleteCloudProvider(ctx context.Context, client client.Client, name string) (*http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.DeleteCloudProvider(ctx, client.SymmetrixID, name).Execute()
+}
+
+// UpdateCloudProviderState updates the cloud provider state from the API response.
+// The connection details are kept as configured since the array does not return the credentials.
+func UpdateCloudProviderState(state *models.CloudProviderResourceModel, cloudProvider *pmax.CloudProvider) {
+	state.ID = types.StringValue(cloudProvider.CloudProviderId)
+	state.Name = types.StringValue(cloudProvider.CloudProviderId)
+	state.UUID = types.StringValue(cloudProvider.GetUuid())
+	state.State = types.StringValue(cloudProvider.GetState())
+	state.Type = types.StringValue(cloudProvider.GetType())
+	state.UsedCapacityGb = types.Float64Value(cloudProvider.GetUsedCapacityGb())
+	state.NumOfCloudPolicies = types.Int64Value(int64(cloudProvider.GetNumOfCloudPolicies()))
+	state.NumOfCloudSnapshots = types.Int64Value(int64(cloudProvider.GetNumOfCloudSnapshots()))
+	state.NumOfCloudStorageGroups = types.Int64Value(int64(cloudProvider.GetNumOfCloudStorageGroups()))
+}
+
+// int64FromInt32 converts an optional int32 returned by the API to an int64 attribute.
+func int64FromInt32(value *int32) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
+
+// ImportCloudProviderConnectionState sets the connection details returned by the array when importing a cloud provider.
+// The credentials are never returned and have to be set in the configuration after the import.
+func ImportCloudProviderConnectionState(state *models.CloudProviderResourceModel, cloudProvider *pmax.CloudProvider) {
+	connection := cloudProvider.CloudProviderConnectionDetails
+	if ecs := connection.EcsConnectionDetails; ecs != nil {
+		state.Ecs = &models.EcsConnectionModel{
+			Key:    types.StringNull(),
+			Secret: types.StringNull(),
+			Node:   types.StringPointerValue(ecs.Node),
+			Secure: types.BoolPointerValue(ecs.Secure),
+			Port:   int64FromInt32(ecs.Port),
+			Bucket: types.StringPointerValue(ecs.Bucket),
+		}
+	}
+	if azure := connection.AzureConnectionDetails; azure != nil {
+		state.Azure = &models.AzureConnectionModel{
+			StorageAccount: types.StringPointerValue(azure.StorageAccount),
+			ManagedKey:     types.StringNull(),
+			Secure:         types.BoolPointerValue(azure.Secure),
+			URL:            types.StringPointerValue(azure.Url),
+			Port:           int64FromInt32(azure.Port),
+			Container:      types.StringPointerValue(azure.Container),
+		}
+	}
+	if amazon := connection.AmazonConnectionDetails; amazon != nil {
+		state.Amazon = &models.AmazonConnectionModel{
+			Key:          types.StringNull(),
+			Secret:       types.StringNull(),
+			Secure:       types.BoolPointerValue(amazon.Secure),
+			Bucket:       types.StringPointerValue(amazon.Bucket),
+			Node:         types.StringPointerValue(amazon.Node),
+			Port:         int64FromInt32(amazon.Port),
+			StorageClass: types.StringPointerValue(amazon.StorageClass),
+			Region:       types.StringPointerValue(amazon.Region),
+		}
+	}
+}
+
+// UpdateCloudProviderDatasourceState updates the cloud provider data source state from the API response, leaving out the credentials.
+func UpdateCloudProviderDatasourceState(detail *models.CloudProviderDetail, cloudProvider *pmax.CloudProvider) {
+	detail.ID = types.StringValue(cloudProvider.CloudProviderId)
+	detail.Name = types.StringValue(cloudProvider.CloudProviderId)
+	detail.UUID = types.StringValue(cloudProvider.GetUuid())
+	detail.State = types.StringValue(cloudProvider.GetState())
+	detail.Type = types.StringValue(cloudProvider.GetType())
+	detail.UsedCapacityGb = types.Float64Value(cloudProvider.GetUsedCapacityGb())
+	detail.NumOfCloudPolicies = types.Int64Value(int64(cloudProvider.GetNumOfCloudPolicies()))
+	detail.NumOfCloudSnapshots = types.Int64Value(int64(cloudProvider.GetNumOfCloudSnapshots()))
+	detail.NumOfCloudStorageGroups = types.Int64Value(int64(cloudProvider.GetNumOfCloudStorageGroups()))
+	detail.Node = types.StringNull()
+	detail.URL = types.StringNull()
+	detail.Port = types.Int64Null()
+	detail.Secure = types.BoolNull()
+	detail.Bucket = types.StringNull()
+	detail.Container = types.StringNull()
+	detail.StorageAccount = types.StringNull()
+	detail.StorageClass = types.StringNull()
+	detail.Region = types.StringNull()
+
+	connection := cloudProvider.CloudProviderConnectionDetails
+	if ecs := connection.EcsConnectionDetails; ecs != nil {
+		detail.Node = types.StringPointerValue(ecs.Node)
+		detail.Secure = types.BoolPointerValue(ecs.Secure)
+		detail.Port = int64FromInt32(ecs.Port)
+		detail.Bucket = types.StringPointerValue(ecs.Bucket)
+	}
+	if azure := connection.AzureConnectionDetails; azure != nil {
+		detail.StorageAccount = types.StringPointerValue(azure.StorageAccount)
+		detail.Secure = types.BoolPointerValue(azure.Secure)
+		detail.URL = types.StringPointerValue(azure.Url)
+		detail.Port = int64FromInt32(azure.Port)
+		detail.Container = types.StringPointerValue(azure.Container)
+	}
+	if amazon := connection.AmazonConnectionDetails; amazon != nil {
+		detail.Secure = types.BoolPointerValue(amazon.Secure)
+		detail.Bucket = types.StringPointerValue(amazon.Bucket)
+		detail.Node = types.StringPointerValue(amazon.Node)
+		detail.Port = int64FromInt32(amazon.Port)
+		detail.StorageClass = types.StringPointerValue(amazon.StorageClass)
+		detail.Region = types.StringPointerValue(amazon.Region)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"net/http"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ActionCloudSnapshotRecover restores a cloud snapshot to a new storage group.
+const ActionCloudSnapshotRecover = "recover"
+
+// CreateCloudSnapshot creates a cloud snapshot of a storage group.
+func CreateCloudSnapshot(ctx context.Context, client client.Client, plan models.CloudSnapshotResourceModel) (*pmax.CloudSnapshot, *http.Response, error) {
+	createParam := pmax.NewCreateCloudSnapshotParam(plan.CloudProviderID.ValueString(), plan.Name.ValueString())
+	if !plan.ExpiryTimeDays.IsNull() && !plan.ExpiryTimeDays.IsUnknown() {
+		createParam.SetExpiryTimeDays(int32(plan.ExpiryTimeDays.ValueInt64()))
+	}
+	return client.PmaxOpenapiClient.ReplicationApi.CreateCloudSnapshot(ctx, client.SymmetrixID, plan.StorageGroup.Name.ValueString()).CreateCloudSnapshotParam(*createParam).Execute()
+}
+
+// GetCloudSnapshot gets the details of a cloud snapshot of a storage group.
+func GetCloudSnapshot(ctx context.Context, client client.Client, sgName string, cloudSnapshotID string) (*pmax.CloudSnapshot, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupCloudSnapshot(ctx, client.SymmetrixID, sgName, cloudSnapshotID).Execute()
+}
+
+// GetStorageGroupCloudSnapshots gets the list of cloud snapshots of a storage group.
+func GetStorageGroupCloudSnapshots(ctx context.Context, client client.Client, sgName string) (*pmax.CloudSnapshotList, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupCloudSnapshots(ctx, client.SymmetrixID, sgName).Execute()
+}
+
+// GetStorageGroupCloudSnapshotSourceVolumes gets the source volumes of a cloud snapshot.
+func GetStorageGroupCloudSnapshotSourceVolumes(ctx context.Context, client client.Client, sgName string, cloudSnapshotID string) (*pmax.SourceVolumeList, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupCloudSnapshotSourceVolumes(ctx, client.SymmetrixID, sgName, cloudSnapshotID).Execute()
+}
+
+// RestoreCloudSnapshot restores a cloud snapshot to a new storage group.
+func RestoreCloudSnapshot(ctx context.Context, client client.Client, sgName string, cloudSnapshotID string, restore models.CloudSnapshotRestore) (*pmax.CloudSnapshot, *http.Response, error) {
+	recoverParam := pmax.NewRecoverCloudSnapshotParam(restore.TargetStorageGroup.ValueString())
+	if !restore.SrpID.IsNull() && !restore.SrpID.IsUnknown() {
+		recoverParam.SetSrpId(restore.SrpID.ValueString())
+	}
+	if !restore.SloID.IsNull() && !restore.SloID.IsUnknown() {
+		recoverParam.SetSloId(restore.SloID.ValueString())
+	}
+	actionParam := pmax.NewEditCloudSnapshotActionParam(ActionCloudSnapshotRecover)
+	actionParam.SetRecover(*recoverParam)
+	editParam := pmax.NewEditCloudSnapshotParam(*actionParam)
+	return client.PmaxOpenapiClient.ReplicationApi.ModifyCloudSnapshot(ctx, client.SymmetrixID, sgName, cloudSnapshotID).EditCloudSnapshotParam(*editParam).Execute()
+}
+
+// DeleteCloudSnapshot deletes a cloud snapshot of a storage group.
+func DeleteCloudSnapshot(ctx context.Context, client client.Client, sgName string, cloudSnapshotID string) (*http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.DeleteCloudSnapshot(ctx, client.SymmetrixID, sgName, cloudSnapshotID).Execute()
+}
+
+// getRecoveredStorageGroups returns the names of the storage groups a cloud snapshot has been restored to.
+func getRecoveredStorageGroups(ctx context.Context, snapshot *pmax.CloudSnapshot) (types.List, error) {
+	recovered := make([]string, 0)
+	for _, info := range snapshot.RecoveredStorageGroupInfos {
+		recovered = append(recovered, info.GetStorageGroupId())
+	}
+	recoveredList, diags := types.ListValueFrom(ctx, types.StringType, recovered)
+	if diags.HasError() {
+		return recoveredList, fmt.Errorf("failed to set the recovered storage groups")
+	}
+	return recoveredList, nil
+}
+
+// UpdateCloudSnapshotResourceState updates the cloud snapshot resource state from the API response.
+func UpdateCloudSnapshotResourceState(ctx context.Context, snapshot *pmax.CloudSnapshot, state *models.CloudSnapshotResourceModel) error {
+	recoveredList, err := getRecoveredStorageGroups(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+	state.ID = types.StringValue(snapshot.CloudSnapshotId)
+	// Keep the configured values if the array does not report them
+	if snapshot.HasSnapshotName() {
+		state.Name = types.StringValue(snapshot.GetSnapshotName())
+	}
+	if snapshot.HasCloudProviderId() {
+		state.CloudProviderID = types.StringValue(snapshot.GetCloudProviderId())
+	}
+	state.CreationDate = types.StringValue(snapshot.GetCreationDate())
+	state.CreationDateTimestamp = types.Int64Value(snapshot.GetCreationDateTimestamp())
+	state.ExpiryDate = types.StringValue(snapshot.GetExpiryDate())
+	state.ExpiryDateTimestamp = types.Int64Value(snapshot.GetExpiryDateTimestamp())
+	state.State = types.StringValue(snapshot.GetState())
+	state.CapacityGb = types.Float64Value(snapshot.GetCapacityGb())
+	state.ProtectedPercent = types.Int64Value(snapshot.GetProtectedPercent())
+	state.NumberOfVolumes = types.Int64Value(int64(snapshot.GetNumberOfVolumes()))
+	state.EncryptionEnabled = types.BoolValue(snapshot.GetEncryptionEnabled())
+	state.CompressionEnabled = types.BoolValue(snapshot.GetCompressionEnabled())
+	state.Orphaned = types.BoolValue(snapshot.GetOrphaned())
+	state.RecoveredStorageGroups = recoveredList
+	return nil
+}
+
+// UpdateCloudSnapshotDatasourceState updates the cloud snapshot data source state from the API response.
+func UpdateCloudSnapshotDatasourceState(ctx context.Context, snapshot *pmax.CloudSnapshot, sourceVolumes *pmax.SourceVolumeList, detail *models.CloudSnapshotDetail) error {
+	recoveredList, err := getRecoveredStorageGroups(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+	sourceVolumeList, diags := types.ListValueFrom(ctx, types.StringType, sourceVolumes.SourceVolumeIds)
+	if diags.HasError() {
+		return fmt.Errorf("failed to set the source volumes")
+	}
+	detail.ID = types.StringValue(snapshot.CloudSnapshotId)
+	detail.Name = types.StringValue(snapshot.GetSnapshotName())
+	detail.CloudProviderID = types.StringValue(snapshot.GetCloudProviderId())
+	detail.StorageGroupName = types.StringValue(snapshot.GetStorageGroupId())
+	detail.CreationDate = types.StringValue(snapshot.GetCreationDate())
+	detail.CreationDateTimestamp = types.Int64Value(snapshot.GetCreationDateTimestamp())
+	detail.ExpiryDate = types.StringValue(snapshot.GetExpiryDate())
+	detail.ExpiryDateTimestamp = types.Int64Value(snapshot.GetExpiryDateTimestamp())
+	detail.State = types.StringValue(snapshot.GetState())
+	detail.CapacityGb = types.Float64Value(snapshot.GetCapacityGb())
+	detail.ProtectedPercent = types.Int64Value(snapshot.GetProtectedPercent())
+	detail.NumberOfVolumes = types.Int64Value(int64(snapshot.GetNumberOfVolumes()))
+	detail.EncryptionEnabled = types.BoolValue(snapshot.GetEncryptionEnabled())
+	detail.CompressionEnabled = types.BoolValue(snapshot.GetCompressionEnabled())
+	detail.Orphaned = types.BoolValue(snapshot.GetOrphaned())
+	detail.RecoveredStorageGroups = recoveredList
+	detail.SourceVolumes = sourceVolumeList
+	return nil
+}
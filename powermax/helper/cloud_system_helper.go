@@ -0,0 +1,168 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"net/http"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// CreateCloudSystemDNSServer adds a DNS server to the cloud system.
+func CreateCloudSystemDNSServer(ctx context.Context, client client.Client, ipAddress string) (*pmax.CreateCloudSystemDnsServerParam, *http.Response, error) {
+	dnsServer := pmax.NewCloudSystemDnsServer()
+	dnsServer.SetDnsId(ipAddress)
+	return client.PmaxOpenapiClient.SystemApi.CreateCloudSystemDnsServer(ctx, client.SymmetrixID).CloudSystemDnsServer(*dnsServer).Execute()
+}
+
+// GetCloudSystemDNSServer gets the details of a cloud system DNS server.
+func GetCloudSystemDNSServer(ctx context.Context, client client.Client, dnsID string) (*pmax.CloudSystemDnsServer, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.GetCloudSystemDnsServer(ctx, client.SymmetrixID, dnsID).Execute()
+}
+
+// ModifyCloudSystemDNSServer changes the IP address of a cloud system DNS server.
+func ModifyCloudSystemDNSServer(ctx context.Context, client client.Client, dnsID string, ipAddress string) (*pmax.CloudSystemDnsServer, *http.Response, error) {
+	editParam := pmax.NewEditCloudSystemDnsServerParam(pmax.EditCloudSystemDnsServerActionParam{
+		EditCloudSystemDnsServerIpAddress: pmax.NewEditCloudSystemDnsServerIpAddressParam(ipAddress),
+	})
+	return client.PmaxOpenapiClient.SystemApi.ModifyCloudSystemDnsServer(ctx, client.SymmetrixID, dnsID).EditCloudSystemDnsServerParam(*editParam).Execute()
+}
+
+// DeleteCloudSystemDNSServer removes a DNS server from the cloud system.
+func DeleteCloudSystemDNSServer(ctx context.Context, client client.Client, dnsID string) (*http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.DeleteCloudSystemDnsServer(ctx, client.SymmetrixID, dnsID).Execute()
+}
+
+// UpdateCloudSystemDNSServerState updates the cloud system DNS server state from the API response.
+func UpdateCloudSystemDNSServerState(state *models.CloudSystemDNSServerResourceModel, dnsServer *pmax.CloudSystemDnsServer) {
+	state.ID = types.StringValue(dnsServer.GetDnsId())
+	state.IPAddress = types.StringValue(dnsServer.GetDnsId())
+}
+
+// CreateCloudSystemRoute adds a route to the cloud system.
+func CreateCloudSystemRoute(ctx context.Context, client client.Client, plan models.CloudSystemRouteResourceModel) (*pmax.CreateCloudSystemRouteParam, *http.Response, error) {
+	route := pmax.NewCloudSystemRoute("")
+	route.DestinationIpAddress = plan.DestinationIPAddress.ValueStringPointer()
+	route.Prefix = plan.Prefix.ValueStringPointer()
+	route.GatewayIpAddress = plan.GatewayIPAddress.ValueStringPointer()
+	if !plan.InterfaceID.IsNull() && !plan.InterfaceID.IsUnknown() {
+		route.InterfaceId = plan.InterfaceID.ValueStringPointer()
+	}
+	return client.PmaxOpenapiClient.SystemApi.CreateCloudSystemRoute(ctx, client.SymmetrixID).CloudSystemRoute(*route).Execute()
+}
+
+// GetCloudSystemRoute gets the details of a cloud system route.
+func GetCloudSystemRoute(ctx context.Context, client client.Client, routeID string) (*pmax.CloudSystemRoute, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.GetCloudSystemRoute(ctx, client.SymmetrixID, routeID).Execute()
+}
+
+// ListCloudSystemRoutes gets the IDs of all cloud system routes.
+func ListCloudSystemRoutes(ctx context.Context, client client.Client) (*pmax.ListCloudSystemRouteResult, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.ListCloudSystemRoutes(ctx, client.SymmetrixID).Execute()
+}
+
+// FindCloudSystemRoute looks up the route matching the destination, prefix and gateway of the plan.
+// The create call does not return the ID of the new route, so it has to be found from the list of routes.
+func FindCloudSystemRoute(ctx context.Context, client client.Client, plan models.CloudSystemRouteResourceModel) (*pmax.CloudSystemRoute, error) {
+	routeList, _, err := ListCloudSystemRoutes(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, routeID := range routeList.RouteId {
+		route, _, err := GetCloudSystemRoute(ctx, client, routeID)
+		if err != nil {
+			return nil, err
+		}
+		if route.GetDestinationIpAddress() == plan.DestinationIPAddress.ValueString() &&
+			route.GetPrefix() == plan.Prefix.ValueString() &&
+			route.GetGatewayIpAddress() == plan.GatewayIPAddress.ValueString() {
+			return route, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find route to %s/%s via %s", plan.DestinationIPAddress.ValueString(), plan.Prefix.ValueString(), plan.GatewayIPAddress.ValueString())
+}
+
+// ModifyCloudSystemRoute updates the destination, prefix, gateway and interface of a cloud system route.
+func ModifyCloudSystemRoute(ctx context.Context, client client.Client, routeID string, plan models.CloudSystemRouteResourceModel) (*pmax.CloudSystemRoute, *http.Response, error) {
+	modifyParam := pmax.NewCreateCloudSystemRouteParam(plan.DestinationIPAddress.ValueString(), plan.Prefix.ValueString(), plan.GatewayIPAddress.ValueString())
+	if !plan.InterfaceID.IsNull() && !plan.InterfaceID.IsUnknown() {
+		modifyParam.InterfaceId = plan.InterfaceID.ValueStringPointer()
+	}
+	return client.PmaxOpenapiClient.SystemApi.ModifyCloudSystemRoute(ctx, client.SymmetrixID, routeID).CreateCloudSystemRouteParam(*modifyParam).Execute()
+}
+
+// DeleteCloudSystemRoute removes a route from the cloud system.
+func DeleteCloudSystemRoute(ctx context.Context, client client.Client, routeID string) (*http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.DeleteCloudSystemRoute(ctx, client.SymmetrixID, routeID).Execute()
+}
+
+// UpdateCloudSystemRouteState updates the cloud system route state from the API response.
+func UpdateCloudSystemRouteState(state *models.CloudSystemRouteResourceModel, route *pmax.CloudSystemRoute) {
+	state.ID = types.StringValue(route.RouteId)
+	state.DestinationIPAddress = types.StringValue(route.GetDestinationIpAddress())
+	state.Prefix = types.StringValue(route.GetPrefix())
+	state.GatewayIPAddress = types.StringValue(route.GetGatewayIpAddress())
+	state.InterfaceID = types.StringValue(route.GetInterfaceId())
+}
+
+// CreateCloudSystemTeam creates a NIC team on the cloud system.
+func CreateCloudSystemTeam(ctx context.Context, client client.Client, plan models.CloudSystemTeamResourceModel, interfaceIDs []string) (*pmax.CloudSystemTeam, *http.Response, error) {
+	createParam := pmax.NewCreateCloudSystemTeamParam(plan.IPAddress.ValueString(), int32(plan.Prefix.ValueInt64()), interfaceIDs)
+	return client.PmaxOpenapiClient.SystemApi.CreateCloudSystemTeam(ctx, client.SymmetrixID).CreateCloudSystemTeamParam(*createParam).Execute()
+}
+
+// GetCloudSystemTeam gets the details of a cloud system NIC team.
+func GetCloudSystemTeam(ctx context.Context, client client.Client, teamID string) (*pmax.CloudSystemTeam, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.GetCloudSystemTeam(ctx, client.SymmetrixID, teamID).Execute()
+}
+
+// ModifyCloudSystemTeam updates the IP address, prefix and interfaces of a cloud system NIC team.
+func ModifyCloudSystemTeam(ctx context.Context, client client.Client, teamID string, plan models.CloudSystemTeamResourceModel, interfaceIDs []string) (*pmax.CloudSystemTeam, *http.Response, error) {
+	editParam := pmax.NewEditCloudSystemTeamParam(pmax.EditCloudSystemTeamActionParam{
+		EditTeamSettings: pmax.NewEditTeamSettingsParam(plan.IPAddress.ValueString(), int32(plan.Prefix.ValueInt64()), interfaceIDs),
+	})
+	return client.PmaxOpenapiClient.SystemApi.ModifyCloudSystemTeam(ctx, client.SymmetrixID, teamID).EditCloudSystemTeamParam(*editParam).Execute()
+}
+
+// DeleteCloudSystemTeam deletes a cloud system NIC team.
+func DeleteCloudSystemTeam(ctx context.Context, client client.Client, teamID string) (*http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.DeleteCloudSystemTeam(ctx, client.SymmetrixID, teamID).Execute()
+}
+
+// UpdateCloudSystemTeamState updates the cloud system NIC team state from the API response.
+// The interfaces are not returned by the array, so they are kept as configured.
+func UpdateCloudSystemTeamState(state *models.CloudSystemTeamResourceModel, team *pmax.CloudSystemTeam) {
+	state.ID = types.StringValue(team.TeamId)
+	if team.IpAddress != nil {
+		state.IPAddress = types.StringValue(team.GetIpAddress())
+	}
+	if team.Prefix != nil {
+		state.Prefix = types.Int64Value(int64(team.GetPrefix()))
+	}
+	state.State = types.StringValue(team.GetState())
+	state.MacAddress = types.StringValue(team.GetMacAddresss())
+	state.Mtu = types.Int64Value(int64(team.GetMtu()))
+	state.NumOfRoutes = types.Int64Value(int64(team.GetNumOfRoutes()))
+	state.Type = types.StringValue(team.GetType())
+	state.IPSource = types.StringValue(team.GetIpSource())
+}
@@ -0,0 +1,201 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ListSplits lists the IDs of the FICON splits.
+func ListSplits(ctx context.Context, client client.Client) (*pmax.SplitList, *http.Response, error) {
+	return client.PmaxOpenapiClient.SLOProvisioningApi.ListSplits(ctx, client.SymmetrixID).Execute()
+}
+
+// GetSplit get the details of a FICON split.
+func GetSplit(ctx context.Context, client client.Client, splitID string) (*pmax.Split, *http.Response, error) {
+	return client.PmaxOpenapiClient.SLOProvisioningApi.GetSplit(ctx, client.SymmetrixID, splitID).Execute()
+}
+
+// UpdateSplitDetailState updates the data source state of a FICON split.
+func UpdateSplitDetailState(detail *models.SplitDetailModel, splitID string, split *pmax.Split) {
+	detail.ID = types.StringValue(splitID)
+	detail.Name = types.StringValue(split.GetName())
+	detail.AlphaSerialNum = types.StringValue(split.GetAlphaSerialNum())
+	detail.HyperPAVState = types.StringValue(split.GetHyperPAVState())
+	detail.NumCUImages = types.Int64Value(int64(split.GetNumCUImages()))
+	detail.NumPorts = types.Int64Value(int64(split.GetNumPorts()))
+	detail.Ports = []models.PortKey{}
+	for _, port := range split.GetSymmetrixPortKey() {
+		detail.Ports = append(detail.Ports, models.PortKey{
+			DirectorID: types.StringValue(port.DirectorId),
+			PortID:     types.StringValue(port.PortId),
+		})
+	}
+}
+
+// ListCUImages lists the SSIDs of the CU images of a FICON split.
+func ListCUImages(ctx context.Context, client client.Client, splitID string) (*pmax.CuImageList, *http.Response, error) {
+	return client.PmaxOpenapiClient.SLOProvisioningApi.ListCUImages(ctx, client.SymmetrixID, splitID).Execute()
+}
+
+// GetCUImage get the details of a CU image.
+func GetCUImage(ctx context.Context, client client.Client, splitID, ssid string) (*pmax.CuImage, *http.Response, error) {
+	return client.PmaxOpenapiClient.SLOProvisioningApi.GetCUImage(ctx, client.SymmetrixID, splitID, ssid).Execute()
+}
+
+// CreateCUImage creates a CU image under a FICON split with volumes mapped to it, a CU image cannot be created without volumes.
+func CreateCUImage(ctx context.Context, client client.Client, plan models.CuImageResourceModel, volumeIDs []string) (*pmax.CuImage, *http.Response, error) {
+	createParam := client.PmaxOpenapiClient.SLOProvisioningApi.CreateCUImage(ctx, client.SymmetrixID, plan.SplitID.ValueString())
+	createParam = createParam.CreateCUImageWithMappedVolumesParam(pmax.CreateCUImageWithMappedVolumesParam{
+		CuImageSSID:      plan.CuImageSSID.ValueString(),
+		CuImageNumber:    plan.CuImageNumber.ValueString(),
+		StartBaseAddress: plan.StartBaseAddress.ValueString(),
+		VolumeId:         volumeIDs,
+	})
+	return createParam.Execute()
+}
+
+// ModifyCUImage runs an edit action on a CU image.
+func ModifyCUImage(ctx context.Context, client client.Client, splitID, ssid string, action pmax.EditCUImageActionParam) error {
+	modifyParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyCUImage(ctx, client.SymmetrixID, splitID, ssid)
+	modifyParam = modifyParam.EditCUImageParam(pmax.EditCUImageParam{
+		EditCUImageActionParam: action,
+	})
+	_, _, err := modifyParam.Execute()
+	return err
+}
+
+// MapCUImageVolumes maps volumes to a CU image from the given base address.
+func MapCUImageVolumes(ctx context.Context, client client.Client, splitID, ssid, startBaseAddress string, volumeIDs []string) error {
+	return ModifyCUImage(ctx, client, splitID, ssid, pmax.EditCUImageActionParam{
+		MapVolumeParam: &pmax.MapVolumeParam{
+			StartBaseAddress: startBaseAddress,
+			VolumeId:         volumeIDs,
+		},
+	})
+}
+
+// UnmapCUImageVolumes unmaps volumes from a CU image, the array removes the CU image when its last volume is unmapped.
+func UnmapCUImageVolumes(ctx context.Context, client client.Client, splitID, ssid string, volumeIDs []string) error {
+	return ModifyCUImage(ctx, client, splitID, ssid, pmax.EditCUImageActionParam{
+		UnmapVolumeParam: &pmax.UnmapVolumeParam{
+			VolumeId: volumeIDs,
+		},
+	})
+}
+
+// AssignCUImageAliasRange assigns an alias range to a CU image.
+func AssignCUImageAliasRange(ctx context.Context, client client.Client, splitID, ssid, startAddress, endAddress string) error {
+	return ModifyCUImage(ctx, client, splitID, ssid, pmax.EditCUImageActionParam{
+		AssignAliasRangeParam: &pmax.AssignAliasRangeParam{
+			StartAliasAddress: startAddress,
+			EndAliasAddress:   endAddress,
+		},
+	})
+}
+
+// RemoveCUImageAliasRange removes the alias range of a CU image.
+func RemoveCUImageAliasRange(ctx context.Context, client client.Client, splitID, ssid, startAddress, endAddress string) error {
+	return ModifyCUImage(ctx, client, splitID, ssid, pmax.EditCUImageActionParam{
+		RemoveAliasRangeParam: &pmax.RemoveAliasRangeParam{
+			StartAliasAddress: startAddress,
+			EndAliasAddress:   endAddress,
+		},
+	})
+}
+
+// GetCUImageVolumeIDs returns the sorted IDs of the volumes mapped to a CU image.
+func GetCUImageVolumeIDs(ctx context.Context, client client.Client, ssid string) ([]string, error) {
+	listParam := client.PmaxOpenapiClient.SLOProvisioningApi.ListVolumes(ctx, client.SymmetrixID).CuImageSsid(ssid)
+	volumeList, _, err := listParam.Execute()
+	if err != nil {
+		return nil, err
+	}
+	volumeIDs := make([]string, 0)
+	for _, result := range volumeList.ResultList.GetResult() {
+		for _, volumeID := range result {
+			volumeIDs = append(volumeIDs, fmt.Sprint(volumeID))
+		}
+	}
+	sort.Strings(volumeIDs)
+	return volumeIDs, nil
+}
+
+// CuImageID returns the ID of a CU image, in the format 'split_id.cu_image_ssid'.
+func CuImageID(splitID, ssid string) string {
+	return splitID + "." + ssid
+}
+
+// ParseCuImageID returns the split ID and the SSID of a CU image ID.
+func ParseCuImageID(id string) (string, string, error) {
+	parts := strings.Split(id, ".")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid CU image ID %s, the import ID must be in the format 'split_id.cu_image_ssid'", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// UpdateCuImageResourceState updates the resource state with the details of the CU image and the volumes mapped to it.
+func UpdateCuImageResourceState(ctx context.Context, state *models.CuImageResourceModel, splitID string, cuImage *pmax.CuImage, volumeIDs []string) diag.Diagnostics {
+	state.ID = types.StringValue(CuImageID(splitID, cuImage.Ssid))
+	state.SplitID = types.StringValue(splitID)
+	state.CuImageSSID = types.StringValue(cuImage.Ssid)
+	state.CuImageNumber = types.StringValue(cuImage.ImageNumber)
+	state.Status = types.StringValue(cuImage.GetStatus())
+	state.NumMappedVolumes = types.Int64Value(int64(cuImage.GetNumMappedVolumes()))
+	state.NumStorageGroups = types.Int64Value(int64(cuImage.GetNumStorageGroups()))
+	state.NumBaseAddresses = types.Int64Value(int64(cuImage.GetNumBaseAddresses()))
+	state.NumAvailableBaseAddresses = types.Int64Value(int64(cuImage.GetNumAvailableBaseAddresses()))
+	state.NextAvailableBaseAddress = types.StringValue(cuImage.GetNextAvailableBaseAddress())
+	state.NumAliases = types.Int64Value(int64(cuImage.GetNumAliases()))
+	state.StartAliasAddress = types.StringNull()
+	state.EndAliasAddress = types.StringNull()
+	if cuImage.GetStartAliasAddress() != "" {
+		state.StartAliasAddress = types.StringValue(cuImage.GetStartAliasAddress())
+		state.EndAliasAddress = types.StringValue(cuImage.GetEndAliasAddress())
+	}
+	volumeSet, diags := types.SetValueFrom(ctx, types.StringType, volumeIDs)
+	state.VolumeIDs = volumeSet
+	return diags
+}
+
+// UpdateCuImageDetailState updates the data source state of a CU image.
+func UpdateCuImageDetailState(detail *models.CuImageDetailModel, splitID string, cuImage *pmax.CuImage) {
+	detail.SplitID = types.StringValue(splitID)
+	detail.SSID = types.StringValue(cuImage.Ssid)
+	detail.ImageNumber = types.StringValue(cuImage.ImageNumber)
+	detail.Status = types.StringValue(cuImage.GetStatus())
+	detail.NumMappedVolumes = types.Int64Value(int64(cuImage.GetNumMappedVolumes()))
+	detail.NumStorageGroups = types.Int64Value(int64(cuImage.GetNumStorageGroups()))
+	detail.NumBaseAddresses = types.Int64Value(int64(cuImage.GetNumBaseAddresses()))
+	detail.NumAvailableBaseAddresses = types.Int64Value(int64(cuImage.GetNumAvailableBaseAddresses()))
+	detail.NextAvailableBaseAddress = types.StringValue(cuImage.GetNextAvailableBaseAddress())
+	detail.NumAliases = types.Int64Value(int64(cuImage.GetNumAliases()))
+	detail.StartAliasAddress = types.StringValue(cuImage.GetStartAliasAddress())
+	detail.EndAliasAddress = types.StringValue(cuImage.GetEndAliasAddress())
+}
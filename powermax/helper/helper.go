@@ -196,6 +196,16 @@ func GetErrorString(err error, errStr string) string {
 	return msgStr
 }
 
+// AnyStringInSlice checks if any of the strings is present in the list.
+func AnyStringInSlice(a []string, list []string) bool {
+	for _, s := range a {
+		if StringInSlice(s, list) {
+			return true
+		}
+	}
+	return false
+}
+
 // StringInSlice checks if string is present in the list.
 func StringInSlice(a string, list []string) bool {
 	for _, b := range list {
@@ -205,3 +215,18 @@ func StringInSlice(a string, list []string) bool {
 	}
 	return false
 }
+
+// GetStringSliceChanges returns the strings to add and to remove to go from the current to the planned list.
+func GetStringSliceChanges(planned, current []string) (toAdd []string, toRemove []string) {
+	for _, name := range planned {
+		if !StringInSlice(name, current) {
+			toAdd = append(toAdd, name)
+		}
+	}
+	for _, name := range current {
+		if !StringInSlice(name, planned) {
+			toRemove = append(toRemove, name)
+		}
+	}
+	return toAdd, toRemove
+}
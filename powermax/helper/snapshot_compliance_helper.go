@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// GetStorageGroupSnapshotCompliance get the snapshot policy compliance of a storage group
+func GetStorageGroupSnapshotCompliance(ctx context.Context, client client.Client, sgName string) (*pmax.StorageGroupSnapshotCompliance, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupSnapshotCompliance(ctx, client.SymmetrixID, sgName).Execute()
+}
+
+// GetSnapshotPolicyStorageGroupNames get the names of all the storage groups associated with a snapshot policy.
+func GetSnapshotPolicyStorageGroupNames(ctx context.Context, client client.Client) ([]string, error) {
+	policies, _, err := GetSnapshotPolicies(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	var sgNames []string
+	for _, policyName := range policies.Name {
+		sgList, _, err := GetSnapshotPolicyStorageGroups(ctx, client, policyName)
+		if err != nil {
+			return nil, err
+		}
+		for _, sgName := range sgList.Name {
+			if !StringInSlice(sgName, sgNames) {
+				sgNames = append(sgNames, sgName)
+			}
+		}
+	}
+	sort.Strings(sgNames)
+	return sgNames, nil
+}
+
+// isGoodSnapshot returns true if the snapshot generation has neither failed nor expired.
+func isGoodSnapshot(snapshot *pmax.SnapVXSnapshotGeneration) bool {
+	if snapshot.IsExpired {
+		return false
+	}
+	for _, state := range snapshot.State {
+		if strings.EqualFold(state, "Failed") {
+			return false
+		}
+	}
+	return true
+}
+
+// GetStorageGroupSnapshotGenerations get the generation numbers of a storage group snapshot.
+func GetStorageGroupSnapshotGenerations(ctx context.Context, client client.Client, sgName string, snapshotName string) (*pmax.StorageGroupSnapshotGenList, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupSnapshotGenerations(ctx, client.SymmetrixID, sgName, snapshotName).Execute()
+}
+
+// GetSnapshotGenerationSG get a generation of a storage group snapshot.
+func GetSnapshotGenerationSG(ctx context.Context, client client.Client, sgName string, snapshotName string, generation int64) (*pmax.SnapVXSnapshotGeneration, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetSnapshotGenerationSG(ctx, client.SymmetrixID, sgName, snapshotName, generation).Execute()
+}
+
+// GetLastGoodSnapshot get the newest generation of a storage group snapshot which has neither failed nor expired.
+// Returns nil when the snapshot has no good generation.
+func GetLastGoodSnapshot(ctx context.Context, client client.Client, sgName string, snapshotName string) (*pmax.SnapVXSnapshotGeneration, error) {
+	generations, _, err := GetStorageGroupSnapshotGenerations(ctx, client, sgName, snapshotName)
+	if err != nil {
+		return nil, err
+	}
+	return firstGoodGeneration(generations.Generations, func(generation int64) (*pmax.SnapVXSnapshotGeneration, error) {
+		snapshot, _, err := GetSnapshotGenerationSG(ctx, client, sgName, snapshotName, generation)
+		return snapshot, err
+	})
+}
+
+// firstGoodGeneration gets the generations from the newest to the oldest, generation 0 being the newest,
+// and returns the first one which has neither failed nor expired, nil when there is none.
+// Unlike snap IDs, which are reused by the array, generation numbers follow the creation time.
+func firstGoodGeneration(generations []int64, getGeneration func(generation int64) (*pmax.SnapVXSnapshotGeneration, error)) (*pmax.SnapVXSnapshotGeneration, error) {
+	sorted := append([]int64{}, generations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, generation := range sorted {
+		snapshot, err := getGeneration(generation)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot != nil && isGoodSnapshot(snapshot) {
+			return snapshot, nil
+		}
+	}
+	return nil, nil
+}
+
+// isPolicySnapshot returns true if the snapshot was created by the snapshot policy.
+// Snapshot policies name their snapshots after the policy, the generations tell them apart.
+func isPolicySnapshot(snapshotName string, policyName string) bool {
+	return snapshotName == policyName
+}
+
+// UpdateSnapshotComplianceState updates the compliance state of a storage group from the API responses.
+// The lastGoodSnapshots map holds the newest good generation of each snapshot policy snapshot of the storage group, by snapshot name.
+func UpdateSnapshotComplianceState(state *models.StorageGroupSnapshotComplianceModel, compliance *pmax.StorageGroupSnapshotCompliance, policies map[string]*pmax.SnapshotPolicy, policyStorageGroups map[string]*pmax.SnapshotPolicyStorageGroup, lastGoodSnapshots map[string]*pmax.SnapVXSnapshotGeneration) {
+	state.StorageGroupName = types.StringValue(compliance.StorageGroupName)
+	state.Compliance = types.StringValue(compliance.GetCompliance())
+	state.PolicyCount = types.Int64Value(compliance.SlCount)
+	state.LastGoodSnapshotTime = types.StringNull()
+	state.LastGoodSnapshotTimestamp = types.Int64Null()
+	state.Policies = []models.SnapshotPolicyComplianceModel{}
+
+	var sgLastGood *pmax.SnapVXSnapshotGeneration
+	for _, slCompliance := range compliance.SlCompliance {
+		policyState := models.SnapshotPolicyComplianceModel{
+			SnapshotPolicyName:        types.StringValue(slCompliance.SlName),
+			Compliance:                types.StringValue(slCompliance.GetCompliance()),
+			CalculationTime:           types.StringValue(slCompliance.GetCalculationTime()),
+			ComplianceCountWarning:    types.Int64Null(),
+			ComplianceCountCritical:   types.Int64Null(),
+			SnapshotsInTimeWindow:     types.Int64Null(),
+			TotalSnapshots:            types.Int64Null(),
+			Suspended:                 types.BoolNull(),
+			LastGoodSnapshotTime:      types.StringNull(),
+			LastGoodSnapshotTimestamp: types.Int64Null(),
+			ErrorMessages:             []models.ComplianceErrorMessageModel{},
+		}
+		if policy, ok := policies[slCompliance.SlName]; ok {
+			policyState.ComplianceCountWarning = types.Int64Value(policy.GetComplianceCountWarning())
+			policyState.ComplianceCountCritical = types.Int64Value(policy.GetComplianceCountCritical())
+		}
+		if policySg, ok := policyStorageGroups[slCompliance.SlName]; ok {
+			policyState.SnapshotsInTimeWindow = types.Int64Value(int64(policySg.GetSnapshotsInTimeWindow()))
+			policyState.TotalSnapshots = types.Int64Value(int64(policySg.GetTotalSnapshots()))
+			policyState.Suspended = types.BoolValue(policySg.GetSuspended())
+		}
+		if policyLastGood := lastGoodSnapshots[slCompliance.SlName]; policyLastGood != nil {
+			policyState.LastGoodSnapshotTime = types.StringValue(policyLastGood.Timestamp)
+			policyState.LastGoodSnapshotTimestamp = types.Int64Value(policyLastGood.TimestampUtc)
+		}
+		for _, message := range slCompliance.ComplianceErrorMessages {
+			policyState.ErrorMessages = append(policyState.ErrorMessages, models.ComplianceErrorMessageModel{
+				Time:         types.StringValue(message.Time),
+				ErrorMessage: types.StringValue(message.ErrorMessage),
+			})
+		}
+		state.Policies = append(state.Policies, policyState)
+	}
+
+	for _, snapshot := range lastGoodSnapshots {
+		if snapshot != nil && (sgLastGood == nil || snapshot.TimestampUtc > sgLastGood.TimestampUtc) {
+			sgLastGood = snapshot
+		}
+	}
+	if sgLastGood != nil {
+		state.LastGoodSnapshotTime = types.StringValue(sgLastGood.Timestamp)
+		state.LastGoodSnapshotTimestamp = types.Int64Value(sgLastGood.TimestampUtc)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFirstGoodGenerationStopsAtNewestGood(t *testing.T) {
+	snapshots := map[int64]*pmax.SnapVXSnapshotGeneration{
+		0: {TimestampUtc: 3000, State: []string{"Failed"}},
+		1: {TimestampUtc: 2500, IsExpired: true},
+		2: {TimestampUtc: 2000, State: []string{"Established"}},
+		3: {TimestampUtc: 1000, State: []string{"Established"}},
+	}
+	var fetched []int64
+	getGeneration := func(generation int64) (*pmax.SnapVXSnapshotGeneration, error) {
+		fetched = append(fetched, generation)
+		return snapshots[generation], nil
+	}
+	newest, err := firstGoodGeneration([]int64{3, 1, 0, 2}, getGeneration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newest == nil || newest.TimestampUtc != 2000 {
+		t.Fatalf("expected the generation created at 2000, got %v", newest)
+	}
+	if !reflect.DeepEqual(fetched, []int64{0, 1, 2}) {
+		t.Fatalf("expected generations 0 to 2 to be fetched, got %v", fetched)
+	}
+}
+
+func TestFirstGoodGenerationWithoutGoodGeneration(t *testing.T) {
+	getGeneration := func(generation int64) (*pmax.SnapVXSnapshotGeneration, error) {
+		return &pmax.SnapVXSnapshotGeneration{IsExpired: true}, nil
+	}
+	newest, err := firstGoodGeneration([]int64{0, 1}, getGeneration)
+	if err != nil || newest != nil {
+		t.Fatalf("expected no good generation, got %v, %v", newest, err)
+	}
+	_, err = firstGoodGeneration([]int64{0}, func(generation int64) (*pmax.SnapVXSnapshotGeneration, error) {
+		return nil, fmt.Errorf("mock error")
+	})
+	if err == nil {
+		t.Fatalf("expected the error of the generation request")
+	}
+}
+
+func TestIsPolicySnapshot(t *testing.T) {
+	tests := []struct {
+		snapshotName string
+		policyName   string
+		expected     bool
+	}{
+		{"daily", "daily", true},
+		{"daily_2", "daily", false},
+		{"daily", "daily_2", false},
+	}
+	for _, test := range tests {
+		if isPolicySnapshot(test.snapshotName, test.policyName) != test.expected {
+			t.Errorf("expected isPolicySnapshot(%s, %s) to be %t", test.snapshotName, test.policyName, test.expected)
+		}
+	}
+}
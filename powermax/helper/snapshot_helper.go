@@ -22,8 +22,11 @@ import (
 	"dell/powermax-go-client"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/models"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -44,14 +47,32 @@ const (
 	ActionSnapshotSecure = "SetSecure"
 	// ActionSnapshotUnlink is used as the unlink action for snasphots.
 	ActionSnapshotUnlink = "Unlink"
+	// ActionSnapshotRelink is used as the relink action for snasphots.
+	ActionSnapshotRelink = "Relink"
 )
 
+// snapshotLinkPollInterval is the time between two checks of the progress of a snapshot link.
+const snapshotLinkPollInterval = 15 * time.Second
+
+const (
+	// SnapshotDeleteStandard only deletes the snapshot and fails if it is still linked.
+	SnapshotDeleteStandard = "standard"
+	// SnapshotDeleteUnlink unlinks all the linked storage groups before deleting the snapshot.
+	SnapshotDeleteUnlink = "unlink"
+	// SnapshotDeleteUnlinkAfterCopy waits for the copy to the linked storage groups to complete, then unlinks them before deleting the snapshot.
+	SnapshotDeleteUnlinkAfterCopy = "unlink_after_copy"
+)
+
+// defaultSnapshotLinkWaitTimeout is used when waiting on links which were not created with a wait timeout.
+const defaultSnapshotLinkWaitTimeout = 60 * time.Minute
+
 // UpdateSnapshotDatasourceState Update Snaposhot state.
 func UpdateSnapshotDatasourceState(ctx context.Context, snapshotDetail *powermax.SnapVXSnapshotInstance, state *models.SnapshotDetailModal) error {
 	// Copy values with the same fields
 	err := CopyFields(ctx, snapshotDetail, state)
 	state.LinkedStorageGroup, _ = GetLinkedSgList(snapshotDetail)
 	state.SourceVolume, _ = GetSnapshotGenerationVolume(snapshotDetail)
+	state.SourceVolumeDetails, _ = GetSnapshotSourceVolumeDetails(ctx, snapshotDetail)
 	tflog.Debug(ctx, fmt.Sprintf("Snapshot Detail State: %v", state))
 	if err != nil {
 		return err
@@ -65,6 +86,7 @@ func UpdateSnapshotResourceState(ctx context.Context, snapshotDetail *powermax.S
 	err := CopyFields(ctx, snapshotDetail, state)
 	state.LinkedStorageGroup, _ = GetLinkedSgList(snapshotDetail)
 	state.SourceVolume, _ = GetSnapshotGenerationVolume(snapshotDetail)
+	state.SourceVolumeDetails, _ = GetSnapshotSourceVolumeDetails(ctx, snapshotDetail)
 	tflog.Debug(ctx, fmt.Sprintf("Snapshot Detail State: %v", state))
 	if err != nil {
 		return err
@@ -92,6 +114,51 @@ func GetSnapshotGenerationVolume(snapshotDetail *powermax.SnapVXSnapshotInstance
 	return types.ListValue(types.ObjectType{AttrTypes: typeKey}, genObjects)
 }
 
+// snapshotSourceVolumeDetailType is the object type of the source_volume_details attribute.
+var snapshotSourceVolumeDetailType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":        types.StringType,
+	"capacity":    types.Int64Type,
+	"capacity_gb": types.Float64Type,
+	"linked":      types.BoolType,
+	"linked_volumes": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"storage_group_name": types.StringType,
+		"linked_volume_name": types.StringType,
+		"tracks":             types.Int64Type,
+		"track_size":         types.Int64Type,
+		"percentage_copied":  types.Int64Type,
+		"defined":            types.BoolType,
+	}}},
+}}
+
+// GetSnapshotSourceVolumeDetails Get the source volumes of the snapshot generation with the volumes they are linked to.
+func GetSnapshotSourceVolumeDetails(ctx context.Context, snapshotDetail *powermax.SnapVXSnapshotInstance) (types.List, diag.Diagnostics) {
+	details := []models.SnapshotSourceVolumeDetail{}
+	for _, volume := range snapshotDetail.SourceVolume {
+		detail := models.SnapshotSourceVolumeDetail{
+			Name:          types.StringValue(volume.Name),
+			Capacity:      types.Int64Value(volume.Capacity),
+			CapacityGb:    types.Float64Value(float64(volume.CapacityGb)),
+			LinkedVolumes: []models.SnapshotSourceVolumeLink{},
+		}
+		for _, linked := range snapshotDetail.LinkedStorageGroup {
+			if !strings.EqualFold(linked.SourceVolumeName, volume.Name) {
+				continue
+			}
+			detail.LinkedVolumes = append(detail.LinkedVolumes, models.SnapshotSourceVolumeLink{
+				StorageGroupName: types.StringValue(linked.Name),
+				LinkedVolumeName: types.StringValue(linked.LinkedVolumeName),
+				Tracks:           types.Int64Value(linked.Tracks),
+				TrackSize:        types.Int64Value(linked.TrackSize),
+				PercentageCopied: types.Int64Value(linked.PercentageCopied),
+				Defined:          types.BoolValue(linked.GetDefined()),
+			})
+		}
+		detail.Linked = types.BoolValue(len(detail.LinkedVolumes) > 0)
+		details = append(details, detail)
+	}
+	return types.ListValueFrom(ctx, snapshotSourceVolumeDetailType, details)
+}
+
 // GetLinkedSgList Get linked storage group list.
 func GetLinkedSgList(snapshotDetail *powermax.SnapVXSnapshotInstance) (types.List, diag.Diagnostics) {
 	var sgObjects []attr.Value
@@ -115,8 +182,8 @@ func GetLinkedSgList(snapshotDetail *powermax.SnapVXSnapshotInstance) (types.Lis
 		sgMap["track_size"] = types.Int64Value(sg.TrackSize)
 		sgMap["percentage_copied"] = types.Int64Value(sg.PercentageCopied)
 		sgMap["linked_creation_timestamp"] = types.StringValue(sg.LinkedCreationTimestamp)
-		sgMap["defined"] = types.BoolValue(*sg.Defined)
-		sgMap["background_define_in_progress"] = types.BoolValue(*sg.BackgroundDefineInProgress)
+		sgMap["defined"] = types.BoolValue(sg.GetDefined())
+		sgMap["background_define_in_progress"] = types.BoolValue(sg.GetBackgroundDefineInProgress())
 
 		sgbject, _ := types.ObjectValue(typeKey, sgMap)
 		sgObjects = append(sgObjects, sgbject)
@@ -165,16 +232,7 @@ func ModifySnapshot(ctx context.Context, client client.Client, plan *models.Snap
 		case ActionSnapshotLink:
 			if plan.Snapshot.Link != nil && (state.Snapshot.Link == nil || plan.Snapshot.Link.Enable.ValueBool() != state.Snapshot.Link.Enable.ValueBool()) {
 				if plan.Snapshot.Link.Enable.ValueBool() {
-					modifyParam := modifyParam.StorageGroupSnapshotInstanceUpdate(powermax.StorageGroupSnapshotInstanceUpdate{
-						Action: ActionSnapshotLink,
-						Link: &powermax.SnapVxLinkOptions{
-							StorageGroupName: plan.Snapshot.Link.TargetStorageGroup.ValueString(),
-							NoCompression:    plan.Snapshot.Link.NoCompression.ValueBoolPointer(),
-							Copy:             plan.Snapshot.Link.Copy.ValueBoolPointer(),
-							Remote:           plan.Snapshot.Link.Remote.ValueBoolPointer(),
-						},
-					})
-					_, _, err := modifyParam.Execute()
+					err := LinkSnapshot(ctx, client, state.StorageGroup.Name.ValueString(), plan, state.Snapid.ValueInt64())
 					if err != nil {
 						return err
 					}
@@ -230,6 +288,298 @@ func ModifySnapshot(ctx context.Context, client client.Client, plan *models.Snap
 	return nil
 }
 
+// LinkSnapshot links the snapshot generation to the target storage group of the plan, or relinks the target
+// storage group to this generation when relink is set, then waits for the link to be defined or copied if asked to.
+func LinkSnapshot(ctx context.Context, client client.Client, sgName string, plan *models.SnapshotResourceModel, snapID int64) error {
+	link := plan.Snapshot.Link
+	if link.WaitForCopy.ValueBool() && !link.Copy.ValueBool() {
+		return fmt.Errorf("wait_for_copy can only be set when copy is true")
+	}
+	snapshotName := plan.Snapshot.Name.ValueString()
+	targetSgName := link.TargetStorageGroup.ValueString()
+	updateParam := powermax.StorageGroupSnapshotInstanceUpdate{
+		Action: ActionSnapshotLink,
+		Link: &powermax.SnapVxLinkOptions{
+			StorageGroupName: targetSgName,
+			NoCompression:    link.NoCompression.ValueBoolPointer(),
+			Copy:             link.Copy.ValueBoolPointer(),
+			Remote:           link.Remote.ValueBoolPointer(),
+		},
+	}
+	if link.Relink.ValueBool() {
+		updateParam = powermax.StorageGroupSnapshotInstanceUpdate{
+			Action: ActionSnapshotRelink,
+			Relink: &powermax.SnapVxRelinkOption{
+				StorageGroupName: targetSgName,
+				Copy:             link.Copy.ValueBoolPointer(),
+				Remote:           link.Remote.ValueBoolPointer(),
+			},
+		}
+	}
+	modifyParam := client.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotSnapID(ctx, client.SymmetrixID, sgName, snapshotName, snapID)
+	_, _, err := modifyParam.StorageGroupSnapshotInstanceUpdate(updateParam).Execute()
+	if err != nil {
+		return err
+	}
+
+	if !link.WaitForCopy.ValueBool() && !link.WaitForDefined.ValueBool() {
+		return nil
+	}
+	timeout := time.Duration(link.WaitTimeoutMinutes.ValueInt64()) * time.Minute
+	return WaitForSnapshotLink(ctx, client, sgName, snapshotName, snapID, targetSgName, link.WaitForCopy.ValueBool(), link.WaitForDefined.ValueBool(), timeout)
+}
+
+// snapshotLinkProgress returns the lowest percentage copied of the volumes linked to the target storage group
+// and whether all of them are defined. found is false until the array reports the link.
+func snapshotLinkProgress(snapshotDetail *powermax.SnapVXSnapshotInstance, targetSgName string) (percentageCopied int64, defined bool, found bool) {
+	percentageCopied = 100
+	defined = true
+	for _, linked := range snapshotDetail.LinkedStorageGroup {
+		if linked.Name != targetSgName {
+			continue
+		}
+		found = true
+		if linked.PercentageCopied < percentageCopied {
+			percentageCopied = linked.PercentageCopied
+		}
+		if !linked.GetDefined() {
+			defined = false
+		}
+	}
+	return percentageCopied, defined, found
+}
+
+// WaitForSnapshotLink polls the snapshot generation until all the volumes linked to the target storage group
+// are fully copied and/or defined, or until the timeout expires.
+func WaitForSnapshotLink(ctx context.Context, client client.Client, sgName string, snapshotName string, snapID int64, targetSgName string, waitForCopy bool, waitForDefined bool, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		snapshotDetail, _, err := GetSnapshotSnapIDSG(ctx, client, sgName, snapshotName, snapID)
+		if err != nil {
+			return err
+		}
+		percentageCopied, defined, found := snapshotLinkProgress(snapshotDetail, targetSgName)
+		tflog.Info(ctx, "waiting for snapshot link", map[string]interface{}{
+			"snapshot":         snapshotName,
+			"snapID":           snapID,
+			"targetSgName":     targetSgName,
+			"percentageCopied": percentageCopied,
+			"defined":          defined,
+		})
+		if found && (!waitForCopy || percentageCopied >= 100) && (!waitForDefined || defined) {
+			return nil
+		}
+		if time.Now().Add(snapshotLinkPollInterval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for the link of snapshot %s to storage group %s, %d%% copied, defined: %t", timeout, snapshotName, targetSgName, percentageCopied, defined)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(snapshotLinkPollInterval):
+		}
+	}
+}
+
+// linkedStorageGroupNames returns the distinct names of the storage groups linked to the snapshot generation.
+func linkedStorageGroupNames(snapshotDetail *powermax.SnapVXSnapshotInstance) []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, linked := range snapshotDetail.LinkedStorageGroup {
+		if !seen[linked.Name] {
+			seen[linked.Name] = true
+			names = append(names, linked.Name)
+		}
+	}
+	for _, name := range snapshotDetail.LinkedStorageGroupNames {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isCopyModeLink returns true when the resource linked the snapshot to the target storage group in copy mode.
+// The array does not report the copy mode of a link, so links made outside of the resource are not known to copy.
+func isCopyModeLink(state *models.SnapshotResourceModel, targetSgName string) bool {
+	if state.Snapshot == nil || state.Snapshot.Link == nil {
+		return false
+	}
+	link := state.Snapshot.Link
+	return link.Enable.ValueBool() && link.Copy.ValueBool() && link.TargetStorageGroup.ValueString() == targetSgName
+}
+
+// DeleteSnapshot terminates the snapshot generation of the state following its delete_behavior.
+// Linked storage groups are unlinked first when the behavior allows it and an active restore session is
+// terminated before the snapshot itself. Secure snapshots are refused until their secure time has expired.
+func DeleteSnapshot(ctx context.Context, client client.Client, state *models.SnapshotResourceModel) error {
+	sgName := state.StorageGroup.Name.ValueString()
+	snapshotName := state.Name.ValueString()
+	snapID := state.Snapid.ValueInt64()
+	snapshotDetail, _, err := GetSnapshotSnapIDSG(ctx, client, sgName, snapshotName, snapID)
+	if err != nil {
+		return err
+	}
+
+	if snapshotDetail.GetSecureExpiryDate() != "" && !snapshotDetail.Expired {
+		return fmt.Errorf("snapshot %s is secure until %s and cannot be deleted before the secure time expires", snapshotName, snapshotDetail.GetSecureExpiryDate())
+	}
+
+	behavior := state.DeleteBehavior.ValueString()
+	linkedNames := linkedStorageGroupNames(snapshotDetail)
+	if len(linkedNames) > 0 {
+		if behavior != SnapshotDeleteUnlink && behavior != SnapshotDeleteUnlinkAfterCopy {
+			return fmt.Errorf("snapshot %s is linked to storage group(s) %s, unlink them or set delete_behavior to %q or %q",
+				snapshotName, strings.Join(linkedNames, ", "), SnapshotDeleteUnlink, SnapshotDeleteUnlinkAfterCopy)
+		}
+		// A nocopy link is never fully copied, fail before unlinking anything rather than waiting for the timeout
+		if behavior == SnapshotDeleteUnlinkAfterCopy {
+			for _, targetSgName := range linkedNames {
+				percentageCopied, _, _ := snapshotLinkProgress(snapshotDetail, targetSgName)
+				if percentageCopied < 100 && !isCopyModeLink(state, targetSgName) {
+					return fmt.Errorf("snapshot %s is linked to storage group %s without copy and its copy will never complete, set delete_behavior to %q",
+						snapshotName, targetSgName, SnapshotDeleteUnlink)
+				}
+			}
+		}
+		timeout := defaultSnapshotLinkWaitTimeout
+		if state.Snapshot != nil && state.Snapshot.Link != nil && state.Snapshot.Link.WaitTimeoutMinutes.ValueInt64() > 0 {
+			timeout = time.Duration(state.Snapshot.Link.WaitTimeoutMinutes.ValueInt64()) * time.Minute
+		}
+		for _, targetSgName := range linkedNames {
+			if behavior == SnapshotDeleteUnlinkAfterCopy {
+				err := WaitForSnapshotLink(ctx, client, sgName, snapshotName, snapID, targetSgName, true, false, timeout)
+				if err != nil {
+					return err
+				}
+			}
+			tflog.Info(ctx, "unlinking snapshot before delete", map[string]interface{}{
+				"snapshot":     snapshotName,
+				"targetSgName": targetSgName,
+			})
+			modifyParam := client.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotSnapID(ctx, client.SymmetrixID, sgName, snapshotName, snapID)
+			_, _, err := modifyParam.StorageGroupSnapshotInstanceUpdate(powermax.StorageGroupSnapshotInstanceUpdate{
+				Action: ActionSnapshotUnlink,
+				Unlink: &powermax.SnapVxUnlinkOptions{
+					StorageGroupName: targetSgName,
+				},
+			}).Execute()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// A delete on a restored snapshot only terminates the restore session, the snapshot needs a second one
+	if snapshotDetail.Restored {
+		tflog.Info(ctx, "terminating restore session before delete", map[string]interface{}{
+			"snapshot": snapshotName,
+		})
+		_, err := client.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotSnapID(ctx, client.SymmetrixID, sgName, snapshotName, snapID).Execute()
+		if err != nil {
+			return err
+		}
+	}
+	_, err = client.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotSnapID(ctx, client.SymmetrixID, sgName, snapshotName, snapID).Execute()
+	return err
+}
+
+// SnapshotResourceID returns the ID of a snapshot resource, in the format symmetrix_id/storage_group_name/snapshot_name/snapid.
+func SnapshotResourceID(symmetrixID string, sgName string, snapshotName string, snapID int64) string {
+	return fmt.Sprintf("%s/%s/%s/%d", symmetrixID, sgName, snapshotName, snapID)
+}
+
+// UpgradeSnapshotResourceRawState upgrades a version 0 snapshot state, decoded from its raw JSON.
+// The constant ID is replaced with the snapshot resource ID, and the attributes added in version 1
+// are set to their defaults, or to null for the computed ones which are refreshed on the next read.
+func UpgradeSnapshotResourceRawState(symmetrixID string, rawState map[string]interface{}) error {
+	storageGroup, _ := rawState["storage_group"].(map[string]interface{})
+	sgName, _ := storageGroup["name"].(string)
+	snapshotName, _ := rawState["name"].(string)
+	snapID, ok := rawState["snapid"].(float64)
+	if sgName == "" || snapshotName == "" || !ok {
+		return fmt.Errorf("the snapshot state has no storage group name, snapshot name or snap ID")
+	}
+	rawState["id"] = SnapshotResourceID(symmetrixID, sgName, snapshotName, int64(snapID))
+	if rawState["delete_behavior"] == nil {
+		rawState["delete_behavior"] = SnapshotDeleteStandard
+	}
+	if _, ok := rawState["source_volume_details"]; !ok {
+		rawState["source_volume_details"] = nil
+	}
+	actions, _ := rawState["snapshot_actions"].(map[string]interface{})
+	if link, ok := actions["link"].(map[string]interface{}); ok {
+		for _, name := range []string{"relink", "wait_for_copy", "wait_for_defined"} {
+			if link[name] == nil {
+				link[name] = false
+			}
+		}
+		if link["wait_timeout_minutes"] == nil {
+			link["wait_timeout_minutes"] = int64(defaultSnapshotLinkWaitTimeout / time.Minute)
+		}
+	}
+	return nil
+}
+
+// ParseSnapshotImportID resolves a snapshot import ID to its storage group, snapshot name and snap ID.
+// The supported formats are the resource ID symmetrix_id/storage_group_name/snapshot_name/snapid and
+// storage_group_name.snapshot_name, optionally followed by .snapid:<snapid> or .generation:<generation>.
+// Without a snap ID or generation the newest generation is imported.
+func ParseSnapshotImportID(ctx context.Context, client client.Client, importID string) (string, string, int64, error) {
+	formatErr := fmt.Errorf("the import ID must be 'symmetrix_id/storage_group_name/snapshot_name/snapid' or 'storage_group_name.snapshot_name' optionally followed by '.snapid:<snapid>' or '.generation:<generation>'")
+	if strings.Contains(importID, "/") {
+		ids := strings.Split(importID, "/")
+		if len(ids) != 4 || ids[1] == "" || ids[2] == "" {
+			return "", "", 0, formatErr
+		}
+		if ids[0] != client.SymmetrixID {
+			return "", "", 0, fmt.Errorf("the symmetrix ID %s of the import ID does not match the configured array %s", ids[0], client.SymmetrixID)
+		}
+		snapID, err := strconv.ParseInt(ids[3], 10, 64)
+		if err != nil {
+			return "", "", 0, formatErr
+		}
+		return ids[1], ids[2], snapID, nil
+	}
+
+	ids := strings.Split(importID, ".")
+	if len(ids) < 2 || len(ids) > 3 || ids[0] == "" || ids[1] == "" {
+		return "", "", 0, formatErr
+	}
+	sgName, snapshotName := ids[0], ids[1]
+	generation := int64(0)
+	if len(ids) == 3 {
+		key, value, found := strings.Cut(ids[2], ":")
+		number, err := strconv.ParseInt(value, 10, 64)
+		if !found || err != nil {
+			return "", "", 0, formatErr
+		}
+		switch key {
+		case "snapid":
+			return sgName, snapshotName, number, nil
+		case "generation":
+			generation = number
+		default:
+			return "", "", 0, formatErr
+		}
+	}
+
+	snapIDs, _, err := GetStorageGroupSnapshotSnapIDs(ctx, client, sgName, snapshotName)
+	if err != nil {
+		return "", "", 0, err
+	}
+	for _, snapID := range snapIDs.Snapids {
+		snapshotDetail, _, err := GetSnapshotSnapIDSG(ctx, client, sgName, snapshotName, snapID)
+		if err != nil {
+			return "", "", 0, err
+		}
+		if snapshotDetail.GetGeneration() == generation {
+			return sgName, snapshotName, snapID, nil
+		}
+	}
+	return "", "", 0, fmt.Errorf("could not find generation %d of snapshot %s on storage group %s", generation, snapshotName, sgName)
+}
+
 // GetStorageGroupSnapshots get SG snapshots
 func GetStorageGroupSnapshots(ctx context.Context, client client.Client, sgName string) (*powermax.StorageGroupSnapshotList, *http.Response, error) {
 	return client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupSnapshots(ctx, client.SymmetrixID, sgName).Execute()
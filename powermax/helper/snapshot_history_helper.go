@@ -0,0 +1,169 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// GetSnapshotIDMapping get the snap ID to generation mapping of a storage group snapshot.
+func GetSnapshotIDMapping(ctx context.Context, client client.Client, sgName string, snapshotName string) (*pmax.SnapshotSnapIDGenerationMappingList, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetSnapshotIDMapping(ctx, client.SymmetrixID, sgName, snapshotName).Execute()
+}
+
+// GetVolumeStorageGroupNames get the names of the storage groups of a volume, including their parent storage groups.
+func GetVolumeStorageGroupNames(ctx context.Context, client client.Client, volumeID string) ([]string, error) {
+	volume, _, err := GetVolume(ctx, client, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	var sgNames []string
+	for _, sg := range volume.StorageGroups {
+		for _, sgName := range []string{sg.GetStorageGroupName(), sg.GetParentStorageGroupName()} {
+			if sgName != "" && !StringInSlice(sgName, sgNames) {
+				sgNames = append(sgNames, sgName)
+			}
+		}
+	}
+	sort.Strings(sgNames)
+	return sgNames, nil
+}
+
+// snapshotAgeHours returns the number of full hours between a snapshot timestamp in milliseconds and now.
+func snapshotAgeHours(timestampUtc int64, now time.Time) int64 {
+	return int64(now.Sub(time.UnixMilli(timestampUtc)) / time.Hour)
+}
+
+// matchSnapshotHistoryAge returns true if a snapshot of the given age passes the age filters.
+func matchSnapshotHistoryAge(ageHours int64, filter *models.SnapshotHistoryFilterType) bool {
+	if filter == nil {
+		return true
+	}
+	if !filter.OlderThanHours.IsNull() && ageHours < filter.OlderThanHours.ValueInt64() {
+		return false
+	}
+	if !filter.NewerThanHours.IsNull() && ageHours >= filter.NewerThanHours.ValueInt64() {
+		return false
+	}
+	return true
+}
+
+// hasSourceVolume returns true if the volume is a source volume of the snapshot.
+func hasSourceVolume(snapshot *pmax.SnapVXSnapshotInstance, volumeID string) bool {
+	for _, volume := range snapshot.SourceVolume {
+		if strings.EqualFold(volume.Name, volumeID) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSnapshotHistoryGeneration builds the snapshot history state of a snapshot generation.
+func NewSnapshotHistoryGeneration(sgName string, snapshot *pmax.SnapVXSnapshotInstance, now time.Time) models.SnapshotHistoryGenerationModel {
+	generation := models.SnapshotHistoryGenerationModel{
+		StorageGroupName:     types.StringValue(sgName),
+		SnapshotName:         types.StringValue(snapshot.Name),
+		Snapid:               types.Int64Value(snapshot.GetSnapid()),
+		Generation:           types.Int64Value(snapshot.GetGeneration()),
+		Timestamp:            types.StringValue(snapshot.Timestamp),
+		TimestampUtc:         types.Int64Value(snapshot.TimestampUtc),
+		AgeHours:             types.Int64Value(snapshotAgeHours(snapshot.TimestampUtc, now)),
+		TimeToLiveExpiryDate: types.StringPointerValue(snapshot.TimeToLiveExpiryDate),
+		SecureExpiryDate:     types.StringPointerValue(snapshot.SecureExpiryDate),
+		Secure:               types.BoolValue(snapshot.SecureExpiryDate != nil),
+		Expired:              types.BoolValue(snapshot.Expired),
+		Linked:               types.BoolValue(snapshot.Linked),
+		Restored:             types.BoolValue(snapshot.Restored),
+		State:                []types.String{},
+		SourceVolumes:        []types.String{},
+		LinkedTargets:        []models.SnapshotHistoryLinkedTargetModel{},
+	}
+	for _, state := range snapshot.State {
+		generation.State = append(generation.State, types.StringValue(state))
+	}
+	for _, volume := range snapshot.SourceVolume {
+		generation.SourceVolumes = append(generation.SourceVolumes, types.StringValue(volume.Name))
+	}
+	for _, target := range snapshot.LinkedStorageGroup {
+		generation.LinkedTargets = append(generation.LinkedTargets, models.SnapshotHistoryLinkedTargetModel{
+			StorageGroupName: types.StringValue(target.Name),
+			SourceVolumeName: types.StringValue(target.SourceVolumeName),
+			LinkedVolumeName: types.StringValue(target.LinkedVolumeName),
+			PercentageCopied: types.Int64Value(target.PercentageCopied),
+			Defined:          types.BoolValue(target.GetDefined()),
+		})
+	}
+	return generation
+}
+
+// GetStorageGroupSnapshotHistory get the snapshot generations of a storage group which pass the filter.
+// When volumeID is set only the generations which include the volume are returned.
+// The generations are listed with the snap ID mapping, so that the name and age filters skip reading the details of the other generations.
+func GetStorageGroupSnapshotHistory(ctx context.Context, client client.Client, sgName string, volumeID string, filter *models.SnapshotHistoryFilterType, now time.Time) ([]models.SnapshotHistoryGenerationModel, error) {
+	snapshots, _, err := GetStorageGroupSnapshots(ctx, client, sgName)
+	if err != nil {
+		return nil, err
+	}
+	generations := []models.SnapshotHistoryGenerationModel{}
+	for _, snapshotNameAndCount := range snapshots.SnapshotNamesAndCounts {
+		snapshotName := snapshotNameAndCount.GetName()
+		if filter != nil && !strings.HasPrefix(snapshotName, filter.NamePrefix.ValueString()) {
+			continue
+		}
+		mapping, _, err := GetSnapshotIDMapping(ctx, client, sgName, snapshotName)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapIDMapping := range mapping.Mappings {
+			if snapIDMapping.SnapshotTimestampUtc != nil && !matchSnapshotHistoryAge(snapshotAgeHours(*snapIDMapping.SnapshotTimestampUtc, now), filter) {
+				continue
+			}
+			// the snap ID, unlike the generation, does not change when snapshots are created or terminated while reading
+			snapshot, _, err := GetSnapshotSnapIDSG(ctx, client, sgName, snapshotName, snapIDMapping.GetSnapid())
+			if err != nil {
+				return nil, err
+			}
+			if volumeID != "" && !hasSourceVolume(snapshot, volumeID) {
+				continue
+			}
+			if filter != nil && !filter.Expired.IsNull() && snapshot.Expired != filter.Expired.ValueBool() {
+				continue
+			}
+			if !matchSnapshotHistoryAge(snapshotAgeHours(snapshot.TimestampUtc, now), filter) {
+				continue
+			}
+			generations = append(generations, NewSnapshotHistoryGeneration(sgName, snapshot, now))
+		}
+	}
+	sort.SliceStable(generations, func(i, j int) bool {
+		if generations[i].SnapshotName.ValueString() != generations[j].SnapshotName.ValueString() {
+			return generations[i].SnapshotName.ValueString() < generations[j].SnapshotName.ValueString()
+		}
+		return generations[i].Generation.ValueInt64() < generations[j].Generation.ValueInt64()
+	})
+	return generations, nil
+}
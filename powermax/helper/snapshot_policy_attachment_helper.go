@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// EditStorageGroupSnapshotPolicies edits the snapshot policies of a storage group from the storage group side.
+func EditStorageGroupSnapshotPolicies(ctx context.Context, client client.Client, sgName string, param pmax.EditSnapshotPoliciesParam) (*pmax.StorageGroup, *http.Response, error) {
+	modifyReq := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgName)
+	modifyReq = modifyReq.EditStorageGroupParam(pmax.EditStorageGroupParam{
+		EditStorageGroupActionParam: pmax.EditStorageGroupActionParam{
+			EditSnapshotPoliciesParam: &param,
+		},
+	})
+	return modifyReq.Execute()
+}
+
+// AttachSnapshotPolicy associates the snapshot policy with the storage group.
+func AttachSnapshotPolicy(ctx context.Context, client client.Client, sgName string, snapPolicyID string) error {
+	_, _, err := EditStorageGroupSnapshotPolicies(ctx, client, sgName, pmax.EditSnapshotPoliciesParam{
+		AssociateSnapshotPolicyParam: &pmax.AssociateSnapshotPolicyParam{
+			SnapshotPolicies: []string{snapPolicyID},
+		},
+	})
+	return err
+}
+
+// DetachSnapshotPolicy disassociates the snapshot policy from the storage group.
+func DetachSnapshotPolicy(ctx context.Context, client client.Client, sgName string, snapPolicyID string) error {
+	_, _, err := EditStorageGroupSnapshotPolicies(ctx, client, sgName, pmax.EditSnapshotPoliciesParam{
+		DisassociateSnapshotPolicyParam: &pmax.DisassociateSnapshotPolicyParam{
+			SnapshotPolicies: []string{snapPolicyID},
+		},
+	})
+	return err
+}
+
+// SuspendOrResumeStorageGroupSnapshotPolicy suspends or resumes the snapshot policy for the storage group only.
+func SuspendOrResumeStorageGroupSnapshotPolicy(ctx context.Context, client client.Client, sgName string, snapPolicyID string, suspend bool) error {
+	param := pmax.EditSnapshotPoliciesParam{}
+	if suspend {
+		param.SuspendSnapshotPolicyParam = &pmax.SuspendSnapshotPolicyParam{
+			SnapshotPolicies: []string{snapPolicyID},
+		}
+	} else {
+		param.ResumeSnapshotPolicyParam = &pmax.ResumeSnapshotPolicyParam{
+			SnapshotPolicies: []string{snapPolicyID},
+		}
+	}
+	_, _, err := EditStorageGroupSnapshotPolicies(ctx, client, sgName, param)
+	return err
+}
+
+// SnapshotPolicyAttachmentID returns the ID of the association of a storage group with a snapshot policy.
+func SnapshotPolicyAttachmentID(sgName string, snapPolicyID string) string {
+	return sgName + "." + snapPolicyID
+}
+
+// ParseSnapshotPolicyAttachmentID returns the storage group and snapshot policy names of an attachment ID.
+func ParseSnapshotPolicyAttachmentID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("the import ID must be storage_group_name.snapshot_policy_name, got %s", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// UpdateSnapshotPolicyAttachmentState updates the attachment state from the snapshot policy storage group details.
+func UpdateSnapshotPolicyAttachmentState(state *models.SnapshotPolicyAttachmentResourceModel, policySg *pmax.SnapshotPolicyStorageGroup) {
+	state.ID = types.StringValue(SnapshotPolicyAttachmentID(policySg.StorageGroupId, policySg.SnapshotPolicyId))
+	state.StorageGroupName = types.StringValue(policySg.StorageGroupId)
+	state.SnapshotPolicyName = types.StringValue(policySg.SnapshotPolicyId)
+	state.Suspended = types.BoolValue(policySg.GetSuspended())
+	state.Compliance = types.StringPointerValue(policySg.Compliance)
+}
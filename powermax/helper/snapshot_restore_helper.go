@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-powermax/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// snapshotRestorePollInterval is the time between two checks of the progress of a snapshot restore.
+const snapshotRestorePollInterval = 15 * time.Second
+
+// restoreWindowLayout is the layout of the start and end of a restore window.
+const restoreWindowLayout = "15:04"
+
+// InRestoreWindow returns whether now is within the UTC window from start to end, both in the HH:MM format.
+// The window wraps around midnight when end is before start.
+func InRestoreWindow(now time.Time, start string, end string) (bool, error) {
+	startTime, err := time.Parse(restoreWindowLayout, start)
+	if err != nil {
+		return false, fmt.Errorf("invalid restore window start %s, expected HH:MM", start)
+	}
+	endTime, err := time.Parse(restoreWindowLayout, end)
+	if err != nil {
+		return false, fmt.Errorf("invalid restore window end %s, expected HH:MM", end)
+	}
+	now = now.UTC()
+	minutes := now.Hour()*60 + now.Minute()
+	startMinutes := startTime.Hour()*60 + startTime.Minute()
+	endMinutes := endTime.Hour()*60 + endTime.Minute()
+	if startMinutes <= endMinutes {
+		return minutes >= startMinutes && minutes < endMinutes, nil
+	}
+	return minutes >= startMinutes || minutes < endMinutes, nil
+}
+
+// RestoreSnapshot starts the restore of the snapshot generation to its storage group.
+func RestoreSnapshot(ctx context.Context, client client.Client, sgName string, snapshotName string, snapID int64, remote bool) error {
+	modifyParam := client.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotSnapID(ctx, client.SymmetrixID, sgName, snapshotName, snapID)
+	_, _, err := modifyParam.StorageGroupSnapshotInstanceUpdate(pmax.StorageGroupSnapshotInstanceUpdate{
+		Action: ActionSnapshotRestore,
+		Restore: &pmax.SnapVxRestoreOptions{
+			Remote: &remote,
+		},
+	}).Execute()
+	return err
+}
+
+// isRestoreComplete returns whether the snapshot generation is restored and no longer copying back to the source.
+func isRestoreComplete(snapshotDetail *pmax.SnapVXSnapshotInstance) bool {
+	if !snapshotDetail.Restored {
+		return false
+	}
+	for _, state := range snapshotDetail.State {
+		if strings.Contains(strings.ToLower(state), "prog") {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitForSnapshotRestore polls the snapshot generation until its restore has completed, or until the timeout expires.
+func WaitForSnapshotRestore(ctx context.Context, client client.Client, sgName string, snapshotName string, snapID int64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		snapshotDetail, _, err := GetSnapshotSnapIDSG(ctx, client, sgName, snapshotName, snapID)
+		if err != nil {
+			return err
+		}
+		tflog.Info(ctx, "waiting for snapshot restore", map[string]interface{}{
+			"snapshot": snapshotName,
+			"snapID":   snapID,
+			"restored": snapshotDetail.Restored,
+			"state":    snapshotDetail.State,
+		})
+		if isRestoreComplete(snapshotDetail) {
+			return nil
+		}
+		if time.Now().Add(snapshotRestorePollInterval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for the restore of snapshot %s, state: %s", timeout, snapshotName, strings.Join(snapshotDetail.State, ", "))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(snapshotRestorePollInterval):
+		}
+	}
+}
+
+// TerminateSnapshotRestore terminates the restore session of the snapshot generation if it is still restored.
+// A delete on a restored snapshot generation only terminates its restore session, the snapshot is kept.
+// There is nothing to terminate when the snapshot generation no longer exists.
+func TerminateSnapshotRestore(ctx context.Context, client client.Client, sgName string, snapshotName string, snapID int64) error {
+	snapshotDetail, resp, err := GetSnapshotSnapIDSG(ctx, client, sgName, snapshotName, snapID)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !snapshotDetail.Restored {
+		return nil
+	}
+	_, err = client.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotSnapID(ctx, client.SymmetrixID, sgName, snapshotName, snapID).Execute()
+	return err
+}
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"net/http"
+	"sort"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// SnapshotSetGenerationType is the object type of the generations of a snapshot set.
+var SnapshotSetGenerationType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"generation":               types.Int64Type,
+		"snapid":                   types.Int64Type,
+		"timestamp":                types.StringType,
+		"timestamp_utc":            types.Int64Type,
+		"time_to_live_expiry_date": types.StringType,
+		"expired":                  types.BoolType,
+		"linked":                   types.BoolType,
+		"restored":                 types.BoolType,
+	},
+}
+
+// CreateSnapshotSetGeneration creates a new generation of the snapshot set.
+func CreateSnapshotSetGeneration(ctx context.Context, client client.Client, plan models.SnapshotSetResourceModel) (*pmax.SnapVXSnapshotGeneration, *http.Response, error) {
+	snapshotCreateParam := pmax.StorageGroupSnapshotCreate{
+		SnapshotName: plan.SnapshotName.ValueString(),
+	}
+	if plan.TimeToLive.ValueInt64() != 0 {
+		ttl := int32(plan.TimeToLive.ValueInt64())
+		snapshotCreateParam.TimeToLive = &ttl
+		snapshotCreateParam.TimeInHours = plan.TimeInHours.ValueBoolPointer()
+	}
+	createParam := client.PmaxOpenapiClient.ReplicationApi.CreateSnapshot1(ctx, client.SymmetrixID, plan.StorageGroupName.ValueString())
+	return createParam.StorageGroupSnapshotCreate(snapshotCreateParam).Execute()
+}
+
+// TerminateSnapshotSetGenerations terminates the oldest generations of the snapshot so that only keepCount remain.
+func TerminateSnapshotSetGenerations(ctx context.Context, client client.Client, sgName string, snapshotName string, keepCount int64) (*http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.TerminateSnapshots(ctx, client.SymmetrixID, sgName).SnapshotName(snapshotName).KeepCount(keepCount).Execute()
+}
+
+// DeleteSnapshotSet terminates all the generations of the snapshot.
+func DeleteSnapshotSet(ctx context.Context, client client.Client, sgName string, snapshotName string) (*http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.TerminateSnapshots(ctx, client.SymmetrixID, sgName).SnapshotName(snapshotName).Execute()
+}
+
+// GetSnapshotSetGenerations returns the details of all the generations of the snapshot, newest first.
+func GetSnapshotSetGenerations(ctx context.Context, client client.Client, sgName string, snapshotName string) ([]pmax.SnapVXSnapshotInstance, error) {
+	snapIDs, _, err := GetStorageGroupSnapshotSnapIDs(ctx, client, sgName, snapshotName)
+	if err != nil {
+		return nil, err
+	}
+	generations := make([]pmax.SnapVXSnapshotInstance, 0, len(snapIDs.Snapids))
+	for _, snapID := range snapIDs.Snapids {
+		detail, _, err := GetSnapshotSnapIDSG(ctx, client, sgName, snapshotName, snapID)
+		if err != nil {
+			return nil, err
+		}
+		generations = append(generations, *detail)
+	}
+	sort.Slice(generations, func(i, j int) bool {
+		return generations[i].GetGeneration() < generations[j].GetGeneration()
+	})
+	return generations, nil
+}
+
+// UpdateSnapshotSetState updates the computed attributes of the snapshot set from its generations.
+func UpdateSnapshotSetState(ctx context.Context, generations []pmax.SnapVXSnapshotInstance, state *models.SnapshotSetResourceModel) error {
+	state.ID = types.StringValue(fmt.Sprintf("%s.%s", state.StorageGroupName.ValueString(), state.SnapshotName.ValueString()))
+	generationModels := make([]models.SnapshotSetGenerationModel, 0, len(generations))
+	for _, generation := range generations {
+		generationModels = append(generationModels, models.SnapshotSetGenerationModel{
+			Generation:           types.Int64Value(generation.GetGeneration()),
+			Snapid:               types.Int64Value(generation.GetSnapid()),
+			Timestamp:            types.StringValue(generation.Timestamp),
+			TimestampUtc:         types.Int64Value(generation.TimestampUtc),
+			TimeToLiveExpiryDate: types.StringValue(generation.GetTimeToLiveExpiryDate()),
+			Expired:              types.BoolValue(generation.Expired),
+			Linked:               types.BoolValue(generation.Linked),
+			Restored:             types.BoolValue(generation.Restored),
+		})
+	}
+	state.LatestSnapid = types.Int64Null()
+	if len(generations) > 0 {
+		state.LatestSnapid = types.Int64Value(generations[0].GetSnapid())
+	}
+	generationList, diags := types.ListValueFrom(ctx, SnapshotSetGenerationType, generationModels)
+	if diags.HasError() {
+		return fmt.Errorf("failed to convert the snapshot generations")
+	}
+	state.Generations = generationList
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"strings"
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/models"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -161,6 +162,15 @@ func ModifySnapshotPolicy(ctx context.Context, client client.Client, plan *model
 		}
 	}
 
+	// Suspend or resume after the rename so the new name is used
+	if !plan.Suspended.IsUnknown() && !plan.Suspended.IsNull() && plan.Suspended.ValueBool() != state.Suspended.ValueBool() {
+		_, _, err := SuspendOrResumeSnapshotPolicy(ctx, client, plan.SnapshotPolicyName.ValueString(), plan.Suspended.ValueBool())
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Error in suspend or resume snapshot policy: %s", err))
+			return err
+		}
+	}
+
 	addRemoveErr := AddOrRemoveStorageGroups(ctx, client, plan, state)
 	if len(addRemoveErr) > 0 {
 		errMessage := strings.Join(addRemoveErr, ",\n")
@@ -171,9 +181,23 @@ func ModifySnapshotPolicy(ctx context.Context, client client.Client, plan *model
 	return nil
 }
 
+// SuspendOrResumeSnapshotPolicy suspends the snapshot policy when suspend is true, resumes it otherwise.
+func SuspendOrResumeSnapshotPolicy(ctx context.Context, client client.Client, snapPolicyID string, suspend bool) (*pmax.SnapshotPolicy, *http.Response, error) {
+	action := "Resume"
+	if suspend {
+		action = "Suspend"
+	}
+	updateReq := client.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotPolicy(ctx, client.SymmetrixID, snapPolicyID)
+	return updateReq.SnapshotPolicyUpdate(*pmax.NewSnapshotPolicyUpdate(action)).Execute()
+}
+
 // AddOrRemoveStorageGroups add/remove storage group from snapshot policy
 func AddOrRemoveStorageGroups(ctx context.Context, client client.Client, plan *models.SnapshotPolicyResource, state *models.SnapshotPolicyResource) []string {
 	errorMessages := []string{}
+	// The storage groups are not managed by the snapshot policy when they are not configured
+	if plan.StorageGroups.IsUnknown() || plan.StorageGroups.IsNull() {
+		return errorMessages
+	}
 	var planStorageGroups []string
 	diags := plan.StorageGroups.ElementsAs(ctx, &planStorageGroups, true)
 	if diags.HasError() {
@@ -294,3 +318,68 @@ func CreateSnapshotPolicy(ctx context.Context, client client.Client, planSnapPol
 	snapPolicyCreateReq = snapPolicyCreateReq.SnapshotPolicyCreate(*createSnapPolicyParam)
 	return snapPolicyCreateReq.Execute()
 }
+
+// GetSnapshotPolicyStorageGroup get the details of a storage group associated with the snapshot policy
+func GetSnapshotPolicyStorageGroup(ctx context.Context, client client.Client, snapPolicyID string, sgName string) (*powermax.SnapshotPolicyStorageGroup, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicyStorageGroup(ctx, client.SymmetrixID, snapPolicyID, sgName).Execute()
+}
+
+// MatchSnapshotPolicyFilter returns true if the snapshot policy passes the type, suspended and interval filters.
+func MatchSnapshotPolicyFilter(policy *pmax.SnapshotPolicy, filter *models.SnapshotPolicyFilterType) bool {
+	if filter == nil {
+		return true
+	}
+	if !filter.Type.IsNull() && !strings.EqualFold(policy.GetType(), filter.Type.ValueString()) {
+		return false
+	}
+	if !filter.Suspended.IsNull() && policy.GetSuspended() != filter.Suspended.ValueBool() {
+		return false
+	}
+	if !filter.MinIntervalMinutes.IsNull() && policy.GetIntervalMinutes() < filter.MinIntervalMinutes.ValueInt64() {
+		return false
+	}
+	if !filter.MaxIntervalMinutes.IsNull() && policy.GetIntervalMinutes() > filter.MaxIntervalMinutes.ValueInt64() {
+		return false
+	}
+	return true
+}
+
+// GetSnapshotPolicyStorageGroupDetails get the compliance and last snapshot time of the storage groups associated with the snapshot policy.
+func GetSnapshotPolicyStorageGroupDetails(ctx context.Context, client client.Client, snapPolicyID string) ([]models.SnapshotPolicyStorageGroupModel, error) {
+	sgList, _, err := GetSnapshotPolicyStorageGroups(ctx, client, snapPolicyID)
+	if err != nil {
+		return nil, err
+	}
+	details := []models.SnapshotPolicyStorageGroupModel{}
+	for _, sgName := range sgList.Name {
+		policySg, _, err := GetSnapshotPolicyStorageGroup(ctx, client, snapPolicyID, sgName)
+		if err != nil {
+			return nil, err
+		}
+		detail := models.SnapshotPolicyStorageGroupModel{
+			StorageGroupName:      types.StringValue(sgName),
+			Compliance:            types.StringPointerValue(policySg.Compliance),
+			SnapshotsInTimeWindow: types.Int64Value(int64(policySg.GetSnapshotsInTimeWindow())),
+			TotalSnapshots:        types.Int64Value(int64(policySg.GetTotalSnapshots())),
+			Suspended:             types.BoolValue(policySg.GetSuspended()),
+			LastSnapshotTime:      types.StringNull(),
+			LastSnapshotTimestamp: types.Int64Null(),
+		}
+		snapshots, _, err := GetStorageGroupSnapshots(ctx, client, sgName)
+		if err != nil {
+			return nil, err
+		}
+		var lastSnapshot int64
+		for _, snapshot := range snapshots.SnapshotNamesAndCounts {
+			if isPolicySnapshot(snapshot.GetName(), snapPolicyID) && snapshot.GetNewestTimestampUtc() > lastSnapshot {
+				lastSnapshot = snapshot.GetNewestTimestampUtc()
+			}
+		}
+		if lastSnapshot > 0 {
+			detail.LastSnapshotTime = types.StringValue(time.UnixMilli(lastSnapshot).UTC().Format(time.RFC3339))
+			detail.LastSnapshotTimestamp = types.Int64Value(lastSnapshot)
+		}
+		details = append(details, detail)
+	}
+	return details, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"errors"
+	"fmt"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// hasStorageGroupSetting returns true if a storage group setting such as the service level or SRP is set to something else than None.
+func hasStorageGroupSetting(value string) bool {
+	return value != "" && !strings.EqualFold(value, "None")
+}
+
+// ValidateStorageGroupCascade checks that the children can be added to the parent storage group.
+// PowerMax supports a single level of cascading, the service level is set on the children, which must share the SRP of the parent,
+// and a child cannot be masked on its own or be the child of another storage group.
+func ValidateStorageGroupCascade(parent *pmax.StorageGroup, children []*pmax.StorageGroup) error {
+	var errs []string
+	if len(parent.ParentStorageGroup) > 0 {
+		errs = append(errs, fmt.Sprintf("storage group %s is a child of %s, it cannot be a parent", parent.StorageGroupId, strings.Join(parent.ParentStorageGroup, ", ")))
+	}
+	if len(parent.ChildStorageGroup) == 0 && parent.GetNumOfVols() > 0 {
+		errs = append(errs, fmt.Sprintf("storage group %s contains volumes, it cannot be a parent", parent.StorageGroupId))
+	}
+	if hasStorageGroupSetting(parent.GetSlo()) {
+		errs = append(errs, fmt.Sprintf("parent storage group %s has service level %s, set the service level on the child storage groups instead", parent.StorageGroupId, parent.GetSlo()))
+	}
+	for _, child := range children {
+		if child.StorageGroupId == parent.StorageGroupId {
+			errs = append(errs, fmt.Sprintf("storage group %s cannot be its own child", child.StorageGroupId))
+			continue
+		}
+		if len(child.Maskingview) > 0 {
+			errs = append(errs, fmt.Sprintf("child storage group %s is in masking view(s) %s, mask the parent storage group instead", child.StorageGroupId, strings.Join(child.Maskingview, ", ")))
+		}
+		if len(child.ChildStorageGroup) > 0 {
+			errs = append(errs, fmt.Sprintf("child storage group %s has child storage groups, only one level of cascading is supported", child.StorageGroupId))
+		}
+		for _, childParent := range child.ParentStorageGroup {
+			if childParent != parent.StorageGroupId {
+				errs = append(errs, fmt.Sprintf("child storage group %s is already a child of %s", child.StorageGroupId, childParent))
+			}
+		}
+		if hasStorageGroupSetting(parent.GetSrp()) && hasStorageGroupSetting(child.GetSrp()) && parent.GetSrp() != child.GetSrp() {
+			errs = append(errs, fmt.Sprintf("child storage group %s is in SRP %s, the parent storage group is in SRP %s", child.StorageGroupId, child.GetSrp(), parent.GetSrp()))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ",\n"))
+	}
+	return nil
+}
+
+// AddChildStorageGroups adds existing storage groups as children of the parent storage group.
+func AddChildStorageGroups(ctx context.Context, client client.Client, parentName string, childNames []string, enableComplianceAlerts bool) error {
+	modifyReq := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, parentName)
+	modifyReq = modifyReq.EditStorageGroupParam(pmax.EditStorageGroupParam{
+		EditStorageGroupActionParam: pmax.EditStorageGroupActionParam{
+			ExpandStorageGroupParam: &pmax.ExpandStorageGroupParam{
+				AddExistingStorageGroupParam: &pmax.AddExistingStorageGroupParam{
+					StorageGroupId:         childNames,
+					EnableComplianceAlerts: &enableComplianceAlerts,
+				},
+			},
+		},
+	})
+	_, _, err := modifyReq.Execute()
+	return err
+}
+
+// RemoveChildStorageGroups removes child storage groups from the parent storage group, the child storage groups are kept.
+func RemoveChildStorageGroups(ctx context.Context, client client.Client, parentName string, childNames []string, force bool) error {
+	modifyReq := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, parentName)
+	modifyReq = modifyReq.EditStorageGroupParam(pmax.EditStorageGroupParam{
+		EditStorageGroupActionParam: pmax.EditStorageGroupActionParam{
+			RemoveStorageGroupParam: &pmax.RemoveStorageGroupParam{
+				StorageGroupId: childNames,
+				Force:          &force,
+			},
+		},
+	})
+	_, _, err := modifyReq.Execute()
+	return err
+}
+
+// ValidateChildStorageGroups reads the parent and the new child storage groups and checks that the children can be added.
+func ValidateChildStorageGroups(ctx context.Context, client client.Client, parentName string, childNames []string) error {
+	parent, _, err := GetStorageGroup(ctx, client, parentName)
+	if err != nil {
+		return err
+	}
+	children := make([]*pmax.StorageGroup, 0, len(childNames))
+	for _, childName := range childNames {
+		child, _, err := GetStorageGroup(ctx, client, childName)
+		if err != nil {
+			return err
+		}
+		children = append(children, child)
+	}
+	return ValidateStorageGroupCascade(parent, children)
+}
+
+// UpdateStorageGroupCascadeState updates the state with the child storage groups of the parent storage group.
+func UpdateStorageGroupCascadeState(ctx context.Context, state *models.StorageGroupCascadeResourceModel, parent *pmax.StorageGroup) diag.Diagnostics {
+	state.ID = types.StringValue(parent.StorageGroupId)
+	state.ParentStorageGroupName = types.StringValue(parent.StorageGroupId)
+	children := parent.ChildStorageGroup
+	if children == nil {
+		children = []string{}
+	}
+	childSet, diags := types.SetValueFrom(ctx, types.StringType, children)
+	state.ChildStorageGroupNames = childSet
+	if state.EnableComplianceAlerts.IsNull() || state.EnableComplianceAlerts.IsUnknown() {
+		state.EnableComplianceAlerts = types.BoolValue(false)
+	}
+	if state.ForceRemove.IsNull() || state.ForceRemove.IsUnknown() {
+		state.ForceRemove = types.BoolValue(false)
+	}
+	return diags
+}
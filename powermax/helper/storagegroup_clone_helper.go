@@ -0,0 +1,226 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"net/http"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// ActionCloneActivate sets the clone pairs to copy mode so the background copy runs.
+	ActionCloneActivate = "Activate"
+	// ActionCloneRecreate incrementally re-establishes the clone pairs.
+	ActionCloneRecreate = "Recreate"
+	// ActionCloneRestore copies the target storage group back to the source storage group.
+	ActionCloneRestore = "Restore"
+	// ActionCloneSplit splits the clone pairs.
+	ActionCloneSplit = "Split"
+
+	// The actions understood by the clone update API.
+	cloneUpdateEstablish = "Establish"
+	cloneUpdateRestore   = "Restore"
+	cloneUpdateSplit     = "Split"
+	cloneUpdateSetMode   = "SetMode"
+)
+
+// EstablishStorageGroupClone establishes a clone session from the source storage group to the target storage group.
+func EstablishStorageGroupClone(ctx context.Context, client client.Client, plan models.StorageGroupCloneResourceModel) (*pmax.CloneStorageGroupPair, *http.Response, error) {
+	establishParam := pmax.StorageGroupCloneEstablish{
+		TargetStorageGroupName: plan.TargetStorageGroupName.ValueStringPointer(),
+		Consistent:             plan.Consistent.ValueBoolPointer(),
+		Vse:                    plan.Vse.ValueBoolPointer(),
+		Force:                  plan.Force.ValueBoolPointer(),
+		Star:                   plan.Star.ValueBoolPointer(),
+		Skip:                   plan.Skip.ValueBoolPointer(),
+	}
+	return client.PmaxOpenapiClient.ReplicationApi.EstablishStorageGroupClone(ctx, client.SymmetrixID, plan.StorageGroupName.ValueString()).StorageGroupCloneEstablish(establishParam).Execute()
+}
+
+// GetStorageGroupClone gets the clone target storage groups of a storage group.
+func GetStorageGroupClone(ctx context.Context, client client.Client, sgName string) (*pmax.StorageGroupCloneTargetList, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupClone(ctx, client.SymmetrixID, sgName).Execute()
+}
+
+// StorageGroupCloneExists checks that the target storage group is still a clone target of the source storage group.
+// A clone session terminated outside of Terraform is reported as not existing.
+func StorageGroupCloneExists(ctx context.Context, client client.Client, sgName, targetSgName string) (bool, error) {
+	targets, resp, err := GetStorageGroupClone(ctx, client, sgName)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return StringInSlice(targetSgName, targets.CloneTargetSgNames), nil
+}
+
+// GetStorageGroupClonePairs gets the clone volume pairs of a storage group.
+func GetStorageGroupClonePairs(ctx context.Context, client client.Client, sgName string) (*pmax.CloneVolumePairList, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupClonePairs(ctx, client.SymmetrixID, sgName).Execute()
+}
+
+// ModifyStorageGroupClone performs the clone action in the plan against the clone session.
+func ModifyStorageGroupClone(ctx context.Context, client client.Client, plan models.StorageGroupCloneResourceModel) (*pmax.CloneStorageGroupPair, *http.Response, error) {
+	var updateParam *pmax.StorageGroupCloneUpdate
+	switch plan.Action.ValueString() {
+	case ActionCloneActivate:
+		copyMode := true
+		updateParam = pmax.NewStorageGroupCloneUpdate(cloneUpdateSetMode)
+		updateParam.SetSetMode(pmax.StorageGroupCloneSetMode{
+			Copy:  &copyMode,
+			Force: plan.Force.ValueBoolPointer(),
+		})
+	case ActionCloneRecreate:
+		updateParam = pmax.NewStorageGroupCloneUpdate(cloneUpdateEstablish)
+		updateParam.SetEstablish(pmax.StorageGroupCloneEstablishIncremental{
+			Consistent: plan.Consistent.ValueBoolPointer(),
+			Vse:        plan.Vse.ValueBoolPointer(),
+			Force:      plan.Force.ValueBoolPointer(),
+			Star:       plan.Star.ValueBoolPointer(),
+			Skip:       plan.Skip.ValueBoolPointer(),
+		})
+	case ActionCloneRestore:
+		updateParam = pmax.NewStorageGroupCloneUpdate(cloneUpdateRestore)
+		updateParam.SetRestore(pmax.StorageGroupCloneRestore{
+			Force: plan.Force.ValueBoolPointer(),
+			Star:  plan.Star.ValueBoolPointer(),
+		})
+	case ActionCloneSplit:
+		updateParam = pmax.NewStorageGroupCloneUpdate(cloneUpdateSplit)
+		updateParam.SetSplit(pmax.StorageGroupCloneSplit{
+			Force: plan.Force.ValueBoolPointer(),
+			Star:  plan.Star.ValueBoolPointer(),
+			Skip:  plan.Skip.ValueBoolPointer(),
+		})
+	default:
+		return nil, nil, fmt.Errorf("unsupported clone action %s", plan.Action.ValueString())
+	}
+	return client.PmaxOpenapiClient.ReplicationApi.UpdateStorageGroupClone(ctx, client.SymmetrixID, plan.StorageGroupName.ValueString(), plan.TargetStorageGroupName.ValueString()).StorageGroupCloneUpdate(*updateParam).Execute()
+}
+
+// TerminateStorageGroupClone terminates the clone session between the source and target storage groups.
+func TerminateStorageGroupClone(ctx context.Context, client client.Client, state models.StorageGroupCloneResourceModel) (*http.Response, error) {
+	terminateParam := client.PmaxOpenapiClient.ReplicationApi.TerminateCloneSg(ctx, client.SymmetrixID, state.StorageGroupName.ValueString())
+	terminateParam = terminateParam.TargetStorageGroup(state.TargetStorageGroupName.ValueString())
+	// A restored session has to be terminated with the restored flag
+	if state.Action.ValueString() == ActionCloneRestore {
+		terminateParam = terminateParam.Restored(true)
+	}
+	if !state.Force.IsNull() {
+		terminateParam = terminateParam.Force(state.Force.ValueBool())
+	}
+	if !state.Star.IsNull() {
+		terminateParam = terminateParam.Star(state.Star.ValueBool())
+	}
+	if !state.Skip.IsNull() {
+		terminateParam = terminateParam.Skip(state.Skip.ValueBool())
+	}
+	return terminateParam.Execute()
+}
+
+// UpdateStorageGroupCloneState updates the clone state with the copy progress of the volume pairs in the target storage group.
+func UpdateStorageGroupCloneState(ctx context.Context, client client.Client, state *models.StorageGroupCloneResourceModel) error {
+	sgName := state.StorageGroupName.ValueString()
+	targetSgName := state.TargetStorageGroupName.ValueString()
+
+	targets, _, err := GetStorageGroupClone(ctx, client, sgName)
+	if err != nil {
+		return err
+	}
+	if !StringInSlice(targetSgName, targets.CloneTargetSgNames) {
+		return fmt.Errorf("storage group %s is not a clone target of storage group %s", targetSgName, sgName)
+	}
+
+	pairs, _, err := GetStorageGroupClonePairs(ctx, client, sgName)
+	if err != nil {
+		return err
+	}
+
+	// The pairs are listed for every target of the source storage group, so only keep those whose target is in this target storage group
+	volIDModel := client.PmaxOpenapiClient.SLOProvisioningApi.ListVolumes(ctx, client.SymmetrixID)
+	volIDModel = volIDModel.StorageGroupId(targetSgName)
+	volumeIDList, _, err := volIDModel.Execute()
+	if err != nil {
+		return err
+	}
+	targetVolumes := make([]string, 0)
+	for _, v := range volumeIDList.GetResultList().Result {
+		for _, v2 := range v {
+			targetVolumes = append(targetVolumes, fmt.Sprint(v2))
+		}
+	}
+
+	var modifiedTracks, srcProtectedTracks, srcModifiedTracks int64
+	pairStates := make([]string, 0)
+	volumePairs := make([]models.CloneVolumePairModel, 0)
+	for _, pair := range pairs.Clones {
+		if !StringInSlice(pair.GetTargetVolumeName(), targetVolumes) {
+			continue
+		}
+		volumePairs = append(volumePairs, models.CloneVolumePairModel{
+			SourceVolumeName:   types.StringValue(pair.GetSourceVolumeName()),
+			TargetVolumeName:   types.StringValue(pair.GetTargetVolumeName()),
+			State:              types.StringValue(pair.GetState()),
+			ModifiedTracks:     types.Int64Value(pair.GetModifiedTracks()),
+			SrcProtectedTracks: types.Int64Value(pair.GetSrcProtectedTracks()),
+			SrcModifiedTracks:  types.Int64Value(pair.GetSrcModifiedTracks()),
+			BackgroundCopy:     types.BoolValue(pair.GetBackgroundCopy()),
+			Differential:       types.BoolValue(pair.GetDifferential()),
+			Precopy:            types.BoolValue(pair.GetPrecopy()),
+		})
+		modifiedTracks += pair.GetModifiedTracks()
+		srcProtectedTracks += pair.GetSrcProtectedTracks()
+		srcModifiedTracks += pair.GetSrcModifiedTracks()
+		if !StringInSlice(pair.GetState(), pairStates) {
+			pairStates = append(pairStates, pair.GetState())
+		}
+	}
+
+	pairStateList, diags := types.ListValueFrom(ctx, types.StringType, pairStates)
+	if diags.HasError() {
+		return fmt.Errorf("failed to set the clone state")
+	}
+
+	SetStorageGroupCloneMinimalState(state)
+	state.State = pairStateList
+	state.VolumePairCount = types.Int64Value(int64(len(volumePairs)))
+	state.ModifiedTracks = types.Int64Value(modifiedTracks)
+	state.SrcProtectedTracks = types.Int64Value(srcProtectedTracks)
+	state.SrcModifiedTracks = types.Int64Value(srcModifiedTracks)
+	state.VolumePairs = volumePairs
+	return nil
+}
+
+// SetStorageGroupCloneMinimalState sets the ID of an established clone session and clears its copy progress,
+// so that the session is tracked when it cannot be read.
+func SetStorageGroupCloneMinimalState(state *models.StorageGroupCloneResourceModel) {
+	state.ID = types.StringValue(fmt.Sprintf("%s.%s", state.StorageGroupName.ValueString(), state.TargetStorageGroupName.ValueString()))
+	state.State = types.ListNull(types.StringType)
+	state.VolumePairCount = types.Int64Null()
+	state.ModifiedTracks = types.Int64Null()
+	state.SrcProtectedTracks = types.Int64Null()
+	state.SrcModifiedTracks = types.Int64Null()
+	state.VolumePairs = nil
+}
@@ -30,6 +30,29 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// StorageGroupVolumeAttributeType is the object type of the volumes created with a storage group.
+var StorageGroupVolumeAttributeType = map[string]attr.Type{
+	"size":              types.NumberType,
+	"cap_unit":          types.StringType,
+	"num_of_vols":       types.Int64Type,
+	"volume_identifier": types.StringType,
+}
+
+// HostIOLimitType is the object type of the host io limit of a storage group.
+var HostIOLimitType = map[string]attr.Type{
+	"host_io_limit_io_sec": types.StringType,
+	"host_io_limit_mb_sec": types.StringType,
+	"dynamic_distribution": types.StringType,
+}
+
+// constants of the host io limit of a storage group.
+const (
+	HostIOLimitNoLimit           = "NOLIMIT"
+	DynamicDistributionNever     = "Never"
+	DynamicDistributionAlways    = "Always"
+	DynamicDistributionOnFailure = "OnFailure"
+)
+
 // constants to annotate if a volume should be added or removed.
 const (
 	AddVolume    = 1
@@ -124,54 +147,74 @@ func AddRemoveVolume(ctx context.Context, plan *models.StorageGroupResourceModel
 }
 
 // CreateSloParam Create SLO param.
-func CreateSloParam(plan models.StorageGroupResourceModel) []powermax.SloBasedStorageGroupParam {
-
-	hostIOLimit := ConstructHostIOLimit(plan)
-	workload := "None"
+func CreateSloParam(ctx context.Context, plan models.StorageGroupResourceModel) ([]powermax.SloBasedStorageGroupParam, error) {
 	slo := "None"
-	thickVolumes := false
-	num := int64(0)
 	if plan.Slo.ValueString() != "" {
 		slo = plan.Slo.ValueString()
 	}
+	workload := "None"
+	if plan.Workload.ValueString() != "" {
+		workload = plan.Workload.ValueString()
+	}
+	volumeAttributes, err := CreateVolumeAttributesParam(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	sloParam := powermax.SloBasedStorageGroupParam{
+		SloId:             &slo,
+		WorkloadSelection: &workload,
+		VolumeAttributes:  volumeAttributes,
+	}
+	// leave compression and allocation to the array defaults when they are not configured
+	if !plan.Compression.IsNull() && !plan.Compression.IsUnknown() {
+		noCompression := !plan.Compression.ValueBool()
+		sloParam.NoCompression = &noCompression
+	}
+	if !plan.AllocateCapacityForEachVol.IsNull() && !plan.AllocateCapacityForEachVol.IsUnknown() {
+		sloParam.AllocateCapacityForEachVol = plan.AllocateCapacityForEachVol.ValueBoolPointer()
+	}
+
+	hostIOLimit := ConstructHostIOLimit(plan)
 	if hostIOLimit != nil {
-		return []powermax.SloBasedStorageGroupParam{
+		sloParam.SetHostIOLimitsParam = ConstructHostIOLimitParam(hostIOLimit)
+	}
+	return []powermax.SloBasedStorageGroupParam{sloParam}, nil
+}
+
+// CreateVolumeAttributesParam constructs the volumes to create with the storage group based on the plan.
+// A storage group without volume attributes is created empty.
+func CreateVolumeAttributesParam(ctx context.Context, plan models.StorageGroupResourceModel) ([]powermax.VolumeAttribute, error) {
+	if plan.VolumeAttributes.IsNull() || plan.VolumeAttributes.IsUnknown() || len(plan.VolumeAttributes.Elements()) == 0 {
+		num := int64(0)
+		return []powermax.VolumeAttribute{
 			{
-				SloId:                      &slo,
-				WorkloadSelection:          &workload,
-				AllocateCapacityForEachVol: &thickVolumes,
-				NoCompression:              &thickVolumes,
-				VolumeAttributes: []powermax.VolumeAttribute{
-					{
-						VolumeSize:   "0",
-						CapacityUnit: "CYL",
-						NumOfVols:    &num,
-					},
-				},
-				SetHostIOLimitsParam: &powermax.SetHostIOLimitsParam{
-					HostIoLimitMbSec:    hostIOLimit.HostIOLimitMBSec.ValueStringPointer(),
-					HostIoLimitIoSec:    hostIOLimit.HostIOLimitIOSec.ValueStringPointer(),
-					DynamicDistribution: hostIOLimit.DynamicDistribution.ValueStringPointer(),
-				},
+				VolumeSize:   "0",
+				CapacityUnit: CapacityUnitCyl,
+				NumOfVols:    &num,
 			},
-		}
+		}, nil
 	}
-	return []powermax.SloBasedStorageGroupParam{
-		{
-			SloId:                      &slo,
-			WorkloadSelection:          &workload,
-			AllocateCapacityForEachVol: &thickVolumes,
-			NoCompression:              &thickVolumes,
-			VolumeAttributes: []powermax.VolumeAttribute{
-				{
-					VolumeSize:   "0",
-					CapacityUnit: "CYL",
-					NumOfVols:    &num,
-				},
-			},
-		},
+	var planAttributes []models.StorageGroupVolumeAttribute
+	diags := plan.VolumeAttributes.ElementsAs(ctx, &planAttributes, true)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to parse volume attributes from plan")
 	}
-
+	volumeAttributes := make([]powermax.VolumeAttribute, 0, len(planAttributes))
+	for _, planAttribute := range planAttributes {
+		volumeAttribute := powermax.VolumeAttribute{
+			VolumeSize:   planAttribute.Size.ValueBigFloat().String(),
+			CapacityUnit: planAttribute.CapUnit.ValueString(),
+			NumOfVols:    planAttribute.NumOfVols.ValueInt64Pointer(),
+		}
+		if identifier := planAttribute.VolumeIdentifier.ValueString(); identifier != "" {
+			volumeAttribute.VolumeIdentifier = &powermax.VolumeIdentifier{
+				VolumeIdentifierChoice: "identifier_name",
+				IdentifierName:         &identifier,
+			}
+		}
+		volumeAttributes = append(volumeAttributes, volumeAttribute)
+	}
+	return volumeAttributes, nil
 }
 
 // UpdateSgState update the state of storage group based on the current state of the storage group.
@@ -195,30 +238,18 @@ func UpdateSgState(ctx context.Context, client *client.Client, sgID string, stat
 		state.UUID = types.StringValue(*uuid)
 	}
 
-	// set HostIOLimit
-	if storageGroup.HostIOLimit != nil {
-		state.HostIOLimit, _ = types.ObjectValue(
-			map[string]attr.Type{
-				"host_io_limit_io_sec": types.StringType,
-				"host_io_limit_mb_sec": types.StringType,
-				"dynamic_distribution": types.StringType,
-			},
-			map[string]attr.Value{
-				"host_io_limit_io_sec": types.StringValue(*storageGroup.HostIOLimit.HostIoLimitIoSec),
-				"host_io_limit_mb_sec": types.StringValue(*storageGroup.HostIOLimit.HostIoLimitMbSec),
-				"dynamic_distribution": types.StringValue(*storageGroup.HostIOLimit.DynamicDistribution),
-			})
-	} else {
+	// the array returns the tags as a comma separated string
+	state.Tags, _ = types.SetValueFrom(ctx, types.StringType, ParseTags(storageGroup.GetTags()))
+
+	// set HostIOLimit, a storage group without limits has a null host_io_limit
+	state.HostIOLimit = types.ObjectNull(HostIOLimitType)
+	if hostIOLimit, ok := storageGroup.GetHostIOLimitOk(); ok && IsHostIOLimitSet(hostIOLimit.GetHostIoLimitIoSec(), hostIOLimit.GetHostIoLimitMbSec()) {
 		state.HostIOLimit, _ = types.ObjectValue(
-			map[string]attr.Type{
-				"host_io_limit_io_sec": types.StringType,
-				"host_io_limit_mb_sec": types.StringType,
-				"dynamic_distribution": types.StringType,
-			},
+			HostIOLimitType,
 			map[string]attr.Value{
-				"host_io_limit_io_sec": types.StringNull(),
-				"host_io_limit_mb_sec": types.StringNull(),
-				"dynamic_distribution": types.StringNull(),
+				"host_io_limit_io_sec": types.StringValue(hostIOLimit.GetHostIoLimitIoSec()),
+				"host_io_limit_mb_sec": types.StringValue(hostIOLimit.GetHostIoLimitMbSec()),
+				"dynamic_distribution": types.StringValue(hostIOLimit.GetDynamicDistribution()),
 			})
 	}
 
@@ -237,12 +268,40 @@ func UpdateSgState(ctx context.Context, client *client.Client, sgID string, stat
 		return err
 	}
 	state.VolumeIDs, _ = types.ListValueFrom(ctx, types.StringType, vol)
+	// volume attributes are only known at creation, keep them typed when not set
+	if state.VolumeAttributes.IsNull() || state.VolumeAttributes.IsUnknown() {
+		state.VolumeAttributes = types.ListNull(types.ObjectType{AttrTypes: StorageGroupVolumeAttributeType})
+	}
 	// set ID
 	state.ID = types.StringValue(storageGroup.StorageGroupId)
 
 	return nil
 }
 
+// IsHostIOLimitSet returns true when either the IOs or the MBs per second of a host IO limit is limited.
+func IsHostIOLimitSet(ioSec, mbSec string) bool {
+	return (ioSec != "" && ioSec != HostIOLimitNoLimit) || (mbSec != "" && mbSec != HostIOLimitNoLimit)
+}
+
+// ConstructHostIOLimitParam constructs the param to set the host io limit of a storage group.
+// A nil host io limit removes the limits of the storage group.
+func ConstructHostIOLimitParam(hostIOLimit *models.SetHostIOLimitsParam) *powermax.SetHostIOLimitsParam {
+	if hostIOLimit == nil {
+		noLimit := HostIOLimitNoLimit
+		distribution := DynamicDistributionNever
+		return &powermax.SetHostIOLimitsParam{
+			HostIoLimitMbSec:    &noLimit,
+			HostIoLimitIoSec:    &noLimit,
+			DynamicDistribution: &distribution,
+		}
+	}
+	return &powermax.SetHostIOLimitsParam{
+		HostIoLimitMbSec:    hostIOLimit.HostIOLimitMBSec.ValueStringPointer(),
+		HostIoLimitIoSec:    hostIOLimit.HostIOLimitIOSec.ValueStringPointer(),
+		DynamicDistribution: hostIOLimit.DynamicDistribution.ValueStringPointer(),
+	}
+}
+
 // ConstructHostIOLimit constructs the host io limit param based on the plan.
 func ConstructHostIOLimit(plan models.StorageGroupResourceModel) *models.SetHostIOLimitsParam {
 	if !plan.HostIOLimit.IsNull() && !plan.HostIOLimit.IsUnknown() {
@@ -258,12 +317,21 @@ func GetStorageGroupList(ctx context.Context, client *client.Client) (*powermax.
 	return client.PmaxOpenapiClient.SLOProvisioningApi.ListStorageGroups(ctx, client.SymmetrixID).Execute()
 }
 
+// GetStorageGroup get the details of a storage group, such as its masking views, snapshot policies and child storage groups.
+func GetStorageGroup(ctx context.Context, client client.Client, sgName string) (*powermax.StorageGroup, *http.Response, error) {
+	return client.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, client.SymmetrixID, sgName).Execute()
+}
+
 // CreateStorageGroup create the StorageGroup
 func CreateStorageGroup(ctx context.Context, client *client.Client, plan models.StorageGroupResourceModel) (*powermax.StorageGroup, *http.Response, error) {
 	sgModel := client.PmaxOpenapiClient.SLOProvisioningApi.CreateStorageGroup(ctx, client.SymmetrixID)
 	create := powermax.NewCreateStorageGroupParam(plan.StorageGroupID.ValueString())
 	create.SetSrpId(plan.Srp.ValueString())
-	create.SetSloBasedStorageGroupParam(CreateSloParam(plan))
+	sloParam, err := CreateSloParam(ctx, plan)
+	if err != nil {
+		return nil, nil, err
+	}
+	create.SetSloBasedStorageGroupParam(sloParam)
 	sgModel = sgModel.CreateStorageGroupParam(*create)
 	return sgModel.Execute()
 }
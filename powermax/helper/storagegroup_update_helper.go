@@ -0,0 +1,223 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"dell/powermax-go-client"
+	"fmt"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+)
+
+// StorageGroupUpdateStep is a single modification of a storage group.
+// The REST API only accepts one edit action per call, so each step is one call.
+type StorageGroupUpdateStep struct {
+	// Name is the attribute modified by the step.
+	Name string
+	// Action is the edit action of the step, nil when the step is applied by Apply.
+	Action *powermax.EditStorageGroupActionParam
+	// Apply modifies the storage group when the step is not a single edit action.
+	Apply func(ctx context.Context, client *client.Client, sgID string) error
+	// Record copies the planned value of the step into the state once it is applied.
+	Record func(state *models.StorageGroupResourceModel)
+}
+
+// StorageGroupUpdateResult describes which steps of an update were applied.
+type StorageGroupUpdateResult struct {
+	Applied []string
+	Failed  string
+	Err     error
+	Skipped []string
+}
+
+// Summary describes the result of a failed update.
+func (r StorageGroupUpdateResult) Summary() string {
+	summary := fmt.Sprintf("Failed to update %s: %s.", r.Failed, r.Err.Error())
+	if len(r.Applied) > 0 {
+		summary += fmt.Sprintf(" Applied: %s.", strings.Join(r.Applied, ", "))
+	}
+	if len(r.Skipped) > 0 {
+		summary += fmt.Sprintf(" Not applied: %s.", strings.Join(r.Skipped, ", "))
+	}
+	return summary
+}
+
+// GetStorageGroupUpdatePlan returns the steps updating a storage group from the state to the plan, in the order they are applied.
+// The storage group is renamed first so that every later step uses the new name, the SRP is set before the service level
+// and the service level before the workload, the volumes are changed last.
+// Attributes which are unknown in the plan are left as they are.
+func GetStorageGroupUpdatePlan(ctx context.Context, plan models.StorageGroupResourceModel, state models.StorageGroupResourceModel) ([]StorageGroupUpdateStep, error) {
+	var steps []StorageGroupUpdateStep
+
+	// Rename
+	if !plan.StorageGroupID.IsUnknown() && plan.StorageGroupID.ValueString() != state.StorageGroupID.ValueString() {
+		steps = append(steps, StorageGroupUpdateStep{
+			Name: "name",
+			Action: &powermax.EditStorageGroupActionParam{
+				RenameStorageGroupParam: &powermax.RenameStorageGroupParam{
+					NewStorageGroupName: plan.StorageGroupID.ValueString(),
+				},
+			},
+			Record: func(state *models.StorageGroupResourceModel) {
+				state.StorageGroupID = plan.StorageGroupID
+				state.ID = plan.StorageGroupID
+			},
+		})
+	}
+
+	// Edit Srp
+	if !plan.Srp.IsUnknown() && plan.Srp.ValueString() != state.Srp.ValueString() {
+		steps = append(steps, StorageGroupUpdateStep{
+			Name: "srp_id",
+			Action: &powermax.EditStorageGroupActionParam{
+				EditStorageGroupSRPParam: &powermax.EditStorageGroupSRPParam{
+					SrpId: plan.Srp.ValueString(),
+				},
+			},
+			Record: func(state *models.StorageGroupResourceModel) {
+				state.Srp = plan.Srp
+			},
+		})
+	}
+
+	// Edit Slo
+	if !plan.Slo.IsUnknown() && plan.Slo.ValueString() != state.Slo.ValueString() {
+		steps = append(steps, StorageGroupUpdateStep{
+			Name: "slo",
+			Action: &powermax.EditStorageGroupActionParam{
+				EditStorageGroupSLOParam: &powermax.EditStorageGroupSLOParam{
+					SloId: plan.Slo.ValueString(),
+				},
+			},
+			Record: func(state *models.StorageGroupResourceModel) {
+				state.Slo = plan.Slo
+			},
+		})
+	}
+
+	// Edit Workload
+	if !plan.Workload.IsUnknown() && plan.Workload.ValueString() != state.Workload.ValueString() {
+		steps = append(steps, StorageGroupUpdateStep{
+			Name: "workload",
+			Action: &powermax.EditStorageGroupActionParam{
+				EditStorageGroupWorkloadParam: &powermax.EditStorageGroupWorkloadParam{
+					WorkloadSelection: plan.Workload.ValueString(),
+				},
+			},
+			Record: func(state *models.StorageGroupResourceModel) {
+				state.Workload = plan.Workload
+			},
+		})
+	}
+
+	// Edit Compression
+	if !plan.Compression.IsUnknown() && !plan.Compression.IsNull() && plan.Compression.ValueBool() != state.Compression.ValueBool() {
+		compression := plan.Compression.ValueBool()
+		steps = append(steps, StorageGroupUpdateStep{
+			Name: "compression",
+			Action: &powermax.EditStorageGroupActionParam{
+				EditCompressionParam: &powermax.EditCompressionParam{
+					Compression: &compression,
+				},
+			},
+			Record: func(state *models.StorageGroupResourceModel) {
+				state.Compression = plan.Compression
+			},
+		})
+	}
+
+	// SetHostIOLimit, a removed host_io_limit removes the limits from the storage group
+	if !plan.HostIOLimit.IsUnknown() && !plan.HostIOLimit.Equal(state.HostIOLimit) {
+		steps = append(steps, StorageGroupUpdateStep{
+			Name: "host_io_limit",
+			Action: &powermax.EditStorageGroupActionParam{
+				SetHostIOLimitsParam: ConstructHostIOLimitParam(ConstructHostIOLimit(plan)),
+			},
+			Record: func(state *models.StorageGroupResourceModel) {
+				state.HostIOLimit = plan.HostIOLimit
+			},
+		})
+	}
+
+	// Edit Tags
+	if !plan.Tags.IsUnknown() && !plan.Tags.Equal(state.Tags) {
+		var planTags []string
+		var stateTags []string
+		diags := plan.Tags.ElementsAs(ctx, &planTags, false)
+		diags.Append(state.Tags.ElementsAs(ctx, &stateTags, false)...)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to parse tags")
+		}
+		steps = append(steps, StorageGroupUpdateStep{
+			Name: "tags",
+			Apply: func(ctx context.Context, client *client.Client, sgID string) error {
+				return UpdateStorageGroupTags(ctx, *client, sgID, planTags, stateTags)
+			},
+			Record: func(state *models.StorageGroupResourceModel) {
+				state.Tags = plan.Tags
+			},
+		})
+	}
+
+	// Update Volume
+	if !plan.VolumeIDs.IsUnknown() && !plan.VolumeIDs.IsNull() && !plan.VolumeIDs.Equal(state.VolumeIDs) {
+		steps = append(steps, StorageGroupUpdateStep{
+			Name: "volume_ids",
+			Apply: func(ctx context.Context, client *client.Client, sgID string) error {
+				current := state
+				return AddRemoveVolume(ctx, &plan, &current, client, sgID)
+			},
+			Record: func(state *models.StorageGroupResourceModel) {
+				state.VolumeIDs = plan.VolumeIDs
+			},
+		})
+	}
+
+	return steps, nil
+}
+
+// ApplyStorageGroupUpdatePlan applies the steps in order and records each applied step into the state.
+// It stops at the first failed step, the later steps can depend on it.
+func ApplyStorageGroupUpdatePlan(ctx context.Context, client *client.Client, steps []StorageGroupUpdateStep, state *models.StorageGroupResourceModel) StorageGroupUpdateResult {
+	result := StorageGroupUpdateResult{}
+	for i, step := range steps {
+		// a rename is recorded into the state, the later steps use the new name
+		sgID := state.StorageGroupID.ValueString()
+		var err error
+		if step.Action != nil {
+			_, _, err = client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgID).EditStorageGroupParam(powermax.EditStorageGroupParam{
+				EditStorageGroupActionParam: *step.Action,
+			}).Execute()
+		} else {
+			err = step.Apply(ctx, client, sgID)
+		}
+		if err != nil {
+			result.Failed = step.Name
+			result.Err = fmt.Errorf("%s", strings.TrimSpace(GetErrorString(err, "")))
+			for _, skipped := range steps[i+1:] {
+				result.Skipped = append(result.Skipped, skipped.Name)
+			}
+			return result
+		}
+		step.Record(state)
+		result.Applied = append(result.Applied, step.Name)
+	}
+	return result
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func storageGroupUpdateTestModel(name string, tags []string) models.StorageGroupResourceModel {
+	model := models.StorageGroupResourceModel{
+		StorageGroupID: types.StringValue(name),
+		Srp:            types.StringValue("SRP_1"),
+		Slo:            types.StringValue("Gold"),
+		Workload:       types.StringValue("None"),
+		Compression:    types.BoolValue(true),
+		HostIOLimit:    types.ObjectNull(HostIOLimitType),
+		VolumeIDs:      types.ListValueMust(types.StringType, []attr.Value{}),
+	}
+	model.Tags, _ = types.SetValueFrom(context.Background(), types.StringType, tags)
+	return model
+}
+
+func storageGroupUpdateStepNames(steps []StorageGroupUpdateStep) []string {
+	names := []string{}
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+	return names
+}
+
+func TestGetStorageGroupUpdatePlanOrder(t *testing.T) {
+	ctx := context.Background()
+	state := storageGroupUpdateTestModel("sg", []string{"a"})
+	plan := storageGroupUpdateTestModel("sg_renamed", []string{"b"})
+	plan.Srp = types.StringValue("SRP_2")
+	plan.Slo = types.StringValue("Silver")
+	plan.Workload = types.StringValue("OLTP")
+	plan.Compression = types.BoolValue(false)
+	plan.HostIOLimit = types.ObjectValueMust(HostIOLimitType, map[string]attr.Value{
+		"host_io_limit_io_sec": types.StringValue("1000"),
+		"host_io_limit_mb_sec": types.StringValue("NOLIMIT"),
+		"dynamic_distribution": types.StringValue("Never"),
+	})
+	plan.VolumeIDs = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("0001A")})
+
+	steps, err := GetStorageGroupUpdatePlan(ctx, plan, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"name", "srp_id", "slo", "workload", "compression", "host_io_limit", "tags", "volume_ids"}
+	if names := storageGroupUpdateStepNames(steps); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected steps %v, got %v", expected, names)
+	}
+	if steps[0].Action.RenameStorageGroupParam.NewStorageGroupName != "sg_renamed" {
+		t.Errorf("expected rename to sg_renamed, got %s", steps[0].Action.RenameStorageGroupParam.NewStorageGroupName)
+	}
+}
+
+func TestGetStorageGroupUpdatePlanNoChange(t *testing.T) {
+	ctx := context.Background()
+	state := storageGroupUpdateTestModel("sg", []string{"a"})
+	plan := storageGroupUpdateTestModel("sg", []string{"a"})
+	// unknown attributes are computed, they are left as they are
+	plan.Workload = types.StringUnknown()
+	plan.Compression = types.BoolUnknown()
+	plan.VolumeIDs = types.ListUnknown(types.StringType)
+
+	steps, err := GetStorageGroupUpdatePlan(ctx, plan, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 0 {
+		t.Fatalf("expected no steps, got %v", storageGroupUpdateStepNames(steps))
+	}
+}
+
+func TestGetStorageGroupUpdatePlanRemoveHostIOLimit(t *testing.T) {
+	ctx := context.Background()
+	state := storageGroupUpdateTestModel("sg", nil)
+	state.HostIOLimit = types.ObjectValueMust(HostIOLimitType, map[string]attr.Value{
+		"host_io_limit_io_sec": types.StringValue("1000"),
+		"host_io_limit_mb_sec": types.StringValue("100"),
+		"dynamic_distribution": types.StringValue("Always"),
+	})
+	plan := storageGroupUpdateTestModel("sg", nil)
+
+	steps, err := GetStorageGroupUpdatePlan(ctx, plan, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := storageGroupUpdateStepNames(steps); !reflect.DeepEqual(names, []string{"host_io_limit"}) {
+		t.Fatalf("expected only the host_io_limit step, got %v", names)
+	}
+	param := steps[0].Action.SetHostIOLimitsParam
+	if *param.HostIoLimitIoSec != HostIOLimitNoLimit || *param.HostIoLimitMbSec != HostIOLimitNoLimit {
+		t.Errorf("expected the limits to be removed, got %s and %s", *param.HostIoLimitIoSec, *param.HostIoLimitMbSec)
+	}
+}
+
+func TestApplyStorageGroupUpdatePlanPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	state := storageGroupUpdateTestModel("sg", nil)
+	var calledWith []string
+	step := func(name string, err error, record func(state *models.StorageGroupResourceModel)) StorageGroupUpdateStep {
+		return StorageGroupUpdateStep{
+			Name: name,
+			Apply: func(ctx context.Context, client *client.Client, sgID string) error {
+				calledWith = append(calledWith, sgID)
+				return err
+			},
+			Record: record,
+		}
+	}
+	steps := []StorageGroupUpdateStep{
+		step("name", nil, func(state *models.StorageGroupResourceModel) {
+			state.StorageGroupID = types.StringValue("sg_renamed")
+		}),
+		step("slo", fmt.Errorf("slo error"), func(state *models.StorageGroupResourceModel) {
+			state.Slo = types.StringValue("Silver")
+		}),
+		step("workload", nil, func(state *models.StorageGroupResourceModel) {
+			state.Workload = types.StringValue("OLTP")
+		}),
+	}
+
+	result := ApplyStorageGroupUpdatePlan(ctx, nil, steps, &state)
+	if !reflect.DeepEqual(result.Applied, []string{"name"}) || result.Failed != "slo" || !reflect.DeepEqual(result.Skipped, []string{"workload"}) {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	// the steps after the rename use the new name
+	if !reflect.DeepEqual(calledWith, []string{"sg", "sg_renamed"}) {
+		t.Errorf("unexpected storage group names %v", calledWith)
+	}
+	if state.StorageGroupID.ValueString() != "sg_renamed" || state.Slo.ValueString() != "Gold" || state.Workload.ValueString() != "None" {
+		t.Errorf("only the applied steps should be recorded, got %s, %s, %s", state.StorageGroupID, state.Slo, state.Workload)
+	}
+	expected := "Failed to update slo: slo error. Applied: name. Not applied: workload."
+	if result.Summary() != expected {
+		t.Errorf("expected summary %q, got %q", expected, result.Summary())
+	}
+}
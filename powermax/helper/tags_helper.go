@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ListTags lists the names of the tags on the arrays.
+func ListTags(ctx context.Context, client client.Client) (*pmax.TagListResult, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.ListTags(ctx).Execute()
+}
+
+// GetTag get the objects which carry a tag.
+func GetTag(ctx context.Context, client client.Client, tagName string) (*pmax.TagResult, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.GetTag(ctx, tagName).Execute()
+}
+
+// UpdateTagDetailState updates the data source state of a tag.
+func UpdateTagDetailState(detail *models.TagDetailModel, tagName string, tag *pmax.TagResult) {
+	detail.Name = types.StringValue(tagName)
+	detail.ArrayIDs = []types.String{}
+	for _, arrayID := range tag.ArrayIds {
+		detail.ArrayIDs = append(detail.ArrayIDs, types.StringValue(arrayID))
+	}
+	detail.StorageGroups = []models.TaggedStorageGroupModel{}
+	if tag.StorageGroupInfos != nil {
+		for _, sg := range tag.StorageGroupInfos.StorageGroupInfo {
+			detail.StorageGroups = append(detail.StorageGroups, models.TaggedStorageGroupModel{
+				StorageGroupName: types.StringValue(sg.StorageGroupId),
+				ArrayID:          types.StringValue(sg.ArrayId),
+			})
+		}
+	}
+}
+
+// GetTaggedStorageGroupNames returns the names of the storage groups of the array carrying any of the tags.
+// Each tag is looked up by its exact name and the results are merged, the storage group list filter
+// gives no guarantee on repeated tags and treats tags starting with <like> as patterns.
+func GetTaggedStorageGroupNames(ctx context.Context, client client.Client, tags []string) ([]string, error) {
+	var tagResults []*pmax.TagResult
+	for _, tagName := range tags {
+		tag, resp, err := GetTag(ctx, client, tagName)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// No object carries the tag
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tagResults = append(tagResults, tag)
+	}
+	return mergeTaggedStorageGroupNames(client.SymmetrixID, tagResults), nil
+}
+
+// mergeTaggedStorageGroupNames returns the sorted names of the storage groups of the array carrying any of the tags.
+func mergeTaggedStorageGroupNames(symmetrixID string, tags []*pmax.TagResult) []string {
+	found := make(map[string]bool)
+	names := []string{}
+	for _, tag := range tags {
+		if tag == nil || tag.StorageGroupInfos == nil {
+			continue
+		}
+		for _, sg := range tag.StorageGroupInfos.StorageGroupInfo {
+			if sg.ArrayId != symmetrixID || found[sg.StorageGroupId] {
+				continue
+			}
+			found[sg.StorageGroupId] = true
+			names = append(names, sg.StorageGroupId)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseTags splits the comma separated tags of a storage group.
+func ParseTags(tags string) []string {
+	tagNames := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tagNames = append(tagNames, tag)
+		}
+	}
+	return tagNames
+}
+
+// UpdateStorageGroupTags adds and removes tags of a storage group to match the planned tags.
+// Tags are removed first, the array limits the number of tags of a storage group.
+func UpdateStorageGroupTags(ctx context.Context, client client.Client, sgID string, planTags []string, stateTags []string) error {
+	toAdd, toRemove := GetStringSliceChanges(planTags, stateTags)
+	if len(toRemove) > 0 {
+		_, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgID).EditStorageGroupParam(pmax.EditStorageGroupParam{
+			EditStorageGroupActionParam: pmax.EditStorageGroupActionParam{
+				TagManagementParam: &pmax.TagManagementParam{
+					RemoveTagsParam: pmax.NewRemoveTagsParam(toRemove),
+				},
+			},
+		}).Execute()
+		if err != nil {
+			return err
+		}
+	}
+	if len(toAdd) > 0 {
+		_, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgID).EditStorageGroupParam(pmax.EditStorageGroupParam{
+			EditStorageGroupActionParam: pmax.EditStorageGroupActionParam{
+				TagManagementParam: &pmax.TagManagementParam{
+					AddTagsParam: pmax.NewAddTagsParam(toAdd),
+				},
+			},
+		}).Execute()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
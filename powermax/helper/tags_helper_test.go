@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	pmax "dell/powermax-go-client"
+	"reflect"
+	"testing"
+)
+
+func TestMergeTaggedStorageGroupNames(t *testing.T) {
+	tagResult := func(sgs ...pmax.SystemStorageGroupInfo) *pmax.TagResult {
+		return &pmax.TagResult{StorageGroupInfos: &pmax.StorageGroupInfoList{StorageGroupInfo: sgs}}
+	}
+	gold := tagResult(
+		pmax.SystemStorageGroupInfo{StorageGroupId: "sg_b", ArrayId: "000120001234"},
+		pmax.SystemStorageGroupInfo{StorageGroupId: "sg_a", ArrayId: "000120001234"},
+		pmax.SystemStorageGroupInfo{StorageGroupId: "sg_remote", ArrayId: "000120005678"},
+	)
+	silver := tagResult(
+		pmax.SystemStorageGroupInfo{StorageGroupId: "sg_a", ArrayId: "000120001234"},
+		pmax.SystemStorageGroupInfo{StorageGroupId: "sg_c", ArrayId: "000120001234"},
+	)
+	tests := []struct {
+		name     string
+		tags     []*pmax.TagResult
+		expected []string
+	}{
+		{"no tags", nil, []string{}},
+		{"single tag of the array", []*pmax.TagResult{gold}, []string{"sg_a", "sg_b"}},
+		{"any of the tags", []*pmax.TagResult{gold, silver}, []string{"sg_a", "sg_b", "sg_c"}},
+		{"tag without storage groups", []*pmax.TagResult{{ArrayIds: []string{"000120001234"}}, silver}, []string{"sg_a", "sg_c"}},
+	}
+	for _, test := range tests {
+		names := mergeTaggedStorageGroupNames("000120001234", test.tags)
+		if !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, names)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"net/http"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// VirtualWitnessActionEnable enables a virtual witness.
+	VirtualWitnessActionEnable = "Enable"
+	// VirtualWitnessActionDisable disables a virtual witness.
+	VirtualWitnessActionDisable = "Disable"
+)
+
+// CreateVirtualWitness adds a new virtual witness to the array.
+func CreateVirtualWitness(ctx context.Context, client client.Client, plan models.VirtualWitness) (*pmax.VirtualWitness, *http.Response, error) {
+	addParam := pmax.NewVirtualWitnessAdd(plan.Name.ValueString(), plan.Location.ValueString())
+	if !plan.Port.IsNull() && !plan.Port.IsUnknown() {
+		addParam.SetPortNumber(plan.Port.ValueInt64())
+	}
+	return client.PmaxOpenapiClient.ReplicationApi.VirtualWitnessAdd(ctx, client.SymmetrixID).VirtualWitnessAdd(*addParam).Execute()
+}
+
+// GetVirtualWitness gets the details of a virtual witness.
+func GetVirtualWitness(ctx context.Context, client client.Client, name string) (*pmax.VirtualWitness, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetSymmetrixVirtualWitnessDetails(ctx, client.SymmetrixID, name).Execute()
+}
+
+// GetVirtualWitnessList gets the names of all virtual witnesses on the array.
+func GetVirtualWitnessList(ctx context.Context, client client.Client) (*pmax.VirtualWitnessNamesList, *http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.GetSymmetrixVirtualWitnessList(ctx, client.SymmetrixID).Execute()
+}
+
+// ModifyVirtualWitness enables or disables a virtual witness.
+func ModifyVirtualWitness(ctx context.Context, client client.Client, name string, enabled bool) (*pmax.VirtualWitness, *http.Response, error) {
+	updateParam := pmax.NewVirtualWitnessUpdate(VirtualWitnessActionEnable)
+	if !enabled {
+		updateParam.SetAction(VirtualWitnessActionDisable)
+		updateParam.SetDisable(*pmax.NewVirtualWitnessDisableParam())
+	}
+	return client.PmaxOpenapiClient.ReplicationApi.VirtualWitnessUpdate(ctx, client.SymmetrixID, name).VirtualWitnessUpdate(*updateParam).Execute()
+}
+
+// DeleteVirtualWitness removes a virtual witness from the array.
+func DeleteVirtualWitness(ctx context.Context, client client.Client, name string) (*http.Response, error) {
+	return client.PmaxOpenapiClient.ReplicationApi.VirtualWitnessRemove(ctx, client.SymmetrixID, name).Execute()
+}
+
+// UpdateVirtualWitnessState updates the virtual witness state from the API response.
+func UpdateVirtualWitnessState(vwState *models.VirtualWitness, vwResponse *pmax.VirtualWitness) {
+	vwState.ID = types.StringValue(vwResponse.Name)
+	vwState.Name = types.StringValue(vwResponse.Name)
+	vwState.Location = types.StringValue(vwResponse.Location)
+	vwState.Port = types.Int64Value(vwResponse.Port)
+	vwState.Enabled = types.BoolValue(vwResponse.Enabled)
+	vwState.Alive = types.BoolValue(vwResponse.Alive)
+	vwState.InUse = types.BoolValue(vwResponse.InUse)
+	vwState.Duplicate = types.BoolValue(vwResponse.Duplicate)
+	vwState.LastHeartbeatTimestamp = types.Int64Value(vwResponse.LastHeartbeatTimestamp)
+}
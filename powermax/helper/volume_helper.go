@@ -26,6 +26,7 @@ import (
 	"reflect"
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/models"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -42,8 +43,41 @@ const (
 	CapacityUnitMb = "MB"
 	// CapacityUnitCyl represents the unit CYL for capacity.
 	CapacityUnitCyl = "CYL"
+	// EmulationFBA represents the FBA emulation of open systems volumes.
+	EmulationFBA = "FBA"
+	// EmulationCKD3390 represents the CKD-3390 emulation of mainframe volumes.
+	EmulationCKD3390 = "CKD-3390"
+	// EmulationCKD3380 represents the CKD-3380 emulation of mainframe volumes.
+	EmulationCKD3380 = "CKD-3380"
 )
 
+// volumeFreePollInterval is the time between two checks of the allocations of a volume being freed.
+const volumeFreePollInterval = 15 * time.Second
+
+// defaultVolumeFreeTimeout is used when waiting on volumes which were created without a free timeout.
+const defaultVolumeFreeTimeout = 30 * time.Minute
+
+// IsCKDEmulation returns true for the emulations of mainframe volumes.
+func IsCKDEmulation(emulation string) bool {
+	return emulation == EmulationCKD3390 || emulation == EmulationCKD3380
+}
+
+// ValidateVolumeEmulation checks that CKD volumes are sized in cylinders, an empty emulation stands for FBA.
+func ValidateVolumeEmulation(emulation, capUnit string) error {
+	if IsCKDEmulation(emulation) && capUnit != CapacityUnitCyl {
+		return fmt.Errorf("invalid config, volumes with emulation %s must be sized with cap_unit 'CYL'", emulation)
+	}
+	return nil
+}
+
+// GetVolumeEmulation returns the planned emulation of a volume, FBA when not set.
+func GetVolumeEmulation(emulation types.String) string {
+	if emulation.IsNull() || emulation.IsUnknown() || emulation.ValueString() == "" {
+		return EmulationFBA
+	}
+	return emulation.ValueString()
+}
+
 // UpdateVolResourceState updates resource state given vol response from array.
 func UpdateVolResourceState(ctx context.Context, volState *models.VolumeResource, volResponse *powermax.Volume, volPlan *models.VolumeResource) error {
 	// Manually copy
@@ -77,6 +111,269 @@ func UpdateVolResourceState(ctx context.Context, volState *models.VolumeResource
 	volState.SymmetrixPortKey, _ = GetSymmetrixPortKeyObjects(volResponse)
 	volState.StorageGroups, _ = GetStorageGroupObjects(volResponse)
 	volState.RDFGroupIDList, _ = GetRfdGroupIdsObjects(volResponse)
+	if volPlan != nil {
+		volState.AdditionalStorageGroupNames = volPlan.AdditionalStorageGroupNames
+		volState.ForceMove = volPlan.ForceMove
+		volState.FreeBeforeDelete = volPlan.FreeBeforeDelete
+		volState.FreeTimeoutMinutes = volPlan.FreeTimeoutMinutes
+	}
+	if volState.ForceMove.IsNull() || volState.ForceMove.IsUnknown() {
+		volState.ForceMove = types.BoolValue(false)
+	}
+	if volState.FreeBeforeDelete.IsNull() || volState.FreeBeforeDelete.IsUnknown() {
+		volState.FreeBeforeDelete = types.BoolValue(false)
+	}
+	if volState.FreeTimeoutMinutes.IsNull() || volState.FreeTimeoutMinutes.IsUnknown() {
+		volState.FreeTimeoutMinutes = types.Int64Value(int64(defaultVolumeFreeTimeout / time.Minute))
+	}
+	// The remote storage groups are only used on create and are not returned by the array
+	if volPlan != nil {
+		volState.RemoteStorageGroups = volPlan.RemoteStorageGroups
+	}
+	if volState.RemoteStorageGroups.IsNull() || volState.RemoteStorageGroups.IsUnknown() {
+		volState.RemoteStorageGroups = types.ListNull(types.ObjectType{AttrTypes: VolumeRemoteStorageGroupsType})
+	}
+	// Only keep the additional storage groups the volume is still a member of, so that a removal outside of Terraform is planned again
+	if !volState.AdditionalStorageGroupNames.IsNull() && !volState.AdditionalStorageGroupNames.IsUnknown() {
+		var additionalSgNames []string
+		diags := volState.AdditionalStorageGroupNames.ElementsAs(ctx, &additionalSgNames, false)
+		if diags.HasError() {
+			return fmt.Errorf("could not read the additional storage groups of volume %s", volResponse.VolumeId)
+		}
+		sgNames := GetVolumeSgNames(volResponse)
+		memberSgNames := make([]string, 0)
+		for _, sgName := range additionalSgNames {
+			if StringInSlice(sgName, sgNames) {
+				memberSgNames = append(memberSgNames, sgName)
+			}
+		}
+		volState.AdditionalStorageGroupNames, _ = types.SetValueFrom(ctx, types.StringType, memberSgNames)
+	}
+	return nil
+}
+
+// VolumeRemoteStorageGroupsType is the object type of the storage groups of an SRDF remote array.
+var VolumeRemoteStorageGroupsType = map[string]attr.Type{
+	"symmetrix_id":        types.StringType,
+	"storage_group_names": types.ListType{ElemType: types.StringType},
+}
+
+// GetVolumeRemoteStorageGroups returns the planned storage groups of the SRDF remote arrays, nil when there are none.
+func GetVolumeRemoteStorageGroups(ctx context.Context, plan models.VolumeResource) ([]models.VolumeRemoteStorageGroups, error) {
+	if plan.RemoteStorageGroups.IsNull() || plan.RemoteStorageGroups.IsUnknown() {
+		return nil, nil
+	}
+	var remotes []models.VolumeRemoteStorageGroups
+	if diags := plan.RemoteStorageGroups.ElementsAs(ctx, &remotes, false); diags.HasError() {
+		return nil, fmt.Errorf("could not read remote_storage_groups")
+	}
+	return remotes, nil
+}
+
+// NewRemoteSymmSGInfoParam builds the storage groups of the SRDF remote arrays the paired volumes are created in,
+// nil when there are none. The API takes at most two remote arrays.
+func NewRemoteSymmSGInfoParam(ctx context.Context, plan models.VolumeResource) (*powermax.RemoteSymmSGInfoParam, error) {
+	remotes, err := GetVolumeRemoteStorageGroups(ctx, plan)
+	if err != nil || len(remotes) == 0 {
+		return nil, err
+	}
+	if len(remotes) > 2 {
+		return nil, fmt.Errorf("at most two remote arrays are supported, got %d", len(remotes))
+	}
+	remoteParam := powermax.NewRemoteSymmSGInfoParam()
+	for i, remote := range remotes {
+		var sgNames []string
+		if diags := remote.StorageGroupNames.ElementsAs(ctx, &sgNames, false); diags.HasError() {
+			return nil, fmt.Errorf("could not read the storage groups of remote array %s", remote.SymmetrixID.ValueString())
+		}
+		if i == 0 {
+			remoteParam.SetRemoteSymmetrix1Id(remote.SymmetrixID.ValueString())
+			remoteParam.SetRemoteSymmetrix1Sgs(sgNames)
+		} else {
+			remoteParam.SetRemoteSymmetrix2Id(remote.SymmetrixID.ValueString())
+			remoteParam.SetRemoteSymmetrix2Sgs(sgNames)
+		}
+	}
+	return remoteParam, nil
+}
+
+// GetVolumeSgNames returns the names of the storage groups the volume is a member of.
+func GetVolumeSgNames(volResponse *powermax.Volume) []string {
+	sgNames := make([]string, 0)
+	for _, sg := range volResponse.StorageGroups {
+		sgNames = append(sgNames, sg.GetStorageGroupName())
+	}
+	return sgNames
+}
+
+// ValidateVolumeCapabilities checks the planned emulation of a new volume against the array and its storage group.
+// The emulation must match the volumes already in the storage group, and CKD volumes need FICON splits on the array.
+// The remote storage groups must be on distinct arrays other than the local one.
+func ValidateVolumeCapabilities(ctx context.Context, client client.Client, plan models.VolumeResource) error {
+	emulation := GetVolumeEmulation(plan.Emulation)
+	sg, _, err := GetStorageGroup(ctx, client, plan.StorageGroupName.ValueString())
+	if err != nil {
+		return err
+	}
+	if sgEmulation := sg.GetDeviceEmulation(); sgEmulation != "" && sgEmulation != emulation {
+		return fmt.Errorf("storage group %s contains %s volumes, a %s volume can not be added to it", plan.StorageGroupName.ValueString(), sgEmulation, emulation)
+	}
+	remotes, err := GetVolumeRemoteStorageGroups(ctx, plan)
+	if err != nil {
+		return err
+	}
+	remoteIDs := make([]string, 0, len(remotes))
+	for _, remote := range remotes {
+		remoteID := remote.SymmetrixID.ValueString()
+		if remoteID == client.SymmetrixID {
+			return fmt.Errorf("remote array %s is the local array, the remote storage groups must be on the SRDF remote arrays", remoteID)
+		}
+		if StringInSlice(remoteID, remoteIDs) {
+			return fmt.Errorf("remote array %s is listed more than once in remote_storage_groups", remoteID)
+		}
+		remoteIDs = append(remoteIDs, remoteID)
+	}
+	if !IsCKDEmulation(emulation) {
+		return nil
+	}
+	splits, _, err := ListSplits(ctx, client)
+	if err != nil {
+		return err
+	}
+	if len(splits.GetSplitId()) == 0 {
+		return fmt.Errorf("array %s has no FICON splits, %s volumes are not supported", client.SymmetrixID, emulation)
+	}
+	return nil
+}
+
+// ValidateVolumeStorageGroups checks that the storage group of the volume is not listed in its additional storage groups.
+func ValidateVolumeStorageGroups(ctx context.Context, plan models.VolumeResource) error {
+	if plan.AdditionalStorageGroupNames.IsNull() || plan.AdditionalStorageGroupNames.IsUnknown() {
+		return nil
+	}
+	var additionalSgNames []string
+	diags := plan.AdditionalStorageGroupNames.ElementsAs(ctx, &additionalSgNames, false)
+	if diags.HasError() {
+		return fmt.Errorf("could not read additional_sg_names")
+	}
+	if StringInSlice(plan.StorageGroupName.ValueString(), additionalSgNames) {
+		return fmt.Errorf("storage group %s is both sg_name and in additional_sg_names", plan.StorageGroupName.ValueString())
+	}
+	return nil
+}
+
+// FreeVolume removes all the allocations of a volume, the volume must not be in any storage group.
+func FreeVolume(ctx context.Context, client client.Client, volumeID string) error {
+	modifyParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyVolume(ctx, client.SymmetrixID, volumeID)
+	modifyParam = modifyParam.EditVolumeParam(powermax.EditVolumeParam{
+		EditVolumeActionParam: &powermax.EditVolumeActionParam{
+			FreeVolumeParam: &powermax.FreeVolumeParam{
+				FreeVolume: true,
+			},
+		},
+	})
+	_, _, err := modifyParam.Execute()
+	return err
+}
+
+// WaitForVolumeFree polls the volume until none of its capacity is allocated, or until the timeout expires.
+func WaitForVolumeFree(ctx context.Context, client client.Client, volumeID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		vol, _, err := GetVolume(ctx, client, volumeID)
+		if err != nil {
+			return err
+		}
+		allocatedPercent := vol.GetAllocatedPercent()
+		tflog.Info(ctx, "waiting for volume allocations to be freed", map[string]interface{}{
+			"volumeID":         volumeID,
+			"allocatedPercent": allocatedPercent,
+		})
+		if allocatedPercent == 0 {
+			return nil
+		}
+		if time.Now().Add(volumeFreePollInterval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for the allocations of volume %s to be freed, %d%% still allocated", timeout, volumeID, allocatedPercent)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(volumeFreePollInterval):
+		}
+	}
+}
+
+// MoveVolumeToStorageGroup moves a volume from a storage group to another one, force is required when the storage groups are masked.
+func MoveVolumeToStorageGroup(ctx context.Context, client client.Client, volumeID, sourceSgName, targetSgName string, force bool) error {
+	moveParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sourceSgName)
+	moveParam = moveParam.EditStorageGroupParam(
+		powermax.EditStorageGroupParam{
+			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+				MoveVolumeToStorageGroupParam: &powermax.MoveVolumeToStorageGroupParam{
+					VolumeId:       []string{volumeID},
+					StorageGroupId: targetSgName,
+					Force:          &force,
+				},
+			},
+		},
+	)
+	_, _, err := moveParam.Execute()
+	return err
+}
+
+// AddVolumeToStorageGroup adds an existing volume to a storage group, the volume stays in its other storage groups.
+func AddVolumeToStorageGroup(ctx context.Context, client client.Client, volumeID, sgName string) error {
+	addParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgName)
+	addParam = addParam.EditStorageGroupParam(
+		powermax.EditStorageGroupParam{
+			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+				ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+					AddSpecificVolumeParam: &powermax.AddSpecificVolumeParam{
+						VolumeId: []string{volumeID},
+					},
+				},
+			},
+		},
+	)
+	_, _, err := addParam.Execute()
+	return err
+}
+
+// RemoveVolumeFromStorageGroup removes a volume from a storage group without deleting it.
+func RemoveVolumeFromStorageGroup(ctx context.Context, client client.Client, volumeID, sgName string) error {
+	removeParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgName)
+	removeParam = removeParam.EditStorageGroupParam(
+		powermax.EditStorageGroupParam{
+			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+				RemoveVolumeParam: &powermax.RemoveVolumeParam{
+					VolumeId: []string{volumeID},
+				},
+			},
+		},
+	)
+	_, _, err := removeParam.Execute()
+	return err
+}
+
+// AddVolumeToAdditionalStorageGroups adds a volume to the planned additional storage groups it is not a member of yet.
+func AddVolumeToAdditionalStorageGroups(ctx context.Context, client client.Client, plan models.VolumeResource, volumeID string, sgNames []string) error {
+	if plan.AdditionalStorageGroupNames.IsNull() || plan.AdditionalStorageGroupNames.IsUnknown() {
+		return nil
+	}
+	var additionalSgNames []string
+	diags := plan.AdditionalStorageGroupNames.ElementsAs(ctx, &additionalSgNames, false)
+	if diags.HasError() {
+		return fmt.Errorf("could not read additional_sg_names")
+	}
+	for _, sgName := range additionalSgNames {
+		if StringInSlice(sgName, sgNames) {
+			continue
+		}
+		err := AddVolumeToStorageGroup(ctx, client, volumeID, sgName)
+		if err != nil {
+			return fmt.Errorf("could not add volume %s to storage group %s: %s", volumeID, sgName, GetErrorString(err, ""))
+		}
+	}
 	return nil
 }
 
@@ -205,6 +502,94 @@ func UpdateVol(ctx context.Context, client *client.Client, planVol, stateVol mod
 		}
 	}
 
+	sgUpdated, sgFailed, sgErrors := updateVolStorageGroups(ctx, *client, planVol, stateVol)
+	updatedParameters = append(updatedParameters, sgUpdated...)
+	updateFailedParameters = append(updateFailedParameters, sgFailed...)
+	errorMessages = append(errorMessages, sgErrors...)
+
+	return updatedParameters, updateFailedParameters, errorMessages
+}
+
+// updateVolStorageGroups moves the volume to the planned storage group and adds it to or removes it from its additional storage groups.
+func updateVolStorageGroups(ctx context.Context, client client.Client, planVol, stateVol models.VolumeResource) ([]string, []string, []string) {
+	var updatedParameters []string
+	var updateFailedParameters []string
+	var errorMessages []string
+
+	var planAdditional, stateAdditional []string
+	additionalChanged := !planVol.AdditionalStorageGroupNames.IsUnknown() && !planVol.AdditionalStorageGroupNames.Equal(stateVol.AdditionalStorageGroupNames)
+	if !planVol.AdditionalStorageGroupNames.IsNull() && !planVol.AdditionalStorageGroupNames.IsUnknown() {
+		planVol.AdditionalStorageGroupNames.ElementsAs(ctx, &planAdditional, false)
+	}
+	if !stateVol.AdditionalStorageGroupNames.IsNull() && !stateVol.AdditionalStorageGroupNames.IsUnknown() {
+		stateVol.AdditionalStorageGroupNames.ElementsAs(ctx, &stateAdditional, false)
+	}
+	planSgName := planVol.StorageGroupName.ValueString()
+	stateSgName := stateVol.StorageGroupName.ValueString()
+	if planSgName == stateSgName && !additionalChanged {
+		return updatedParameters, updateFailedParameters, errorMessages
+	}
+
+	volumeID := stateVol.ID.ValueString()
+	vol, _, err := GetVolume(ctx, client, volumeID)
+	if err != nil {
+		return updatedParameters, []string{"sg_name", "additional_sg_names"}, []string{fmt.Sprintf("Failed to read the storage groups of the volume: %s", GetErrorString(err, ""))}
+	}
+	sgNames := GetVolumeSgNames(vol)
+
+	if planSgName != stateSgName {
+		switch {
+		case StringInSlice(planSgName, sgNames):
+			// Already a member, for example an additional storage group becoming the storage group of the volume, only leave the former one
+			if stateSgName != "" && StringInSlice(stateSgName, sgNames) && !StringInSlice(stateSgName, planAdditional) {
+				err = RemoveVolumeFromStorageGroup(ctx, client, volumeID, stateSgName)
+			}
+		case stateSgName != "" && StringInSlice(stateSgName, sgNames) && !StringInSlice(stateSgName, planAdditional):
+			err = MoveVolumeToStorageGroup(ctx, client, volumeID, stateSgName, planSgName, planVol.ForceMove.ValueBool())
+		default:
+			// Imported volumes have no storage group in the state, the volume is added without leaving its storage groups
+			err = AddVolumeToStorageGroup(ctx, client, volumeID, planSgName)
+		}
+		if err != nil {
+			updateFailedParameters = append(updateFailedParameters, "sg_name")
+			errorMessages = append(errorMessages, fmt.Sprintf("Failed to move the volume to storage group %s: %s", planSgName, GetErrorString(err, "")))
+		} else {
+			updatedParameters = append(updatedParameters, "sg_name")
+			vol, _, err = GetVolume(ctx, client, volumeID)
+			if err == nil {
+				sgNames = GetVolumeSgNames(vol)
+			}
+		}
+	}
+
+	if additionalChanged {
+		toAdd, toRemove := GetStringSliceChanges(planAdditional, stateAdditional)
+		var failed []string
+		for _, sgName := range toAdd {
+			if StringInSlice(sgName, sgNames) {
+				continue
+			}
+			if err := AddVolumeToStorageGroup(ctx, client, volumeID, sgName); err != nil {
+				failed = append(failed, sgName)
+				errorMessages = append(errorMessages, fmt.Sprintf("Failed to add the volume to storage group %s: %s", sgName, GetErrorString(err, "")))
+			}
+		}
+		for _, sgName := range toRemove {
+			if sgName == planSgName || !StringInSlice(sgName, sgNames) {
+				continue
+			}
+			if err := RemoveVolumeFromStorageGroup(ctx, client, volumeID, sgName); err != nil {
+				failed = append(failed, sgName)
+				errorMessages = append(errorMessages, fmt.Sprintf("Failed to remove the volume from storage group %s: %s", sgName, GetErrorString(err, "")))
+			}
+		}
+		if len(failed) > 0 {
+			updateFailedParameters = append(updateFailedParameters, "additional_sg_names")
+		} else {
+			updatedParameters = append(updatedParameters, "additional_sg_names")
+		}
+	}
+
 	return updatedParameters, updateFailedParameters, errorMessages
 }
 
@@ -235,7 +620,11 @@ func CreateVolume(ctx context.Context, client client.Client, plan models.VolumeR
 	})
 	tflog.Info(ctx, fmt.Sprintf("Create Volume att Param: %v", volumeAttributes))
 	createNewVol := true
-	emulation := "FBA"
+	emulation := GetVolumeEmulation(plan.Emulation)
+	remoteParam, err := NewRemoteSymmSGInfoParam(ctx, plan)
+	if err != nil {
+		return nil, nil, err
+	}
 	tflog.Debug(ctx, "calling create volume in storage groups on pmax client", map[string]interface{}{
 		"symmetrixID":      client.SymmetrixID,
 		"storageGroupName": plan.StorageGroupName.ValueString(),
@@ -256,6 +645,7 @@ func CreateVolume(ctx context.Context, client client.Client, plan models.VolumeR
 							VolumeIdentifierChoice: "identifier_name",
 							IdentifierName:         plan.VolumeIdentifier.ValueStringPointer(),
 						},
+						RemoteSymmSGInfoParam: remoteParam,
 					},
 				},
 			},
@@ -305,6 +695,41 @@ func UpdateVolumeState(ctx context.Context, p *client.Client, params powermax.Ap
 	return response, nil
 }
 
+// UpdateTaggedVolumeState updates the state with the filtered volumes of the storage groups carrying any of the filter tags.
+func UpdateTaggedVolumeState(ctx context.Context, p *client.Client, params powermax.ApiListVolumesRequest, filter *models.VolumeDatasourceFilter) ([]models.VolumeDatasourceEntity, error) {
+	var tags []string
+	diags := filter.Tags.ElementsAs(ctx, &tags, false)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to parse tags from filter")
+	}
+	sgNames, err := GetTaggedStorageGroupNames(ctx, *p, tags)
+	if err != nil {
+		errStr := ""
+		message := GetErrorString(err, errStr)
+		return nil, fmt.Errorf(message)
+	}
+	response := []models.VolumeDatasourceEntity{}
+	found := make(map[string]bool)
+	for _, sgName := range sgNames {
+		// the storage group filter narrows the tagged storage groups down
+		if !filter.StorageGroupID.IsNull() && filter.StorageGroupID.ValueString() != sgName {
+			continue
+		}
+		volumes, err := UpdateVolumeState(ctx, p, params.StorageGroupId(sgName))
+		if err != nil {
+			return nil, err
+		}
+		// a volume can be in several tagged storage groups
+		for _, volume := range volumes {
+			if !found[volume.VolumeID.ValueString()] {
+				found[volume.VolumeID.ValueString()] = true
+				response = append(response, volume)
+			}
+		}
+	}
+	return response, nil
+}
+
 // GetVolumeFilterParam returns volume filter parameters.
 func GetVolumeFilterParam(ctx context.Context, p *client.Client, model models.VolumeDatasource) (powermax.ApiListVolumesRequest, error) {
 	filter := model.VolumeFilter
@@ -0,0 +1,463 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"dell/powermax-go-client"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// VolumeSetNamePatternRegex matches a volume set name pattern, which has exactly one integer verb such as %d or %03d.
+var VolumeSetNamePatternRegex = regexp.MustCompile(`^[^%]*%(0[1-9][0-9]*)?d[^%]*$`)
+
+// volumeSetVerbRegex matches the integer verb of a volume set name pattern.
+var volumeSetVerbRegex = regexp.MustCompile(`%(0[1-9][0-9]*)?d`)
+
+// VolumeSetMemberType is the object type of a volume of a volume set.
+var VolumeSetMemberType = types.ObjectType{AttrTypes: volumeSetMemberType}
+
+// volumeSetMemberType is the attribute types of a volume of a volume set.
+var volumeSetMemberType = map[string]attr.Type{
+	"index":             types.Int64Type,
+	"id":                types.StringType,
+	"volume_identifier": types.StringType,
+	"wwn":               types.StringType,
+	"cap_gb":            types.NumberType,
+}
+
+// VolumeSetName returns the name of the volume at the given index of a volume set.
+func VolumeSetName(pattern string, index int64) string {
+	return fmt.Sprintf(pattern, index)
+}
+
+// VolumeSetNameIndex returns the index of a volume named after the pattern of a volume set, false if the name does not match the pattern.
+func VolumeSetNameIndex(pattern, name string) (int64, bool) {
+	loc := volumeSetVerbRegex.FindStringIndex(pattern)
+	if loc == nil {
+		return 0, false
+	}
+	nameRegex := regexp.MustCompile("^" + regexp.QuoteMeta(pattern[:loc[0]]) + `([0-9]+)` + regexp.QuoteMeta(pattern[loc[1]:]) + "$")
+	match := nameRegex.FindStringSubmatch(name)
+	if match == nil {
+		return 0, false
+	}
+	index, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil || VolumeSetName(pattern, index) != name {
+		return 0, false
+	}
+	return index, true
+}
+
+// VolumeSetAppendNumberPrefix returns the prefix of a pattern which ends with a plain %d verb, such as ora_data_%d.
+// The array can name the volumes of such a pattern itself when they are created, by appending their index to the prefix.
+func VolumeSetAppendNumberPrefix(pattern string) (string, bool) {
+	if !strings.HasSuffix(pattern, "%d") || strings.Count(pattern, "%") != 1 {
+		return "", false
+	}
+	return strings.TrimSuffix(pattern, "%d"), true
+}
+
+// isContiguous returns true when the sorted indexes follow each other without gaps.
+func isContiguous(indexes []int64) bool {
+	for i := 1; i < len(indexes); i++ {
+		if indexes[i] != indexes[i-1]+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// GetVolumeSetMembersToRename returns the volumes of a volume set whose name does not follow the pattern,
+// such as volumes which kept their temporary identifier because their rename failed.
+func GetVolumeSetMembersToRename(pattern string, members []models.VolumeSetMember) []models.VolumeSetMember {
+	var toRename []models.VolumeSetMember
+	for _, member := range members {
+		if member.VolumeIdentifier.ValueString() != VolumeSetName(pattern, member.Index.ValueInt64()) {
+			toRename = append(toRename, member)
+		}
+	}
+	return toRename
+}
+
+// VolumeSetID returns the ID of a volume set, in the format 'sg_name.name_pattern'.
+func VolumeSetID(sgName, pattern string) string {
+	return sgName + "." + pattern
+}
+
+// ParseVolumeSetID returns the storage group name and the name pattern of a volume set ID.
+func ParseVolumeSetID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || !VolumeSetNamePatternRegex.MatchString(parts[1]) {
+		return "", "", fmt.Errorf("invalid volume set ID %s, the import ID must be in the format 'sg_name.name_pattern', for example 'ora_sg.ora_data_%%03d'", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// GetVolumeSetIndexChanges returns the indexes of the volumes to create and the volumes to delete
+// so that the set holds the volumes from startIndex to startIndex+count-1.
+func GetVolumeSetIndexChanges(members []models.VolumeSetMember, startIndex, count int64) ([]int64, []models.VolumeSetMember) {
+	existing := make(map[int64]bool)
+	var toDelete []models.VolumeSetMember
+	for _, member := range members {
+		index := member.Index.ValueInt64()
+		if index < startIndex || index >= startIndex+count || existing[index] {
+			toDelete = append(toDelete, member)
+			continue
+		}
+		existing[index] = true
+	}
+	var toCreate []int64
+	for index := startIndex; index < startIndex+count; index++ {
+		if !existing[index] {
+			toCreate = append(toCreate, index)
+		}
+	}
+	return toCreate, toDelete
+}
+
+// GetVolumeSetMembers returns the volumes of a volume set held in the state.
+func GetVolumeSetMembers(ctx context.Context, volumes types.List) ([]models.VolumeSetMember, diag.Diagnostics) {
+	members := make([]models.VolumeSetMember, 0)
+	if volumes.IsNull() || volumes.IsUnknown() {
+		return members, nil
+	}
+	diags := volumes.ElementsAs(ctx, &members, false)
+	return members, diags
+}
+
+// CreateVolumeSetVolumes creates the volumes of the given indexes in a single request and returns their IDs in creation order.
+// When the pattern ends with a plain %d verb and the indexes follow each other, the array names the volumes itself and true is returned.
+// Otherwise the volumes are created with a temporary identifier, so that they can be told apart from the other volumes of the storage group, and have to be renamed.
+func CreateVolumeSetVolumes(ctx context.Context, client client.Client, plan models.VolumeSetResourceModel, indexes []int64) ([]string, bool, error) {
+	num := int64(len(indexes))
+	identifier := &powermax.VolumeIdentifier{
+		VolumeIdentifierChoice: "identifier_name",
+	}
+	listIdentifier := "tf_vs_" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	identifier.IdentifierName = &listIdentifier
+	var existingIDs []string
+	prefix, named := VolumeSetAppendNumberPrefix(plan.NamePattern.ValueString())
+	named = named && prefix != "" && isContiguous(indexes)
+	if named {
+		// The volumes are listed by their prefix, which the volumes already in the set share
+		appendNumber := strconv.FormatInt(indexes[0], 10)
+		listIdentifier = prefix
+		identifier.VolumeIdentifierChoice = "identifier_name_plus_append_number"
+		identifier.IdentifierName = &prefix
+		identifier.AppendNumber = &appendNumber
+		var err error
+		existingIDs, err = listVolumeSetVolumeIDs(ctx, client, plan.StorageGroupName.ValueString(), listIdentifier)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	createNewVol := true
+	emulation := GetVolumeEmulation(plan.Emulation)
+	volumeAttributes := []powermax.VolumeAttribute{
+		{
+			CapacityUnit:     plan.CapUnit.ValueString(),
+			NumOfVols:        &num,
+			VolumeSize:       plan.Size.ValueBigFloat().String(),
+			VolumeIdentifier: identifier,
+		},
+	}
+	tflog.Debug(ctx, "calling create volumes in storage group on pmax client", map[string]interface{}{
+		"symmetrixID":      client.SymmetrixID,
+		"storageGroupName": plan.StorageGroupName.ValueString(),
+		"volumeAttributes": volumeAttributes,
+	})
+	createParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, plan.StorageGroupName.ValueString())
+	createParam = createParam.EditStorageGroupParam(
+		powermax.EditStorageGroupParam{
+			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+				ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+					AddVolumeParam: &powermax.AddVolumeParam{
+						CreateNewVolumes: &createNewVol,
+						EnableMobilityId: plan.MobilityIDEnabled.ValueBoolPointer(),
+						VolumeAttributes: volumeAttributes,
+						Emulation:        &emulation,
+					},
+				},
+			},
+		},
+	)
+	_, _, err := createParam.Execute()
+	if err != nil {
+		return nil, false, err
+	}
+
+	listedIDs, err := listVolumeSetVolumeIDs(ctx, client, plan.StorageGroupName.ValueString(), listIdentifier)
+	if err != nil {
+		return nil, false, err
+	}
+	var volumeIDs []string
+	for _, volumeID := range listedIDs {
+		if !StringInSlice(volumeID, existingIDs) {
+			volumeIDs = append(volumeIDs, volumeID)
+		}
+	}
+	sort.Strings(volumeIDs)
+	if int64(len(volumeIDs)) != num {
+		return volumeIDs, named, fmt.Errorf("expected %d new volumes with identifier %s in storage group %s, found %d", num, listIdentifier, plan.StorageGroupName.ValueString(), len(volumeIDs))
+	}
+	return volumeIDs, named, nil
+}
+
+// listVolumeSetVolumeIDs lists the IDs of the volumes of a storage group whose identifier contains the given one.
+func listVolumeSetVolumeIDs(ctx context.Context, client client.Client, sgName, identifier string) ([]string, error) {
+	listParam := client.PmaxOpenapiClient.SLOProvisioningApi.ListVolumes(ctx, client.SymmetrixID)
+	listParam = listParam.StorageGroupId(sgName).VolumeIdentifier(identifier)
+	volumeList, _, err := listParam.Execute()
+	if err != nil {
+		return nil, err
+	}
+	var volumeIDs []string
+	for _, result := range volumeList.ResultList.GetResult() {
+		for _, volumeID := range result {
+			volumeIDs = append(volumeIDs, fmt.Sprint(volumeID))
+		}
+	}
+	return volumeIDs, nil
+}
+
+// RenameVolume sets the identifier of a volume.
+func RenameVolume(ctx context.Context, client client.Client, volumeID, name string) error {
+	modifyParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyVolume(ctx, client.SymmetrixID, volumeID)
+	modifyParam = modifyParam.EditVolumeParam(powermax.EditVolumeParam{
+		EditVolumeActionParam: &powermax.EditVolumeActionParam{
+			ModifyVolumeIdentifierParam: &powermax.ModifyVolumeIdentifierParam{
+				VolumeIdentifier: &powermax.VolumeIdentifier{
+					VolumeIdentifierChoice: "identifier_name",
+					IdentifierName:         &name,
+				},
+			},
+		},
+	})
+	_, _, err := modifyParam.Execute()
+	return err
+}
+
+// ExpandVolume expands a volume to the given size.
+func ExpandVolume(ctx context.Context, client client.Client, volumeID, size, capUnit string) error {
+	modifyParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyVolume(ctx, client.SymmetrixID, volumeID)
+	modifyParam = modifyParam.EditVolumeParam(powermax.EditVolumeParam{
+		EditVolumeActionParam: &powermax.EditVolumeActionParam{
+			ExpandVolumeParam: &powermax.ExpandVolumeParam{
+				VolumeAttribute: powermax.VolumeAttribute{
+					CapacityUnit: capUnit,
+					VolumeSize:   size,
+				},
+			},
+		},
+	})
+	_, _, err := modifyParam.Execute()
+	return err
+}
+
+// SetVolumeMobilityID enables or disables the mobility ID of a volume.
+func SetVolumeMobilityID(ctx context.Context, client client.Client, volumeID string, enable bool) error {
+	modifyParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyVolume(ctx, client.SymmetrixID, volumeID)
+	modifyParam = modifyParam.EditVolumeParam(powermax.EditVolumeParam{
+		EditVolumeActionParam: &powermax.EditVolumeActionParam{
+			EnableMobilityIdParam: &powermax.EnableMobilityIdParam{
+				EnableMobilityId: enable,
+			},
+		},
+	})
+	_, _, err := modifyParam.Execute()
+	return err
+}
+
+// DeleteVolumeSetVolumes removes the volumes from all their storage groups, with one request per storage group, and deletes them.
+// Volumes which no longer exist are skipped.
+func DeleteVolumeSetVolumes(ctx context.Context, client client.Client, volumeIDs []string) error {
+	sgVolumes := make(map[string][]string)
+	var sgNames, existingIDs []string
+	for _, volumeID := range volumeIDs {
+		vol, resp, err := GetVolume(ctx, client, volumeID)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		existingIDs = append(existingIDs, volumeID)
+		for _, sg := range vol.GetStorageGroups() {
+			sgName := sg.GetStorageGroupName()
+			if _, ok := sgVolumes[sgName]; !ok {
+				sgNames = append(sgNames, sgName)
+			}
+			sgVolumes[sgName] = append(sgVolumes[sgName], volumeID)
+		}
+	}
+	for _, sgName := range sgNames {
+		removeParam := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgName)
+		removeParam = removeParam.EditStorageGroupParam(
+			powermax.EditStorageGroupParam{
+				EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+					RemoveVolumeParam: &powermax.RemoveVolumeParam{
+						VolumeId: sgVolumes[sgName],
+					},
+				},
+			},
+		)
+		_, _, err := removeParam.Execute()
+		if err != nil {
+			return fmt.Errorf("could not remove volumes %s from storage group %s: %s", strings.Join(sgVolumes[sgName], ", "), sgName, GetErrorString(err, ""))
+		}
+	}
+	for _, volumeID := range existingIDs {
+		_, err := client.PmaxOpenapiClient.SLOProvisioningApi.DeleteVolume(ctx, client.SymmetrixID, volumeID).Execute()
+		if err != nil {
+			return fmt.Errorf("could not delete volume %s: %s", volumeID, GetErrorString(err, ""))
+		}
+	}
+	return nil
+}
+
+// getVolumeSize returns the size of a volume in the given capacity unit.
+func getVolumeSize(vol *powermax.Volume, capUnit string) float64 {
+	switch capUnit {
+	case CapacityUnitCyl:
+		return float64(vol.GetCapCyl())
+	case CapacityUnitTb:
+		return vol.GetCapGb() / 1024
+	case CapacityUnitMb:
+		// pmax returns 1 MB less than actual cap
+		return vol.GetCapMb() - 1.0
+	default:
+		return vol.GetCapGb()
+	}
+}
+
+// FindVolumeSetMembers returns the volumes of a storage group named after the pattern of a volume set, for import.
+func FindVolumeSetMembers(ctx context.Context, client client.Client, sgName, pattern string) ([]models.VolumeSetMember, error) {
+	listParam := client.PmaxOpenapiClient.SLOProvisioningApi.ListVolumes(ctx, client.SymmetrixID).StorageGroupId(sgName)
+	volumeList, _, err := listParam.Execute()
+	if err != nil {
+		return nil, err
+	}
+	members := make([]models.VolumeSetMember, 0)
+	for _, result := range volumeList.ResultList.GetResult() {
+		for _, volumeID := range result {
+			vol, _, err := GetVolume(ctx, client, fmt.Sprint(volumeID))
+			if err != nil {
+				return nil, err
+			}
+			if index, ok := VolumeSetNameIndex(pattern, vol.GetVolumeIdentifier()); ok {
+				members = append(members, models.VolumeSetMember{
+					Index: types.Int64Value(index),
+					ID:    types.StringValue(vol.VolumeId),
+				})
+			}
+		}
+	}
+	SortVolumeSetMembers(members)
+	return members, nil
+}
+
+// SortVolumeSetMembers sorts the volumes of a volume set by index.
+func SortVolumeSetMembers(members []models.VolumeSetMember) {
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Index.ValueInt64() < members[j].Index.ValueInt64()
+	})
+}
+
+// UpdateVolumeSetState reads the volumes of the set and updates the state, volumes which no longer exist are dropped.
+// The count reflects the volumes found and the size is the size of the smallest volume, so that drift is planned as a change.
+func UpdateVolumeSetState(ctx context.Context, client client.Client, state *models.VolumeSetResourceModel, members []models.VolumeSetMember) diag.Diagnostics {
+	var diags diag.Diagnostics
+	state.ID = types.StringValue(VolumeSetID(state.StorageGroupName.ValueString(), state.NamePattern.ValueString()))
+	var volumeObjects []attr.Value
+	var minSize *float64
+	var minIndex *int64
+	mobilityIDEnabled := state.MobilityIDEnabled.ValueBool()
+	for _, member := range members {
+		vol, resp, err := GetVolume(ctx, client, member.ID.ValueString())
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, "volume of volume set no longer exists", map[string]interface{}{"volumeID": member.ID.ValueString()})
+			continue
+		}
+		if err != nil {
+			diags.AddError("Error reading volume set", fmt.Sprintf("Could not read volume %s with error: %s", member.ID.ValueString(), GetErrorString(err, "")))
+			return diags
+		}
+		if state.CapUnit.IsNull() || state.CapUnit.IsUnknown() {
+			// On import, CKD volumes are sized in cylinders
+			state.CapUnit = types.StringValue(CapacityUnitGb)
+			if IsCKDEmulation(vol.GetEmulation()) {
+				state.CapUnit = types.StringValue(CapacityUnitCyl)
+			}
+		}
+		size := getVolumeSize(vol, state.CapUnit.ValueString())
+		if minSize == nil || size < *minSize {
+			minSize = &size
+		}
+		index := member.Index.ValueInt64()
+		if minIndex == nil || index < *minIndex {
+			minIndex = &index
+		}
+		mobilityIDEnabled = vol.GetMobilityIdEnabled()
+		if vol.GetEmulation() != "" {
+			state.Emulation = types.StringValue(vol.GetEmulation())
+		}
+		volumeObject, objDiags := types.ObjectValue(volumeSetMemberType, map[string]attr.Value{
+			"index":             types.Int64Value(index),
+			"id":                types.StringValue(vol.VolumeId),
+			"volume_identifier": types.StringValue(vol.GetVolumeIdentifier()),
+			"wwn":               types.StringValue(vol.GetWwn()),
+			"cap_gb":            types.NumberValue(big.NewFloat(vol.GetCapGb())),
+		})
+		diags.Append(objDiags...)
+		volumeObjects = append(volumeObjects, volumeObject)
+	}
+	if state.CapUnit.IsNull() || state.CapUnit.IsUnknown() {
+		state.CapUnit = types.StringValue(CapacityUnitGb)
+	}
+	volumes, listDiags := types.ListValue(VolumeSetMemberType, volumeObjects)
+	diags.Append(listDiags...)
+	state.Volumes = volumes
+	state.Count = types.Int64Value(int64(len(volumeObjects)))
+	// The start index is only derived from the volumes on import, a missing first volume is recreated rather than replacing the set
+	if state.StartIndex.IsNull() || state.StartIndex.IsUnknown() {
+		state.StartIndex = types.Int64Value(1)
+		if minIndex != nil {
+			state.StartIndex = types.Int64Value(*minIndex)
+		}
+	}
+	if minSize != nil {
+		state.Size = types.NumberValue(big.NewFloat(*minSize))
+	}
+	state.MobilityIDEnabled = types.BoolValue(mobilityIDEnabled)
+	return diags
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"terraform-provider-powermax/powermax/models"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVolumeSetAppendNumberPrefix(t *testing.T) {
+	tests := []struct {
+		pattern string
+		prefix  string
+		ok      bool
+	}{
+		{"ora_data_%d", "ora_data_", true},
+		{"ora_data_%03d", "", false},
+		{"ora_%d_data", "", false},
+		{"%d", "", true},
+	}
+	for _, test := range tests {
+		prefix, ok := VolumeSetAppendNumberPrefix(test.pattern)
+		if prefix != test.prefix || ok != test.ok {
+			t.Errorf("VolumeSetAppendNumberPrefix(%q) = %q, %t, expected %q, %t", test.pattern, prefix, ok, test.prefix, test.ok)
+		}
+	}
+}
+
+func TestIsContiguous(t *testing.T) {
+	if !isContiguous([]int64{4, 5, 6}) || !isContiguous([]int64{2}) {
+		t.Errorf("expected following indexes to be contiguous")
+	}
+	if isContiguous([]int64{1, 3}) {
+		t.Errorf("expected indexes with a gap not to be contiguous")
+	}
+}
+
+func TestGetVolumeSetMembersToRename(t *testing.T) {
+	members := []models.VolumeSetMember{
+		{Index: types.Int64Value(1), ID: types.StringValue("0012A"), VolumeIdentifier: types.StringValue("ora_data_001")},
+		{Index: types.Int64Value(2), ID: types.StringValue("0012B"), VolumeIdentifier: types.StringValue("tf_vs_abc123")},
+		{Index: types.Int64Value(3), ID: types.StringValue("0012C"), VolumeIdentifier: types.StringValue("ora_data_3")},
+	}
+	toRename := GetVolumeSetMembersToRename("ora_data_%03d", members)
+	if len(toRename) != 2 || toRename[0].ID.ValueString() != "0012B" || toRename[1].ID.ValueString() != "0012C" {
+		t.Errorf("expected volumes 0012B and 0012C to be renamed, got %v", toRename)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// CloudProviderResourceModel describes the cloud provider resource data model.
+type CloudProviderResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The name of the cloud provider.
+	Name types.String `tfsdk:"name"`
+	// The connection details of an ECS cloud provider.
+	Ecs *EcsConnectionModel `tfsdk:"ecs"`
+	// The connection details of an Azure cloud provider.
+	Azure *AzureConnectionModel `tfsdk:"azure"`
+	// The connection details of an Amazon cloud provider.
+	Amazon *AmazonConnectionModel `tfsdk:"amazon"`
+	// The UUID of the cloud provider.
+	UUID types.String `tfsdk:"uuid"`
+	// The current state of the cloud provider.
+	State types.String `tfsdk:"state"`
+	// The cloud provider type.
+	Type types.String `tfsdk:"type"`
+	// The total cloud provider used capacity in GB.
+	UsedCapacityGb types.Float64 `tfsdk:"used_capacity_gb"`
+	// The number of cloud policies associated with the cloud provider.
+	NumOfCloudPolicies types.Int64 `tfsdk:"num_of_cloud_policies"`
+	// The number of cloud snapshots associated with the cloud provider.
+	NumOfCloudSnapshots types.Int64 `tfsdk:"num_of_cloud_snapshots"`
+	// The number of cloud storage groups associated with the cloud provider.
+	NumOfCloudStorageGroups types.Int64 `tfsdk:"num_of_cloud_storage_groups"`
+}
+
+// EcsConnectionModel holds the connection details of an ECS cloud provider.
+type EcsConnectionModel struct {
+	Key    types.String `tfsdk:"key"`
+	Secret types.String `tfsdk:"secret"`
+	Node   types.String `tfsdk:"node"`
+	Secure types.Bool   `tfsdk:"secure"`
+	Port   types.Int64  `tfsdk:"port"`
+	Bucket types.String `tfsdk:"bucket"`
+}
+
+// AzureConnectionModel holds the connection details of an Azure cloud provider.
+type AzureConnectionModel struct {
+	StorageAccount types.String `tfsdk:"storage_account"`
+	ManagedKey     types.String `tfsdk:"managed_key"`
+	Secure         types.Bool   `tfsdk:"secure"`
+	URL            types.String `tfsdk:"url"`
+	Port           types.Int64  `tfsdk:"port"`
+	Container      types.String `tfsdk:"container"`
+}
+
+// AmazonConnectionModel holds the connection details of an Amazon cloud provider.
+type AmazonConnectionModel struct {
+	Key          types.String `tfsdk:"key"`
+	Secret       types.String `tfsdk:"secret"`
+	Secure       types.Bool   `tfsdk:"secure"`
+	Bucket       types.String `tfsdk:"bucket"`
+	Node         types.String `tfsdk:"node"`
+	Port         types.Int64  `tfsdk:"port"`
+	StorageClass types.String `tfsdk:"storage_class"`
+	Region       types.String `tfsdk:"region"`
+}
+
+// CloudProviderDataSourceModel describes the cloud provider data source data model.
+type CloudProviderDataSourceModel struct {
+	ID             types.String          `tfsdk:"id"`
+	CloudProviders []CloudProviderDetail `tfsdk:"cloud_providers"`
+	//filter
+	CloudProviderFilter *CloudProviderFilterType `tfsdk:"filter"`
+}
+
+// CloudProviderFilterType holds filter attribute for cloud provider.
+type CloudProviderFilterType struct {
+	Names []types.String `tfsdk:"names"`
+}
+
+// CloudProviderDetail holds the details of a cloud provider, the credentials are never read back.
+type CloudProviderDetail struct {
+	ID                      types.String  `tfsdk:"id"`
+	Name                    types.String  `tfsdk:"name"`
+	UUID                    types.String  `tfsdk:"uuid"`
+	State                   types.String  `tfsdk:"state"`
+	Type                    types.String  `tfsdk:"type"`
+	UsedCapacityGb          types.Float64 `tfsdk:"used_capacity_gb"`
+	NumOfCloudPolicies      types.Int64   `tfsdk:"num_of_cloud_policies"`
+	NumOfCloudSnapshots     types.Int64   `tfsdk:"num_of_cloud_snapshots"`
+	NumOfCloudStorageGroups types.Int64   `tfsdk:"num_of_cloud_storage_groups"`
+	Node                    types.String  `tfsdk:"node"`
+	URL                     types.String  `tfsdk:"url"`
+	Port                    types.Int64   `tfsdk:"port"`
+	Secure                  types.Bool    `tfsdk:"secure"`
+	Bucket                  types.String  `tfsdk:"bucket"`
+	Container               types.String  `tfsdk:"container"`
+	StorageAccount          types.String  `tfsdk:"storage_account"`
+	StorageClass            types.String  `tfsdk:"storage_class"`
+	Region                  types.String  `tfsdk:"region"`
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// CloudSnapshotResourceModel describes the cloud snapshot resource data model.
+type CloudSnapshotResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The storage group the cloud snapshot is taken of.
+	StorageGroup *FilterTypeSnapshot `tfsdk:"storage_group"`
+	// The name of the cloud snapshot.
+	Name types.String `tfsdk:"name"`
+	// The name of the cloud provider where the snapshot will reside.
+	CloudProviderID types.String `tfsdk:"cloud_provider_id"`
+	// The number of days the cloud snapshot is to live for.
+	ExpiryTimeDays types.Int64 `tfsdk:"expiry_time_days"`
+	// Restore the cloud snapshot to a new storage group.
+	Restore *CloudSnapshotRestore `tfsdk:"restore"`
+	// The date time the snapshot was created.
+	CreationDate types.String `tfsdk:"creation_date"`
+	// The timestamp the snapshot was created.
+	CreationDateTimestamp types.Int64 `tfsdk:"creation_date_timestamp"`
+	// The date time the snapshot is due to expire in the cloud.
+	ExpiryDate types.String `tfsdk:"expiry_date"`
+	// The timestamp the snapshot is due to expire in the cloud.
+	ExpiryDateTimestamp types.Int64 `tfsdk:"expiry_date_timestamp"`
+	// The current state of the cloud snapshot.
+	State types.String `tfsdk:"state"`
+	// The total capacity of the snapshot at creation time in GBs.
+	CapacityGb types.Float64 `tfsdk:"capacity_gb"`
+	// The progress percent of the snapshot being archived up to the cloud.
+	ProtectedPercent types.Int64 `tfsdk:"protected_percent"`
+	// The number of source volumes in the snapshot.
+	NumberOfVolumes types.Int64 `tfsdk:"number_of_volumes"`
+	// Whether encryption is enabled.
+	EncryptionEnabled types.Bool `tfsdk:"encryption_enabled"`
+	// Whether compression is enabled.
+	CompressionEnabled types.Bool `tfsdk:"compression_enabled"`
+	// Whether the storage group at the creation time no longer exists.
+	Orphaned types.Bool `tfsdk:"orphaned"`
+	// The storage groups the cloud snapshot has been restored to.
+	RecoveredStorageGroups types.List `tfsdk:"recovered_storage_groups"`
+}
+
+// CloudSnapshotRestore holds the details of restoring a cloud snapshot to a new storage group.
+type CloudSnapshotRestore struct {
+	TargetStorageGroup types.String `tfsdk:"target_storage_group"`
+	SrpID              types.String `tfsdk:"srp_id"`
+	SloID              types.String `tfsdk:"slo_id"`
+}
+
+// CloudSnapshotDataSourceModel describes the cloud snapshot data source data model.
+type CloudSnapshotDataSourceModel struct {
+	ID             types.String          `tfsdk:"id"`
+	CloudSnapshots []CloudSnapshotDetail `tfsdk:"cloud_snapshots"`
+	StorageGroup   *FilterTypeSnapshot   `tfsdk:"storage_group"`
+	//filter
+	CloudSnapshotFilter *CloudSnapshotFilterType `tfsdk:"filter"`
+}
+
+// CloudSnapshotFilterType holds filter attribute for cloud snapshot.
+type CloudSnapshotFilterType struct {
+	Names []types.String `tfsdk:"names"`
+}
+
+// CloudSnapshotDetail holds the details of a cloud snapshot.
+type CloudSnapshotDetail struct {
+	ID                     types.String  `tfsdk:"id"`
+	Name                   types.String  `tfsdk:"name"`
+	CloudProviderID        types.String  `tfsdk:"cloud_provider_id"`
+	StorageGroupName       types.String  `tfsdk:"storage_group_name"`
+	CreationDate           types.String  `tfsdk:"creation_date"`
+	CreationDateTimestamp  types.Int64   `tfsdk:"creation_date_timestamp"`
+	ExpiryDate             types.String  `tfsdk:"expiry_date"`
+	ExpiryDateTimestamp    types.Int64   `tfsdk:"expiry_date_timestamp"`
+	State                  types.String  `tfsdk:"state"`
+	CapacityGb             types.Float64 `tfsdk:"capacity_gb"`
+	ProtectedPercent       types.Int64   `tfsdk:"protected_percent"`
+	NumberOfVolumes        types.Int64   `tfsdk:"number_of_volumes"`
+	EncryptionEnabled      types.Bool    `tfsdk:"encryption_enabled"`
+	CompressionEnabled     types.Bool    `tfsdk:"compression_enabled"`
+	Orphaned               types.Bool    `tfsdk:"orphaned"`
+	RecoveredStorageGroups types.List    `tfsdk:"recovered_storage_groups"`
+	SourceVolumes          types.List    `tfsdk:"source_volumes"`
+}
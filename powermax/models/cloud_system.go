@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// CloudSystemDNSServerResourceModel describes the cloud system DNS server resource data model.
+type CloudSystemDNSServerResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The IP address of the DNS server.
+	IPAddress types.String `tfsdk:"ip_address"`
+}
+
+// CloudSystemRouteResourceModel describes the cloud system route resource data model.
+type CloudSystemRouteResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The destination IP address of the route.
+	DestinationIPAddress types.String `tfsdk:"destination_ip_address"`
+	// The netmask prefix number of the route.
+	Prefix types.String `tfsdk:"prefix"`
+	// The gateway IP address of the route.
+	GatewayIPAddress types.String `tfsdk:"gateway_ip_address"`
+	// The ID of the interface associated with the route.
+	InterfaceID types.String `tfsdk:"interface_id"`
+}
+
+// CloudSystemTeamResourceModel describes the cloud system NIC team resource data model.
+type CloudSystemTeamResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The IP address of the NIC team.
+	IPAddress types.String `tfsdk:"ip_address"`
+	// The netmask prefix number of the NIC team.
+	Prefix types.Int64 `tfsdk:"prefix"`
+	// The interfaces associated with the NIC team.
+	InterfaceIDs types.Set `tfsdk:"interface_ids"`
+	// The state of the NIC team.
+	State types.String `tfsdk:"state"`
+	// The MAC address of the default interface in the NIC team.
+	MacAddress types.String `tfsdk:"mac_address"`
+	// The MTU of the default interface in the NIC team.
+	Mtu types.Int64 `tfsdk:"mtu"`
+	// The number of routes associated with the default interface in the NIC team.
+	NumOfRoutes types.Int64 `tfsdk:"num_of_routes"`
+	// The default interface type in the NIC team.
+	Type types.String `tfsdk:"type"`
+	// The IP source of the default interface in the NIC team.
+	IPSource types.String `tfsdk:"ip_source"`
+}
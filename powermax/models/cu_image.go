@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// CuImageResourceModel describes the resource data model of a CU image of a FICON split.
+type CuImageResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The ID of the split the CU image belongs to.
+	SplitID types.String `tfsdk:"split_id"`
+	// The SSID of the CU image.
+	CuImageSSID types.String `tfsdk:"cu_image_ssid"`
+	// The number of the CU image.
+	CuImageNumber types.String `tfsdk:"cu_image_number"`
+	// The starting base address used to map the volumes.
+	StartBaseAddress types.String `tfsdk:"start_base_address"`
+	// The IDs of the volumes mapped to the CU image.
+	VolumeIDs types.Set `tfsdk:"volume_ids"`
+	// The start address of the alias range.
+	StartAliasAddress types.String `tfsdk:"start_alias_address"`
+	// The end address of the alias range.
+	EndAliasAddress types.String `tfsdk:"end_alias_address"`
+	// The status of the CU image.
+	Status types.String `tfsdk:"status"`
+	// The number of volumes mapped to the CU image.
+	NumMappedVolumes types.Int64 `tfsdk:"num_mapped_volumes"`
+	// The number of storage groups with volumes mapped to the CU image.
+	NumStorageGroups types.Int64 `tfsdk:"num_storage_groups"`
+	// The number of base addresses of the CU image.
+	NumBaseAddresses types.Int64 `tfsdk:"num_base_addresses"`
+	// The number of base addresses still available.
+	NumAvailableBaseAddresses types.Int64 `tfsdk:"num_available_base_addresses"`
+	// The next base address available for a volume mapping.
+	NextAvailableBaseAddress types.String `tfsdk:"next_available_base_address"`
+	// The number of aliases of the CU image.
+	NumAliases types.Int64 `tfsdk:"num_aliases"`
+}
+
+// CuImageDataSourceModel describes the data source data model of CU images.
+type CuImageDataSourceModel struct {
+	ID       types.String         `tfsdk:"id"`
+	CuImages []CuImageDetailModel `tfsdk:"cu_images"`
+	// filter
+	CuImageFilter *CuImageFilterType `tfsdk:"filter"`
+}
+
+// CuImageFilterType describes the filter of the CU image data source.
+type CuImageFilterType struct {
+	SplitIDs []types.String `tfsdk:"split_ids"`
+	SSIDs    []types.String `tfsdk:"ssids"`
+}
+
+// CuImageDetailModel describes a CU image.
+type CuImageDetailModel struct {
+	SplitID                   types.String `tfsdk:"split_id"`
+	SSID                      types.String `tfsdk:"ssid"`
+	ImageNumber               types.String `tfsdk:"image_number"`
+	Status                    types.String `tfsdk:"status"`
+	NumMappedVolumes          types.Int64  `tfsdk:"num_mapped_volumes"`
+	NumStorageGroups          types.Int64  `tfsdk:"num_storage_groups"`
+	NumBaseAddresses          types.Int64  `tfsdk:"num_base_addresses"`
+	NumAvailableBaseAddresses types.Int64  `tfsdk:"num_available_base_addresses"`
+	NextAvailableBaseAddress  types.String `tfsdk:"next_available_base_address"`
+	NumAliases                types.Int64  `tfsdk:"num_aliases"`
+	StartAliasAddress         types.String `tfsdk:"start_alias_address"`
+	EndAliasAddress           types.String `tfsdk:"end_alias_address"`
+}
@@ -46,6 +46,8 @@ type SnapshotResourceModel struct {
 	NumSourceVolumes types.Int64 `tfsdk:"num_source_volumes"`
 	// The source volumes of the snapshot generation.
 	SourceVolume types.List `tfsdk:"source_volume"`
+	// The source volumes of the snapshot generation with the volumes they are linked to.
+	SourceVolumeDetails types.List `tfsdk:"source_volume_details"`
 	// The number of non-gatekeeper storage group volumes.
 	NumStorageGroupVolumes types.Int64 `tfsdk:"num_storage_group_volumes"`
 	// The number of source tracks that have been overwritten by the host.
@@ -67,9 +69,11 @@ type SnapshotResourceModel struct {
 	// Linked storage group and volume information. Only populated if the generation is linked.
 	LinkedStorageGroup types.List `tfsdk:"linked_storage_group"`
 	// Set if this snapshot is persistent.  Only applicable to policy based snapshots.
-	Persistent   types.Bool              `tfsdk:"persistent"`
-	StorageGroup *FilterTypeSnapshot     `tfsdk:"storage_group"`
-	Snapshot     *SnapshotResourceFields `tfsdk:"snapshot_actions"`
+	Persistent types.Bool `tfsdk:"persistent"`
+	// How linked storage groups and restore sessions are handled when the snapshot is deleted.
+	DeleteBehavior types.String            `tfsdk:"delete_behavior"`
+	StorageGroup   *FilterTypeSnapshot     `tfsdk:"storage_group"`
+	Snapshot       *SnapshotResourceFields `tfsdk:"snapshot_actions"`
 }
 
 // SnapshotResourceFields The different Action fields for snapshot.
@@ -94,6 +98,10 @@ type linkActionFields struct {
 	NoCompression      types.Bool   `tfsdk:"no_compression"`
 	Remote             types.Bool   `tfsdk:"remote"`
 	Copy               types.Bool   `tfsdk:"copy"`
+	Relink             types.Bool   `tfsdk:"relink"`
+	WaitForCopy        types.Bool   `tfsdk:"wait_for_copy"`
+	WaitForDefined     types.Bool   `tfsdk:"wait_for_defined"`
+	WaitTimeoutMinutes types.Int64  `tfsdk:"wait_timeout_minutes"`
 }
 
 type ttlActionFields struct {
@@ -131,6 +139,8 @@ type SnapshotDetailModal struct {
 	NumSourceVolumes types.Int64 `tfsdk:"num_source_volumes"`
 	// The source volumes of the snapshot generation.
 	SourceVolume types.List `tfsdk:"source_volume"`
+	// The source volumes of the snapshot generation with the volumes they are linked to.
+	SourceVolumeDetails types.List `tfsdk:"source_volume_details"`
 	// The number of non-gatekeeper storage group volumes.
 	NumStorageGroupVolumes types.Int64 `tfsdk:"num_storage_group_volumes"`
 	// The number of source tracks that have been overwritten by the host.
@@ -165,6 +175,30 @@ type SnapshotGenerationSource struct {
 	CapacityGb types.Float64 `tfsdk:"capacity_gb"`
 }
 
+// SnapshotSourceVolumeDetail A source volume of the snapshot generation with the volumes it is linked to.
+type SnapshotSourceVolumeDetail struct {
+	// The name of the source volume.
+	Name types.String `tfsdk:"name"`
+	// The capacity of the source volume in cylinders.
+	Capacity types.Int64 `tfsdk:"capacity"`
+	// The capacity of the source volume in GB.
+	CapacityGb types.Float64 `tfsdk:"capacity_gb"`
+	// Set if the source volume is linked to a target volume.
+	Linked types.Bool `tfsdk:"linked"`
+	// The target volumes the source volume is linked to.
+	LinkedVolumes []SnapshotSourceVolumeLink `tfsdk:"linked_volumes"`
+}
+
+// SnapshotSourceVolumeLink A target volume a snapshot source volume is linked to.
+type SnapshotSourceVolumeLink struct {
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	LinkedVolumeName types.String `tfsdk:"linked_volume_name"`
+	Tracks           types.Int64  `tfsdk:"tracks"`
+	TrackSize        types.Int64  `tfsdk:"track_size"`
+	PercentageCopied types.Int64  `tfsdk:"percentage_copied"`
+	Defined          types.Bool   `tfsdk:"defined"`
+}
+
 // LinkedSnapshot Linked snapshot.
 type LinkedSnapshot struct {
 	// The storage group name.
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// SnapshotComplianceDataSourceModel describes the snapshot compliance data source data model.
+type SnapshotComplianceDataSourceModel struct {
+	ID            types.String                          `tfsdk:"id"`
+	StorageGroups []StorageGroupSnapshotComplianceModel `tfsdk:"storage_groups"`
+	//filter
+	SnapshotComplianceFilter *SnapshotComplianceFilterType `tfsdk:"filter"`
+}
+
+// SnapshotComplianceFilterType holds filter attribute for snapshot compliance.
+type SnapshotComplianceFilterType struct {
+	StorageGroupNames []types.String `tfsdk:"storage_group_names"`
+}
+
+// StorageGroupSnapshotComplianceModel holds the snapshot compliance of a storage group.
+type StorageGroupSnapshotComplianceModel struct {
+	// The name of the storage group.
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	// The overall snapshot compliance of the storage group, GREEN, YELLOW, RED or NONE.
+	Compliance types.String `tfsdk:"compliance"`
+	// The number of snapshot policies associated with the storage group.
+	PolicyCount types.Int64 `tfsdk:"policy_count"`
+	// The time of the newest snapshot of the storage group created by a snapshot policy which is not failed or expired.
+	LastGoodSnapshotTime types.String `tfsdk:"last_good_snapshot_time"`
+	// The timestamp of the newest good policy snapshot.
+	LastGoodSnapshotTimestamp types.Int64 `tfsdk:"last_good_snapshot_timestamp"`
+	// The compliance of the storage group for each of its snapshot policies.
+	Policies []SnapshotPolicyComplianceModel `tfsdk:"policies"`
+}
+
+// SnapshotPolicyComplianceModel holds the compliance of a storage group for a single snapshot policy.
+type SnapshotPolicyComplianceModel struct {
+	// The name of the snapshot policy.
+	SnapshotPolicyName types.String `tfsdk:"snapshot_policy_name"`
+	// The compliance of the storage group for the snapshot policy, GREEN, YELLOW, RED or NONE.
+	Compliance types.String `tfsdk:"compliance"`
+	// The time the compliance was calculated.
+	CalculationTime types.String `tfsdk:"calculation_time"`
+	// The threshold of good snapshots for compliance to change from normal to warning.
+	ComplianceCountWarning types.Int64 `tfsdk:"compliance_count_warning"`
+	// The threshold of good snapshots for compliance to change from warning to critical.
+	ComplianceCountCritical types.Int64 `tfsdk:"compliance_count_critical"`
+	// The number of snapshots in the compliance time window.
+	SnapshotsInTimeWindow types.Int64 `tfsdk:"snapshots_in_time_window"`
+	// The total number of snapshots of the storage group for the snapshot policy.
+	TotalSnapshots types.Int64 `tfsdk:"total_snapshots"`
+	// Set if the snapshot policy is suspended for the storage group.
+	Suspended types.Bool `tfsdk:"suspended"`
+	// The time of the newest good snapshot created by the snapshot policy.
+	LastGoodSnapshotTime types.String `tfsdk:"last_good_snapshot_time"`
+	// The timestamp of the newest good snapshot created by the snapshot policy.
+	LastGoodSnapshotTimestamp types.Int64 `tfsdk:"last_good_snapshot_timestamp"`
+	// The compliance error messages of the snapshot policy.
+	ErrorMessages []ComplianceErrorMessageModel `tfsdk:"error_messages"`
+}
+
+// ComplianceErrorMessageModel holds a compliance error message.
+type ComplianceErrorMessageModel struct {
+	Time         types.String `tfsdk:"time"`
+	ErrorMessage types.String `tfsdk:"error_message"`
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// SnapshotHistoryDataSourceModel describes the snapshot history data source data model.
+type SnapshotHistoryDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The storage group to list the snapshot generations of.
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	// The volume to list the snapshot generations of, from all its storage groups.
+	VolumeID    types.String                     `tfsdk:"volume_id"`
+	Generations []SnapshotHistoryGenerationModel `tfsdk:"generations"`
+	//filter
+	SnapshotHistoryFilter *SnapshotHistoryFilterType `tfsdk:"filter"`
+}
+
+// SnapshotHistoryFilterType holds filter attributes for the snapshot history.
+type SnapshotHistoryFilterType struct {
+	NamePrefix     types.String `tfsdk:"name_prefix"`
+	OlderThanHours types.Int64  `tfsdk:"older_than_hours"`
+	NewerThanHours types.Int64  `tfsdk:"newer_than_hours"`
+	Expired        types.Bool   `tfsdk:"expired"`
+}
+
+// SnapshotHistoryGenerationModel holds a single snapshot generation of the history.
+type SnapshotHistoryGenerationModel struct {
+	// The storage group of the snapshot.
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	// The name of the SnapVX snapshot.
+	SnapshotName types.String `tfsdk:"snapshot_name"`
+	// The unique snap ID of the generation.
+	Snapid types.Int64 `tfsdk:"snapid"`
+	// The generation number, 0 is the newest.
+	Generation types.Int64 `tfsdk:"generation"`
+	// The timestamp of the snapshot generation.
+	Timestamp types.String `tfsdk:"timestamp"`
+	// The timestamp of the snapshot generation in milliseconds since 1970.
+	TimestampUtc types.Int64 `tfsdk:"timestamp_utc"`
+	// The number of full hours since the generation was created.
+	AgeHours types.Int64 `tfsdk:"age_hours"`
+	// When the snapshot will expire once it is not linked.
+	TimeToLiveExpiryDate types.String `tfsdk:"time_to_live_expiry_date"`
+	// When the secure snapshot will expire.
+	SecureExpiryDate types.String `tfsdk:"secure_expiry_date"`
+	// Set if the generation is a secure snapshot.
+	Secure types.Bool `tfsdk:"secure"`
+	// Set if the generation has expired.
+	Expired types.Bool `tfsdk:"expired"`
+	// Set if the generation is SnapVX linked.
+	Linked types.Bool `tfsdk:"linked"`
+	// Set if the generation is restored.
+	Restored types.Bool `tfsdk:"restored"`
+	// The state of the generation.
+	State []types.String `tfsdk:"state"`
+	// The source volumes of the generation.
+	SourceVolumes []types.String `tfsdk:"source_volumes"`
+	// The link targets of the generation.
+	LinkedTargets []SnapshotHistoryLinkedTargetModel `tfsdk:"linked_targets"`
+}
+
+// SnapshotHistoryLinkedTargetModel holds a link target of a snapshot generation.
+type SnapshotHistoryLinkedTargetModel struct {
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	SourceVolumeName types.String `tfsdk:"source_volume_name"`
+	LinkedVolumeName types.String `tfsdk:"linked_volume_name"`
+	PercentageCopied types.Int64  `tfsdk:"percentage_copied"`
+	Defined          types.Bool   `tfsdk:"defined"`
+}
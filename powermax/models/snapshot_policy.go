@@ -48,19 +48,46 @@ type SnapshotPolicyModel struct {
 	ComplianceCountCritical types.Int64 `tfsdk:"compliance_count_critical"`
 	// The type of Snapshots that are created with the policy, local or cloud.
 	Type types.String `tfsdk:"type"`
+	// The storage groups associated with the snapshot policy, only set when expand_storage_groups is set.
+	StorageGroups []SnapshotPolicyStorageGroupModel `tfsdk:"storage_groups"`
+}
+
+// SnapshotPolicyStorageGroupModel describes a storage group associated with a snapshot policy.
+type SnapshotPolicyStorageGroupModel struct {
+	// The name of the storage group.
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	// The compliance of the storage group for the snapshot policy.
+	Compliance types.String `tfsdk:"compliance"`
+	// The number of snapshots in the compliance time window.
+	SnapshotsInTimeWindow types.Int64 `tfsdk:"snapshots_in_time_window"`
+	// The total number of snapshots of the storage group for the snapshot policy.
+	TotalSnapshots types.Int64 `tfsdk:"total_snapshots"`
+	// Set if the snapshot policy is suspended for the storage group.
+	Suspended types.Bool `tfsdk:"suspended"`
+	// The time the snapshot policy last created a snapshot of the storage group.
+	LastSnapshotTime types.String `tfsdk:"last_snapshot_time"`
+	// The time the snapshot policy last created a snapshot of the storage group, in milliseconds since 1970.
+	LastSnapshotTimestamp types.Int64 `tfsdk:"last_snapshot_timestamp"`
 }
 
 // SnapshotPolicyDataSourceModel describes the snapshot policy data source model.
 type SnapshotPolicyDataSourceModel struct {
 	ID               types.String          `tfsdk:"id"`
 	SnapshotPolicies []SnapshotPolicyModel `tfsdk:"snapshot_policies"`
+	// Set to list the storage groups associated with each snapshot policy.
+	ExpandStorageGroups types.Bool `tfsdk:"expand_storage_groups"`
 	//filter
 	SnapshotPolicyFilter *SnapshotPolicyFilterType `tfsdk:"filter"`
 }
 
 // SnapshotPolicyFilterType describes the filter data model.
 type SnapshotPolicyFilterType struct {
-	Names []types.String `tfsdk:"names"`
+	Names              []types.String `tfsdk:"names"`
+	Type               types.String   `tfsdk:"type"`
+	Suspended          types.Bool     `tfsdk:"suspended"`
+	MinIntervalMinutes types.Int64    `tfsdk:"min_interval_minutes"`
+	MaxIntervalMinutes types.Int64    `tfsdk:"max_interval_minutes"`
+	Tags               []types.String `tfsdk:"tags"`
 }
 
 // SnapshotPolicyResource structure.
@@ -0,0 +1,33 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// SnapshotPolicyAttachmentResourceModel describes the association of a storage group with a snapshot policy.
+type SnapshotPolicyAttachmentResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The name of the storage group.
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	// The name of the snapshot policy.
+	SnapshotPolicyName types.String `tfsdk:"snapshot_policy_name"`
+	// Set if the snapshot policy is suspended for the storage group.
+	Suspended types.Bool `tfsdk:"suspended"`
+	// The compliance of the storage group for the snapshot policy.
+	Compliance types.String `tfsdk:"compliance"`
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// SnapshotRestoreResourceModel describes the snapshot restore resource data model.
+type SnapshotRestoreResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The storage group the snapshot is restored to.
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	// The name of the snapshot to restore.
+	SnapshotName types.String `tfsdk:"snapshot_name"`
+	// The snap ID of the snapshot generation to restore.
+	Snapid types.Int64 `tfsdk:"snapid"`
+	// Must be set to the storage group name to confirm the restore.
+	Confirm types.String `tfsdk:"confirm"`
+	// Whether the data is propagated to the remote mirror of the SRDF volumes.
+	Remote types.Bool `tfsdk:"remote"`
+	// Whether the restore is refused when the storage group is in a masking view.
+	RequireUnmasked types.Bool `tfsdk:"require_unmasked"`
+	// The UTC time window in which the restore is allowed.
+	RestoreWindow *SnapshotRestoreWindow `tfsdk:"restore_window"`
+	// The number of minutes to wait for the restore to complete.
+	WaitTimeoutMinutes types.Int64 `tfsdk:"wait_timeout_minutes"`
+	// Whether the restore session is terminated once the restore has completed.
+	TerminateAfterRestore types.Bool `tfsdk:"terminate_after_restore"`
+	// When the restore completed.
+	RestoreCompletedAt types.String `tfsdk:"restore_completed_at"`
+	// Whether the restore session has been terminated.
+	SessionTerminated types.Bool `tfsdk:"session_terminated"`
+}
+
+// SnapshotRestoreWindow holds the UTC time window in which a restore is allowed.
+type SnapshotRestoreWindow struct {
+	Start types.String `tfsdk:"start"`
+	End   types.String `tfsdk:"end"`
+}
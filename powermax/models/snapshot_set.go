@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// SnapshotSetResourceModel describes the rolling snapshot generations resource data model.
+type SnapshotSetResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The storage group the snapshot generations are taken of.
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	// The name of the snapshot.
+	SnapshotName types.String `tfsdk:"snapshot_name"`
+	// The number of generations to keep.
+	RetentionCount types.Int64 `tfsdk:"retention_count"`
+	// The time to live of new generations.
+	TimeToLive types.Int64 `tfsdk:"time_to_live"`
+	// Whether the time to live is in hours instead of days.
+	TimeInHours types.Bool `tfsdk:"time_in_hours"`
+	// Whether each apply creates a new generation.
+	RotateOnApply types.Bool `tfsdk:"rotate_on_apply"`
+	// Arbitrary values which create a new generation when changed.
+	Triggers types.Map `tfsdk:"triggers"`
+	// The snap ID of the newest generation.
+	LatestSnapid types.Int64 `tfsdk:"latest_snapid"`
+	// The generations of the snapshot, newest first.
+	Generations types.List `tfsdk:"generations"`
+}
+
+// SnapshotSetGenerationModel holds the details of one generation of the snapshot set.
+type SnapshotSetGenerationModel struct {
+	Generation           types.Int64  `tfsdk:"generation"`
+	Snapid               types.Int64  `tfsdk:"snapid"`
+	Timestamp            types.String `tfsdk:"timestamp"`
+	TimestampUtc         types.Int64  `tfsdk:"timestamp_utc"`
+	TimeToLiveExpiryDate types.String `tfsdk:"time_to_live_expiry_date"`
+	Expired              types.Bool   `tfsdk:"expired"`
+	Linked               types.Bool   `tfsdk:"linked"`
+	Restored             types.Bool   `tfsdk:"restored"`
+}
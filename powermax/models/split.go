@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// SplitDataSourceModel describes the data source data model of FICON splits.
+type SplitDataSourceModel struct {
+	ID     types.String       `tfsdk:"id"`
+	Splits []SplitDetailModel `tfsdk:"splits"`
+	// filter
+	SplitFilter *SplitFilterType `tfsdk:"filter"`
+}
+
+// SplitFilterType describes the filter of the split data source.
+type SplitFilterType struct {
+	IDs []types.String `tfsdk:"ids"`
+}
+
+// SplitDetailModel describes a FICON split.
+type SplitDetailModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	AlphaSerialNum types.String `tfsdk:"alpha_serial_num"`
+	HyperPAVState  types.String `tfsdk:"hyper_pav_state"`
+	NumCUImages    types.Int64  `tfsdk:"num_cu_images"`
+	NumPorts       types.Int64  `tfsdk:"num_ports"`
+	Ports          []PortKey    `tfsdk:"ports"`
+}
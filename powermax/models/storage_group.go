@@ -47,10 +47,21 @@ type StorageGroupResourceModel struct {
 	CompressionRatio      types.String `tfsdk:"compression_ratio"`
 	CompressionRatioToOne types.Number `tfsdk:"compression_ratio_to_one"`
 	VpSavedPercent        types.Number `tfsdk:"vp_saved_percent"`
-	Tags                  types.String `tfsdk:"tags"`
+	Tags                  types.Set    `tfsdk:"tags"`
 	UUID                  types.String `tfsdk:"uuid"`
 	UnreducibleDataGb     types.Number `tfsdk:"unreducible_data_gb"`
 	VolumeIDs             types.List   `tfsdk:"volume_ids"`
+	// creation only attributes
+	VolumeAttributes           types.List `tfsdk:"volume_attributes"`
+	AllocateCapacityForEachVol types.Bool `tfsdk:"allocate_capacity_for_each_vol"`
+}
+
+// StorageGroupVolumeAttribute describes the data model of volumes created with a storage group.
+type StorageGroupVolumeAttribute struct {
+	Size             types.Number `tfsdk:"size"`
+	CapUnit          types.String `tfsdk:"cap_unit"`
+	NumOfVols        types.Int64  `tfsdk:"num_of_vols"`
+	VolumeIdentifier types.String `tfsdk:"volume_identifier"`
 }
 
 // SetHostIOLimitsParam describes the data model for setting host IO limits.
@@ -68,5 +79,6 @@ type StorageGroupDataSourceModel struct {
 }
 
 type sgFilterType struct {
-	IDs []types.String `tfsdk:"names"`
+	IDs  []types.String `tfsdk:"names"`
+	Tags []types.String `tfsdk:"tags"`
 }
@@ -0,0 +1,33 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// StorageGroupCascadeResourceModel describes the child storage groups of a cascaded storage group.
+type StorageGroupCascadeResourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// The name of the parent storage group.
+	ParentStorageGroupName types.String `tfsdk:"parent_storage_group_name"`
+	// The names of the child storage groups.
+	ChildStorageGroupNames types.Set `tfsdk:"child_storage_group_names"`
+	// Enable compliance alerts on the child storage groups when they are added.
+	EnableComplianceAlerts types.Bool `tfsdk:"enable_compliance_alerts"`
+	// Remove child storage groups even when the parent is in a masking view.
+	ForceRemove types.Bool `tfsdk:"force_remove"`
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// StorageGroupCloneResourceModel describes the storage group clone resource data model.
+type StorageGroupCloneResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	StorageGroupName       types.String `tfsdk:"storage_group_name"`
+	TargetStorageGroupName types.String `tfsdk:"target_storage_group_name"`
+	Action                 types.String `tfsdk:"action"`
+	Consistent             types.Bool   `tfsdk:"consistent"`
+	Vse                    types.Bool   `tfsdk:"vse"`
+	Force                  types.Bool   `tfsdk:"force"`
+	Star                   types.Bool   `tfsdk:"star"`
+	Skip                   types.Bool   `tfsdk:"skip"`
+	// Copy progress of the clone session
+	State              types.List             `tfsdk:"state"`
+	VolumePairCount    types.Int64            `tfsdk:"volume_pair_count"`
+	ModifiedTracks     types.Int64            `tfsdk:"modified_tracks"`
+	SrcProtectedTracks types.Int64            `tfsdk:"src_protected_tracks"`
+	SrcModifiedTracks  types.Int64            `tfsdk:"src_modified_tracks"`
+	VolumePairs        []CloneVolumePairModel `tfsdk:"volume_pairs"`
+}
+
+// CloneVolumePairModel holds the details of a clone volume pair.
+type CloneVolumePairModel struct {
+	SourceVolumeName   types.String `tfsdk:"source_volume_name"`
+	TargetVolumeName   types.String `tfsdk:"target_volume_name"`
+	State              types.String `tfsdk:"state"`
+	ModifiedTracks     types.Int64  `tfsdk:"modified_tracks"`
+	SrcProtectedTracks types.Int64  `tfsdk:"src_protected_tracks"`
+	SrcModifiedTracks  types.Int64  `tfsdk:"src_modified_tracks"`
+	BackgroundCopy     types.Bool   `tfsdk:"background_copy"`
+	Differential       types.Bool   `tfsdk:"differential"`
+	Precopy            types.Bool   `tfsdk:"precopy"`
+}
@@ -0,0 +1,46 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// TagsDataSourceModel describes the data source data model of the tags on the arrays.
+type TagsDataSourceModel struct {
+	ID   types.String     `tfsdk:"id"`
+	Tags []TagDetailModel `tfsdk:"tags"`
+	// filter
+	TagFilter *TagFilterType `tfsdk:"filter"`
+}
+
+// TagFilterType describes the filter of the tags data source.
+type TagFilterType struct {
+	Names []types.String `tfsdk:"names"`
+}
+
+// TagDetailModel describes a tag and the objects which carry it.
+type TagDetailModel struct {
+	Name          types.String              `tfsdk:"name"`
+	ArrayIDs      []types.String            `tfsdk:"array_ids"`
+	StorageGroups []TaggedStorageGroupModel `tfsdk:"storage_groups"`
+}
+
+// TaggedStorageGroupModel describes a storage group carrying a tag.
+type TaggedStorageGroupModel struct {
+	StorageGroupName types.String `tfsdk:"storage_group_name"`
+	ArrayID          types.String `tfsdk:"array_id"`
+}
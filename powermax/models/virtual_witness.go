@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// VirtualWitness holds virtual witness schema attribute details.
+type VirtualWitness struct {
+	// ID - defines virtual witness ID
+	ID types.String `tfsdk:"id"`
+	// Name - The name of the virtual witness
+	Name types.String `tfsdk:"name"`
+	// Location - The IP address or fully qualified domain name of the virtual witness
+	Location types.String `tfsdk:"location"`
+	// Port - The port number the virtual witness listens on
+	Port types.Int64 `tfsdk:"port"`
+	// Enabled - Whether the virtual witness is enabled
+	Enabled types.Bool `tfsdk:"enabled"`
+	// Alive - Whether the virtual witness is alive
+	Alive types.Bool `tfsdk:"alive"`
+	// InUse - Whether the virtual witness is in use
+	InUse types.Bool `tfsdk:"in_use"`
+	// Duplicate - Whether the virtual witness is a duplicate
+	Duplicate types.Bool `tfsdk:"duplicate"`
+	// LastHeartbeatTimestamp - The time of the last heartbeat received from the virtual witness
+	LastHeartbeatTimestamp types.Int64 `tfsdk:"last_heartbeat_timestamp"`
+}
+
+// VirtualWitnessDataSourceModel describes the data source data model.
+type VirtualWitnessDataSourceModel struct {
+	ID               types.String     `tfsdk:"id"`
+	VirtualWitnesses []VirtualWitness `tfsdk:"virtual_witnesses"`
+	//filter
+	VirtualWitnessFilter *VirtualWitnessFilterType `tfsdk:"filter"`
+}
+
+// VirtualWitnessFilterType holds filter attribute for virtual witness.
+type VirtualWitnessFilterType struct {
+	Names []types.String `tfsdk:"names"`
+}
@@ -49,6 +49,22 @@ type VolumeResource struct {
 	OracleInstanceName types.String `tfsdk:"oracle_instance_name"`
 	SymmetrixPortKey   types.List   `tfsdk:"symmetrix_port_key"`
 	RDFGroupIDList     types.List   `tfsdk:"rdf_group_ids"`
+	// Storage groups the volume is also a member of, besides sg_name.
+	AdditionalStorageGroupNames types.Set `tfsdk:"additional_sg_names"`
+	// Force moving the volume when the storage groups are masked.
+	ForceMove types.Bool `tfsdk:"force_move"`
+	// Free the allocations of the volume before deleting it.
+	FreeBeforeDelete types.Bool `tfsdk:"free_before_delete"`
+	// Minutes to wait for the allocations of the volume to be freed.
+	FreeTimeoutMinutes types.Int64 `tfsdk:"free_timeout_minutes"`
+	// Storage groups of the SRDF remote arrays the paired volumes are created in.
+	RemoteStorageGroups types.List `tfsdk:"remote_storage_groups"`
+}
+
+// VolumeRemoteStorageGroups holds the storage groups of an SRDF remote array.
+type VolumeRemoteStorageGroups struct {
+	SymmetrixID       types.String `tfsdk:"symmetrix_id"`
+	StorageGroupNames types.List   `tfsdk:"storage_group_names"`
 }
 
 // VolumeDatasourceFilter holds volume datasource filter schema attribute details.
@@ -96,6 +112,7 @@ type VolumeDatasourceFilter struct {
 	MobilityIDEnabled    types.Bool   `tfsdk:"mobility_id_enabled"`
 	UnreducibleDataGb    types.String `tfsdk:"unreducible_data_gb"`
 	Nguid                types.String `tfsdk:"nguid"`
+	Tags                 types.Set    `tfsdk:"tags"`
 }
 
 // VolumeDatasource holds volume datasource schema attribute details.
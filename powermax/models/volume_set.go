@@ -0,0 +1,43 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// VolumeSetResourceModel holds the schema attribute details of a set of identical volumes.
+type VolumeSetResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	StorageGroupName  types.String `tfsdk:"sg_name"`
+	NamePattern       types.String `tfsdk:"name_pattern"`
+	Count             types.Int64  `tfsdk:"volume_count"`
+	StartIndex        types.Int64  `tfsdk:"start_index"`
+	Size              types.Number `tfsdk:"size"`
+	CapUnit           types.String `tfsdk:"cap_unit"`
+	Emulation         types.String `tfsdk:"emulation"`
+	MobilityIDEnabled types.Bool   `tfsdk:"mobility_id_enabled"`
+	Volumes           types.List   `tfsdk:"volumes"`
+}
+
+// VolumeSetMember holds the details of one volume of a volume set.
+type VolumeSetMember struct {
+	Index            types.Int64  `tfsdk:"index"`
+	ID               types.String `tfsdk:"id"`
+	VolumeIdentifier types.String `tfsdk:"volume_identifier"`
+	Wwn              types.String `tfsdk:"wwn"`
+	CapGb            types.Number `tfsdk:"cap_gb"`
+}
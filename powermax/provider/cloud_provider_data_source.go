@@ -0,0 +1,247 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &cloudProviderDataSource{}
+	_ datasource.DataSourceWithConfigure = &cloudProviderDataSource{}
+)
+
+// NewCloudProviderDataSource is a helper function to simplify the provider implementation.
+func NewCloudProviderDataSource() datasource.DataSource {
+	return &cloudProviderDataSource{}
+}
+
+// cloudProviderDataSource is the data source implementation.
+type cloudProviderDataSource struct {
+	client *client.Client
+}
+
+func (d *cloudProviderDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_provider"
+}
+
+func (d *cloudProviderDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for reading Cloud Providers in PowerMax array. The credentials of the cloud providers are never read back from the array.",
+		Description:         "Data source for reading Cloud Providers in PowerMax array. The credentials of the cloud providers are never read back from the array.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"cloud_providers": schema.ListNestedAttribute{
+				Description:         "List of cloud providers",
+				MarkdownDescription: "List of cloud providers",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "The ID of the cloud provider.",
+							MarkdownDescription: "The ID of the cloud provider.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "The name of the cloud provider.",
+							MarkdownDescription: "The name of the cloud provider.",
+							Computed:            true,
+						},
+						"uuid": schema.StringAttribute{
+							Description:         "The UUID of the cloud provider.",
+							MarkdownDescription: "The UUID of the cloud provider.",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							Description:         "The current state of the cloud provider.",
+							MarkdownDescription: "The current state of the cloud provider.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							Description:         "The type of the cloud provider.",
+							MarkdownDescription: "The type of the cloud provider.",
+							Computed:            true,
+						},
+						"used_capacity_gb": schema.Float64Attribute{
+							Description:         "The capacity used on the cloud provider in GB.",
+							MarkdownDescription: "The capacity used on the cloud provider in GB.",
+							Computed:            true,
+						},
+						"num_of_cloud_policies": schema.Int64Attribute{
+							Description:         "The number of cloud policies associated with the cloud provider.",
+							MarkdownDescription: "The number of cloud policies associated with the cloud provider.",
+							Computed:            true,
+						},
+						"num_of_cloud_snapshots": schema.Int64Attribute{
+							Description:         "The number of cloud snapshots on the cloud provider.",
+							MarkdownDescription: "The number of cloud snapshots on the cloud provider.",
+							Computed:            true,
+						},
+						"num_of_cloud_storage_groups": schema.Int64Attribute{
+							Description:         "The number of storage groups with cloud snapshots on the cloud provider.",
+							MarkdownDescription: "The number of storage groups with cloud snapshots on the cloud provider.",
+							Computed:            true,
+						},
+						"node": schema.StringAttribute{
+							Description:         "The node or endpoint of an ECS or Amazon cloud provider.",
+							MarkdownDescription: "The node or endpoint of an ECS or Amazon cloud provider.",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							Description:         "The URL of an Azure cloud provider.",
+							MarkdownDescription: "The URL of an Azure cloud provider.",
+							Computed:            true,
+						},
+						"port": schema.Int64Attribute{
+							Description:         "The port used to connect to the cloud provider.",
+							MarkdownDescription: "The port used to connect to the cloud provider.",
+							Computed:            true,
+						},
+						"secure": schema.BoolAttribute{
+							Description:         "Whether a secure connection is used to connect to the cloud provider.",
+							MarkdownDescription: "Whether a secure connection is used to connect to the cloud provider.",
+							Computed:            true,
+						},
+						"bucket": schema.StringAttribute{
+							Description:         "The bucket of an ECS or Amazon cloud provider.",
+							MarkdownDescription: "The bucket of an ECS or Amazon cloud provider.",
+							Computed:            true,
+						},
+						"container": schema.StringAttribute{
+							Description:         "The container of an Azure cloud provider.",
+							MarkdownDescription: "The container of an Azure cloud provider.",
+							Computed:            true,
+						},
+						"storage_account": schema.StringAttribute{
+							Description:         "The storage account of an Azure cloud provider.",
+							MarkdownDescription: "The storage account of an Azure cloud provider.",
+							Computed:            true,
+						},
+						"storage_class": schema.StringAttribute{
+							Description:         "The storage class of an Amazon cloud provider.",
+							MarkdownDescription: "The storage class of an Amazon cloud provider.",
+							Computed:            true,
+						},
+						"region": schema.StringAttribute{
+							Description:         "The region of an Amazon cloud provider.",
+							MarkdownDescription: "The region of an Amazon cloud provider.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"names": schema.SetAttribute{
+						Description:         "The names of the cloud providers to list.",
+						MarkdownDescription: "The names of the cloud providers to list.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *cloudProviderDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider is not config
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Config Failure",
+			fmt.Sprintf("Expected client, %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *cloudProviderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.CloudProviderDataSourceModel
+	var plan models.CloudProviderDataSourceModel
+	tflog.Info(ctx, "Attempting to read cloud providers")
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	if plan.CloudProviderFilter != nil && len(plan.CloudProviderFilter.Names) > 0 {
+		for _, name := range plan.CloudProviderFilter.Names {
+			names = append(names, name.ValueString())
+		}
+	} else {
+		list, _, err := helper.ListCloudProviders(ctx, *d.client)
+		if err != nil {
+			errStr := constants.ReadCloudProviders + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the list of cloud providers",
+				message,
+			)
+			return
+		}
+		names = list.CloudProviderId
+	}
+
+	state.CloudProviders = []models.CloudProviderDetail{}
+	for _, name := range names {
+		cloudProvider, _, err := helper.GetCloudProvider(ctx, *d.client, name)
+		if err != nil {
+			errStr := constants.ReadCloudProviders + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the cloud provider details",
+				message,
+			)
+			return
+		}
+		var detail models.CloudProviderDetail
+		helper.UpdateCloudProviderDatasourceState(&detail, cloudProvider)
+		state.CloudProviders = append(state.CloudProviders, detail)
+	}
+	state.ID = types.StringValue("cloud-provider-datasource")
+	state.CloudProviderFilter = plan.CloudProviderFilter
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,518 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &CloudProvider{}
+	_ resource.ResourceWithConfigure   = &CloudProvider{}
+	_ resource.ResourceWithImportState = &CloudProvider{}
+)
+
+// NewCloudProvider is a helper function to simplify the provider implementation.
+func NewCloudProvider() resource.Resource {
+	return &CloudProvider{}
+}
+
+// CloudProvider defines the resource implementation.
+type CloudProvider struct {
+	client *client.Client
+}
+
+// cloudProviderTypeValidators ensures exactly one of the connection blocks is configured.
+func cloudProviderTypeValidators() []validator.Object {
+	return []validator.Object{
+		objectvalidator.ExactlyOneOf(
+			path.MatchRoot("ecs"),
+			path.MatchRoot("azure"),
+			path.MatchRoot("amazon"),
+		),
+	}
+}
+
+// cloudProviderPortAttribute returns the schema of the port of a cloud provider connection.
+func cloudProviderPortAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:            true,
+		Description:         "The port used to connect to the cloud provider. (Update Supported)",
+		MarkdownDescription: "The port used to connect to the cloud provider. (Update Supported)",
+		Validators: []validator.Int64{
+			int64validator.Between(1, 65535),
+		},
+	}
+}
+
+// cloudProviderSecureAttribute returns the schema of the secure flag of a cloud provider connection.
+func cloudProviderSecureAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		Description:         "Whether a secure connection is used to connect to the cloud provider. (Update Supported)",
+		MarkdownDescription: "Whether a secure connection is used to connect to the cloud provider. (Update Supported)",
+	}
+}
+
+// Schema Resource schema.
+func (r *CloudProvider) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for managing Cloud Providers in PowerMax array. A cloud provider holds the connection details of the ECS, Azure or Amazon object store used for cloud snapshots and cloud enabled snapshot policies.",
+		Description:         "Resource for managing Cloud Providers in PowerMax array. A cloud provider holds the connection details of the ECS, Azure or Amazon object store used for cloud snapshots and cloud enabled snapshot policies.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The ID of the cloud provider.",
+				MarkdownDescription: "The ID of the cloud provider.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the cloud provider. Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed. (Update Supported)",
+				MarkdownDescription: "The name of the cloud provider. Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed. (Update Supported)",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(64),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_-]*$`),
+						"must contain only alphanumeric characters and _-",
+					),
+				},
+			},
+			"ecs": schema.SingleNestedAttribute{
+				Optional:            true,
+				Description:         "The connection details of an ECS cloud provider. Exactly one of `ecs`, `azure` and `amazon` must be set.",
+				MarkdownDescription: "The connection details of an ECS cloud provider. Exactly one of `ecs`, `azure` and `amazon` must be set.",
+				Validators:          cloudProviderTypeValidators(),
+				Attributes: map[string]schema.Attribute{
+					"key": schema.StringAttribute{
+						Required:            true,
+						Sensitive:           true,
+						Description:         "The access key of the ECS object user. (Update Supported)",
+						MarkdownDescription: "The access key of the ECS object user. (Update Supported)",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"secret": schema.StringAttribute{
+						Required:            true,
+						Sensitive:           true,
+						Description:         "The secret key of the ECS object user. (Update Supported)",
+						MarkdownDescription: "The secret key of the ECS object user. (Update Supported)",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"node": schema.StringAttribute{
+						Required:            true,
+						Description:         "The IP address or hostname of the ECS node. (Update Supported)",
+						MarkdownDescription: "The IP address or hostname of the ECS node. (Update Supported)",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"secure": cloudProviderSecureAttribute(),
+					"port":   cloudProviderPortAttribute(),
+					"bucket": schema.StringAttribute{
+						Optional:            true,
+						Description:         "The ECS bucket used by the cloud provider. Cannot be updated after creation.",
+						MarkdownDescription: "The ECS bucket used by the cloud provider. Cannot be updated after creation.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+				},
+			},
+			"azure": schema.SingleNestedAttribute{
+				Optional:            true,
+				Description:         "The connection details of an Azure cloud provider. Exactly one of `ecs`, `azure` and `amazon` must be set.",
+				MarkdownDescription: "The connection details of an Azure cloud provider. Exactly one of `ecs`, `azure` and `amazon` must be set.",
+				Validators:          cloudProviderTypeValidators(),
+				Attributes: map[string]schema.Attribute{
+					"storage_account": schema.StringAttribute{
+						Required:            true,
+						Description:         "The name of the Azure storage account. (Update Supported)",
+						MarkdownDescription: "The name of the Azure storage account. (Update Supported)",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"managed_key": schema.StringAttribute{
+						Required:            true,
+						Sensitive:           true,
+						Description:         "The access key of the Azure storage account. (Update Supported)",
+						MarkdownDescription: "The access key of the Azure storage account. (Update Supported)",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"secure": cloudProviderSecureAttribute(),
+					"url": schema.StringAttribute{
+						Optional:            true,
+						Description:         "The URL of the Azure blob service. (Update Supported)",
+						MarkdownDescription: "The URL of the Azure blob service. (Update Supported)",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"port": cloudProviderPortAttribute(),
+					"container": schema.StringAttribute{
+						Optional:            true,
+						Description:         "The Azure container used by the cloud provider. Cannot be updated after creation.",
+						MarkdownDescription: "The Azure container used by the cloud provider. Cannot be updated after creation.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+				},
+			},
+			"amazon": schema.SingleNestedAttribute{
+				Optional:            true,
+				Description:         "The connection details of an Amazon cloud provider. Exactly one of `ecs`, `azure` and `amazon` must be set.",
+				MarkdownDescription: "The connection details of an Amazon cloud provider. Exactly one of `ecs`, `azure` and `amazon` must be set.",
+				Validators:          cloudProviderTypeValidators(),
+				Attributes: map[string]schema.Attribute{
+					"key": schema.StringAttribute{
+						Required:            true,
+						Sensitive:           true,
+						Description:         "The AWS access key ID. (Update Supported)",
+						MarkdownDescription: "The AWS access key ID. (Update Supported)",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"secret": schema.StringAttribute{
+						Required:            true,
+						Sensitive:           true,
+						Description:         "The AWS secret access key. (Update Supported)",
+						MarkdownDescription: "The AWS secret access key. (Update Supported)",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"secure": cloudProviderSecureAttribute(),
+					"bucket": schema.StringAttribute{
+						Optional:            true,
+						Description:         "The S3 bucket used by the cloud provider. Cannot be updated after creation.",
+						MarkdownDescription: "The S3 bucket used by the cloud provider. Cannot be updated after creation.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"node": schema.StringAttribute{
+						Optional:            true,
+						Description:         "The S3 endpoint of the cloud provider. Cannot be updated after creation.",
+						MarkdownDescription: "The S3 endpoint of the cloud provider. Cannot be updated after creation.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"port": cloudProviderPortAttribute(),
+					"storage_class": schema.StringAttribute{
+						Optional:            true,
+						Description:         "The S3 storage class of the cloud provider. Cannot be updated after creation.",
+						MarkdownDescription: "The S3 storage class of the cloud provider. Cannot be updated after creation.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"region": schema.StringAttribute{
+						Optional:            true,
+						Description:         "The AWS region of the cloud provider. Cannot be updated after creation.",
+						MarkdownDescription: "The AWS region of the cloud provider. Cannot be updated after creation.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+				},
+			},
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The UUID of the cloud provider.",
+				MarkdownDescription: "The UUID of the cloud provider.",
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The current state of the cloud provider.",
+				MarkdownDescription: "The current state of the cloud provider.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The type of the cloud provider.",
+				MarkdownDescription: "The type of the cloud provider.",
+			},
+			"used_capacity_gb": schema.Float64Attribute{
+				Computed:            true,
+				Description:         "The capacity used on the cloud provider in GB.",
+				MarkdownDescription: "The capacity used on the cloud provider in GB.",
+			},
+			"num_of_cloud_policies": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The number of cloud policies associated with the cloud provider.",
+				MarkdownDescription: "The number of cloud policies associated with the cloud provider.",
+			},
+			"num_of_cloud_snapshots": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The number of cloud snapshots on the cloud provider.",
+				MarkdownDescription: "The number of cloud snapshots on the cloud provider.",
+			},
+			"num_of_cloud_storage_groups": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The number of storage groups with cloud snapshots on the cloud provider.",
+				MarkdownDescription: "The number of storage groups with cloud snapshots on the cloud provider.",
+			},
+		},
+	}
+}
+
+// Metadata Resource metadata.
+func (r *CloudProvider) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_provider"
+}
+
+// Configure CloudProvider.
+func (r *CloudProvider) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = pmaxClient
+}
+
+// Create CloudProvider.
+func (r *CloudProvider) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating cloud provider")
+
+	var plan models.CloudProviderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := helper.CreateCloudProvider(ctx, *r.client, plan)
+	if err != nil {
+		errStr := constants.CreateCloudProviderDetailErrorMsg + plan.Name.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating cloud provider", msgStr,
+		)
+		return
+	}
+
+	cpResponse, _, err := helper.GetCloudProvider(ctx, *r.client, plan.Name.ValueString())
+	if err != nil {
+		errStr := constants.ReadCloudProviderDetailsErrorMsg + plan.Name.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating cloud provider", msgStr,
+		)
+		return
+	}
+	tflog.Debug(ctx, "get cloud provider response", map[string]interface{}{
+		"cloudProviderID": cpResponse.CloudProviderId,
+		"state":           cpResponse.GetState(),
+	})
+
+	// The connection details are kept from the plan since the credentials are never read back
+	cpState := plan
+	helper.UpdateCloudProviderState(&cpState, cpResponse)
+
+	diags = resp.State.Set(ctx, cpState)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create cloud provider completed")
+}
+
+// Read CloudProvider.
+func (r *CloudProvider) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading cloud provider")
+	var cpState models.CloudProviderResourceModel
+	diags := req.State.Get(ctx, &cpState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cpID := cpState.ID.ValueString()
+	tflog.Debug(ctx, "getting cloud provider by ID", map[string]interface{}{
+		"symmetrixID":     r.client.SymmetrixID,
+		"cloudProviderID": cpID,
+	})
+	cpResponse, _, err := helper.GetCloudProvider(ctx, *r.client, cpID)
+	if err != nil {
+		errStr := constants.ReadCloudProviderDetailsErrorMsg + cpID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud provider", msgStr,
+		)
+		return
+	}
+
+	helper.UpdateCloudProviderState(&cpState, cpResponse)
+
+	diags = resp.State.Set(ctx, cpState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "read cloud provider completed")
+}
+
+// unsupportedCloudProviderChanges returns the connection details in the plan which the array cannot modify.
+func unsupportedCloudProviderChanges(plan models.CloudProviderResourceModel, state models.CloudProviderResourceModel) []string {
+	var changes []string
+	if (plan.Ecs == nil) != (state.Ecs == nil) || (plan.Azure == nil) != (state.Azure == nil) || (plan.Amazon == nil) != (state.Amazon == nil) {
+		return append(changes, "cloud provider type")
+	}
+	if plan.Ecs != nil && !plan.Ecs.Bucket.Equal(state.Ecs.Bucket) {
+		changes = append(changes, "ecs.bucket")
+	}
+	if plan.Azure != nil && !plan.Azure.Container.Equal(state.Azure.Container) {
+		changes = append(changes, "azure.container")
+	}
+	if plan.Amazon != nil {
+		if !plan.Amazon.Bucket.Equal(state.Amazon.Bucket) {
+			changes = append(changes, "amazon.bucket")
+		}
+		if !plan.Amazon.Node.Equal(state.Amazon.Node) {
+			changes = append(changes, "amazon.node")
+		}
+		if !plan.Amazon.StorageClass.Equal(state.Amazon.StorageClass) {
+			changes = append(changes, "amazon.storage_class")
+		}
+		if !plan.Amazon.Region.Equal(state.Amazon.Region) {
+			changes = append(changes, "amazon.region")
+		}
+	}
+	return changes
+}
+
+// Update CloudProvider
+// Supported updates: name and the connection details other than the bucket, container, storage class and region.
+func (r *CloudProvider) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating cloud provider")
+	var cpPlan, cpState models.CloudProviderResourceModel
+	diags := req.State.Get(ctx, &cpState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &cpPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, change := range unsupportedCloudProviderChanges(cpPlan, cpState) {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("%s cannot be updated after creation.", change),
+			fmt.Sprintf("unexpected error: %s change is not supported", change),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cpID := cpState.ID.ValueString()
+	_, _, err := helper.ModifyCloudProvider(ctx, *r.client, cpPlan, cpState)
+	if err != nil {
+		errStr := constants.UpdateCloudProviderDetailsErrorMsg + cpID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error updating cloud provider", msgStr,
+		)
+		return
+	}
+
+	cpID = cpPlan.Name.ValueString()
+	cpResponse, _, err := helper.GetCloudProvider(ctx, *r.client, cpID)
+	if err != nil {
+		errStr := constants.ReadCloudProviderDetailsErrorMsg + cpID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud provider", msgStr,
+		)
+		return
+	}
+
+	cpState = cpPlan
+	helper.UpdateCloudProviderState(&cpState, cpResponse)
+
+	diags = resp.State.Set(ctx, cpState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "update cloud provider completed")
+}
+
+// Delete CloudProvider.
+func (r *CloudProvider) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting cloud provider")
+	var cpState models.CloudProviderResourceModel
+	diags := req.State.Get(ctx, &cpState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cpID := cpState.ID.ValueString()
+	tflog.Debug(ctx, "calling delete cloud provider on pmax client", map[string]interface{}{
+		"symmetrixID":     r.client.SymmetrixID,
+		"cloudProviderID": cpID,
+	})
+
+	_, err := helper.DeleteCloudProvider(ctx, *r.client, cpID)
+	if err != nil {
+		errStr := constants.DeleteCloudProviderDetailsErrorMsg + cpID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error deleting cloud provider", msgStr,
+		)
+	}
+	tflog.Info(ctx, "delete cloud provider completed")
+}
+
+// ImportState import resource.
+// The credentials cannot be read from the array and have to be added to the configuration after the import.
+func (r *CloudProvider) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing cloud provider state")
+	cpID := req.ID
+	cpResponse, _, err := helper.GetCloudProvider(ctx, *r.client, cpID)
+	if err != nil {
+		errStr := constants.ReadCloudProviderDetailsErrorMsg + cpID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error importing cloud provider", msgStr,
+		)
+		return
+	}
+
+	var cpState models.CloudProviderResourceModel
+	helper.UpdateCloudProviderState(&cpState, cpResponse)
+	helper.ImportCloudProviderConnectionState(&cpState, cpResponse)
+
+	diags := resp.State.Set(ctx, cpState)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "import cloud provider state completed")
+}
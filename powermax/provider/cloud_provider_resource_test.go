@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var createCloudProviderConfig = `
+resource "powermax_cloud_provider" "test_cloud_provider" {
+	name = "tfacc_cloud_provider"
+	ecs = {
+		key    = "tfacc_key"
+		secret = "tfacc_secret"
+		node   = "10.0.0.10"
+		secure = true
+		port   = 9021
+		bucket = "tfacc-bucket"
+	}
+}
+`
+var updateCloudProviderConfig = `
+resource "powermax_cloud_provider" "test_cloud_provider" {
+	# This will be updated
+	name = "tfacc_cloud_provider_new"
+	ecs = {
+		key    = "tfacc_key"
+		secret = "tfacc_secret_new"
+		node   = "10.0.0.10"
+		secure = true
+		port   = 9021
+		bucket = "tfacc-bucket"
+	}
+}
+`
+var updateCloudProviderBucketConfig = `
+resource "powermax_cloud_provider" "test_cloud_provider" {
+	name = "tfacc_cloud_provider_new"
+	ecs = {
+		key    = "tfacc_key"
+		secret = "tfacc_secret_new"
+		node   = "10.0.0.10"
+		secure = true
+		port   = 9021
+		# The bucket cannot be updated
+		bucket = "tfacc-bucket-new"
+	}
+}
+`
+var invalidCloudProviderConfig = `
+resource "powermax_cloud_provider" "test_cloud_provider" {
+	name = "tfacc_cloud_provider"
+	ecs = {
+		key    = "tfacc_key"
+		secret = "tfacc_secret"
+		node   = "10.0.0.10"
+	}
+	azure = {
+		storage_account = "tfaccaccount"
+		managed_key     = "tfacc_key"
+	}
+}
+`
+
+func TestAccCloudProviderResource(t *testing.T) {
+	var cloudProviderTerraformName = "powermax_cloud_provider.test_cloud_provider"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + createCloudProviderConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cloudProviderTerraformName, "name", "tfacc_cloud_provider"),
+					resource.TestCheckResourceAttr(cloudProviderTerraformName, "ecs.node", "10.0.0.10"),
+					resource.TestCheckResourceAttr(cloudProviderTerraformName, "ecs.bucket", "tfacc-bucket"),
+					resource.TestCheckResourceAttrSet(cloudProviderTerraformName, "uuid"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:            cloudProviderTerraformName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ecs.key", "ecs.secret"},
+			},
+			// Update testing
+			{
+				Config: ProviderConfig + updateCloudProviderConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cloudProviderTerraformName, "name", "tfacc_cloud_provider_new"),
+					resource.TestCheckResourceAttr(cloudProviderTerraformName, "id", "tfacc_cloud_provider_new"),
+				),
+			},
+			// Update of a non modifiable connection detail
+			{
+				Config:      ProviderConfig + updateCloudProviderBucketConfig,
+				ExpectError: regexp.MustCompile(`.*ecs.bucket cannot be updated after creation*.`),
+			},
+			// Read test Error
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetCloudProvider).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + updateCloudProviderConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Modify Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.ModifyCloudProvider).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudProviderConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// auto checks delete to clean up the test
+		},
+	})
+}
+
+func TestAccCloudProviderResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateCloudProvider).Return(nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudProviderConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccCloudProviderResourceInvalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + invalidCloudProviderConfig,
+				ExpectError: regexp.MustCompile(`.*Invalid Attribute Combination*.`),
+			},
+		},
+	})
+}
@@ -0,0 +1,284 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &cloudSnapshotDataSource{}
+	_ datasource.DataSourceWithConfigure = &cloudSnapshotDataSource{}
+)
+
+// NewCloudSnapshotDataSource is a helper function to simplify the provider implementation.
+func NewCloudSnapshotDataSource() datasource.DataSource {
+	return &cloudSnapshotDataSource{}
+}
+
+// cloudSnapshotDataSource is the data source implementation.
+type cloudSnapshotDataSource struct {
+	client *client.Client
+}
+
+func (d *cloudSnapshotDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_snapshot"
+}
+
+func (d *cloudSnapshotDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for the cloud snapshots of a specific StorageGroup in PowerMax array. A cloud snapshot is a snapshot of a storage group archived to a cloud provider.",
+		Description:         "Data source for the cloud snapshots of a specific StorageGroup in PowerMax array. A cloud snapshot is a snapshot of a storage group archived to a cloud provider.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"cloud_snapshots": schema.ListNestedAttribute{
+				Description:         "List of cloud snapshots",
+				MarkdownDescription: "List of cloud snapshots",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "The ID of the cloud snapshot.",
+							MarkdownDescription: "The ID of the cloud snapshot.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "The name of the cloud snapshot.",
+							MarkdownDescription: "The name of the cloud snapshot.",
+							Computed:            true,
+						},
+						"cloud_provider_id": schema.StringAttribute{
+							Description:         "The name of the cloud provider the snapshot resides on.",
+							MarkdownDescription: "The name of the cloud provider the snapshot resides on.",
+							Computed:            true,
+						},
+						"storage_group_name": schema.StringAttribute{
+							Description:         "The name of the storage group at the time the cloud snapshot was created.",
+							MarkdownDescription: "The name of the storage group at the time the cloud snapshot was created.",
+							Computed:            true,
+						},
+						"creation_date": schema.StringAttribute{
+							Description:         "The date time the cloud snapshot was created.",
+							MarkdownDescription: "The date time the cloud snapshot was created.",
+							Computed:            true,
+						},
+						"creation_date_timestamp": schema.Int64Attribute{
+							Description:         "The timestamp the cloud snapshot was created.",
+							MarkdownDescription: "The timestamp the cloud snapshot was created.",
+							Computed:            true,
+						},
+						"expiry_date": schema.StringAttribute{
+							Description:         "The date time the cloud snapshot is due to expire in the cloud.",
+							MarkdownDescription: "The date time the cloud snapshot is due to expire in the cloud.",
+							Computed:            true,
+						},
+						"expiry_date_timestamp": schema.Int64Attribute{
+							Description:         "The timestamp the cloud snapshot is due to expire in the cloud.",
+							MarkdownDescription: "The timestamp the cloud snapshot is due to expire in the cloud.",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							Description:         "The current state of the cloud snapshot.",
+							MarkdownDescription: "The current state of the cloud snapshot.",
+							Computed:            true,
+						},
+						"capacity_gb": schema.Float64Attribute{
+							Description:         "The total capacity of the cloud snapshot at creation time in GBs.",
+							MarkdownDescription: "The total capacity of the cloud snapshot at creation time in GBs.",
+							Computed:            true,
+						},
+						"protected_percent": schema.Int64Attribute{
+							Description:         "The progress percent of the cloud snapshot being archived up to the cloud.",
+							MarkdownDescription: "The progress percent of the cloud snapshot being archived up to the cloud.",
+							Computed:            true,
+						},
+						"number_of_volumes": schema.Int64Attribute{
+							Description:         "The number of source volumes in the cloud snapshot.",
+							MarkdownDescription: "The number of source volumes in the cloud snapshot.",
+							Computed:            true,
+						},
+						"encryption_enabled": schema.BoolAttribute{
+							Description:         "Whether encryption is enabled for the cloud snapshot.",
+							MarkdownDescription: "Whether encryption is enabled for the cloud snapshot.",
+							Computed:            true,
+						},
+						"compression_enabled": schema.BoolAttribute{
+							Description:         "Whether compression is enabled for the cloud snapshot.",
+							MarkdownDescription: "Whether compression is enabled for the cloud snapshot.",
+							Computed:            true,
+						},
+						"orphaned": schema.BoolAttribute{
+							Description:         "Whether the storage group the cloud snapshot was taken of no longer exists.",
+							MarkdownDescription: "Whether the storage group the cloud snapshot was taken of no longer exists.",
+							Computed:            true,
+						},
+						"recovered_storage_groups": schema.ListAttribute{
+							Description:         "The storage groups the cloud snapshot has been restored to.",
+							MarkdownDescription: "The storage groups the cloud snapshot has been restored to.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"source_volumes": schema.ListAttribute{
+							Description:         "The source volumes of the cloud snapshot.",
+							MarkdownDescription: "The source volumes of the cloud snapshot.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"storage_group": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description:         "Name of the storage group to list the cloud snapshots of.",
+						MarkdownDescription: "Name of the storage group to list the cloud snapshots of.",
+						Required:            true,
+					},
+				},
+			},
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"names": schema.SetAttribute{
+						Description:         "The names of the cloud snapshots to list.",
+						MarkdownDescription: "The names of the cloud snapshots to list.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *cloudSnapshotDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider is not config
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Config Failure",
+			fmt.Sprintf("Expected client, %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *cloudSnapshotDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.CloudSnapshotDataSourceModel
+	var plan models.CloudSnapshotDataSourceModel
+	tflog.Info(ctx, "Attempting to read cloud snapshots")
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.StorageGroup == nil {
+		resp.Diagnostics.AddError(
+			"Error getting the list of cloud snapshots",
+			"storage_group block is required",
+		)
+		return
+	}
+	sgName := plan.StorageGroup.Name.ValueString()
+
+	list, _, err := helper.GetStorageGroupCloudSnapshots(ctx, *d.client, sgName)
+	if err != nil {
+		errStr := constants.ReadCloudSnapshots + " with error: "
+		message := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error getting the list of cloud snapshots",
+			message,
+		)
+		return
+	}
+
+	var names []string
+	if plan.CloudSnapshotFilter != nil {
+		for _, name := range plan.CloudSnapshotFilter.Names {
+			names = append(names, name.ValueString())
+		}
+	}
+
+	state.CloudSnapshots = []models.CloudSnapshotDetail{}
+	for _, info := range list.CloudSnapshotInfos {
+		if len(names) > 0 && !helper.StringInSlice(info.GetSnapshotName(), names) {
+			continue
+		}
+		snapshot, _, err := helper.GetCloudSnapshot(ctx, *d.client, sgName, info.GetCloudSnapshotId())
+		if err != nil {
+			errStr := constants.ReadCloudSnapshots + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the cloud snapshot details",
+				message,
+			)
+			return
+		}
+		sourceVolumes, _, err := helper.GetStorageGroupCloudSnapshotSourceVolumes(ctx, *d.client, sgName, info.GetCloudSnapshotId())
+		if err != nil {
+			errStr := constants.ReadCloudSnapshots + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the cloud snapshot source volumes",
+				message,
+			)
+			return
+		}
+		var detail models.CloudSnapshotDetail
+		errState := helper.UpdateCloudSnapshotDatasourceState(ctx, snapshot, sourceVolumes, &detail)
+		if errState != nil {
+			errStr := constants.ReadCloudSnapshots + " with error: "
+			message := helper.GetErrorString(errState, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the cloud snapshot details",
+				message,
+			)
+			return
+		}
+		state.CloudSnapshots = append(state.CloudSnapshots, detail)
+	}
+	state.ID = types.StringValue("cloud-snapshot-datasource")
+	state.StorageGroup = plan.StorageGroup
+	state.CloudSnapshotFilter = plan.CloudSnapshotFilter
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
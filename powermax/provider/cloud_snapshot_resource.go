@@ -0,0 +1,442 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &cloudSnapshotResource{}
+	_ resource.ResourceWithConfigure   = &cloudSnapshotResource{}
+	_ resource.ResourceWithImportState = &cloudSnapshotResource{}
+)
+
+// NewCloudSnapshotResource is a helper function to simplify the provider implementation.
+func NewCloudSnapshotResource() resource.Resource {
+	return &cloudSnapshotResource{}
+}
+
+// cloudSnapshotResource is the resource implementation.
+type cloudSnapshotResource struct {
+	client *client.Client
+}
+
+// Metadata returns the resource type name.
+func (r *cloudSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_snapshot"
+}
+
+// Schema defines the schema for the resource.
+func (r *cloudSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for managing cloud snapshots of storage groups in PowerMax array. A cloud snapshot archives a snapshot of a storage group to a cloud provider, and can be restored to a new storage group once it has been archived.",
+		Description:         "Resource for managing cloud snapshots of storage groups in PowerMax array. A cloud snapshot archives a snapshot of a storage group to a cloud provider, and can be restored to a new storage group once it has been archived.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "The ID of the cloud snapshot.",
+				MarkdownDescription: "The ID of the cloud snapshot.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "The name of the cloud snapshot.",
+				MarkdownDescription: "The name of the cloud snapshot.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"cloud_provider_id": schema.StringAttribute{
+				Description:         "The name of the cloud provider where the snapshot will reside.",
+				MarkdownDescription: "The name of the cloud provider where the snapshot will reside.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"expiry_time_days": schema.Int64Attribute{
+				Description:         "The number of days the cloud snapshot is to live for. Can only be set on create, later changes are ignored with a warning as the provider cannot alter the expiry of an existing cloud snapshot.",
+				MarkdownDescription: "The number of days the cloud snapshot is to live for. Can only be set on create, later changes are ignored with a warning as the provider cannot alter the expiry of an existing cloud snapshot.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					createOnlyModifier{resourceName: "cloud snapshot", hint: "The expiry of an existing cloud snapshot cannot be altered by the provider."},
+				},
+			},
+			"restore": schema.SingleNestedAttribute{
+				Description:         "Restore the cloud snapshot to a new storage group. The cloud snapshot must be in the Archived state, so the restore can only be set once the snapshot has been created. The restore is performed each time the target storage group changes. (Update Supported)",
+				MarkdownDescription: "Restore the cloud snapshot to a new storage group. The cloud snapshot must be in the Archived state, so the restore can only be set once the snapshot has been created. The restore is performed each time the target storage group changes. (Update Supported)",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"target_storage_group": schema.StringAttribute{
+						Description:         "The name of the new storage group to restore the cloud snapshot to.",
+						MarkdownDescription: "The name of the new storage group to restore the cloud snapshot to.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"srp_id": schema.StringAttribute{
+						Description:         "The SRP of the new storage group. If not set the original SRP is used if it exists on the array, otherwise the default SRP.",
+						MarkdownDescription: "The SRP of the new storage group. If not set the original SRP is used if it exists on the array, otherwise the default SRP.",
+						Optional:            true,
+					},
+					"slo_id": schema.StringAttribute{
+						Description:         "The SLO of the new storage group. If not set the original SLO is used if it exists on the SRP, otherwise the default SLO.",
+						MarkdownDescription: "The SLO of the new storage group. If not set the original SLO is used if it exists on the SRP, otherwise the default SLO.",
+						Optional:            true,
+					},
+				},
+			},
+			"creation_date": schema.StringAttribute{
+				Description:         "The date time the cloud snapshot was created.",
+				MarkdownDescription: "The date time the cloud snapshot was created.",
+				Computed:            true,
+			},
+			"creation_date_timestamp": schema.Int64Attribute{
+				Description:         "The timestamp the cloud snapshot was created.",
+				MarkdownDescription: "The timestamp the cloud snapshot was created.",
+				Computed:            true,
+			},
+			"expiry_date": schema.StringAttribute{
+				Description:         "The date time the cloud snapshot is due to expire in the cloud.",
+				MarkdownDescription: "The date time the cloud snapshot is due to expire in the cloud.",
+				Computed:            true,
+			},
+			"expiry_date_timestamp": schema.Int64Attribute{
+				Description:         "The timestamp the cloud snapshot is due to expire in the cloud.",
+				MarkdownDescription: "The timestamp the cloud snapshot is due to expire in the cloud.",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				Description:         "The current state of the cloud snapshot.",
+				MarkdownDescription: "The current state of the cloud snapshot.",
+				Computed:            true,
+			},
+			"capacity_gb": schema.Float64Attribute{
+				Description:         "The total capacity of the cloud snapshot at creation time in GBs.",
+				MarkdownDescription: "The total capacity of the cloud snapshot at creation time in GBs.",
+				Computed:            true,
+			},
+			"protected_percent": schema.Int64Attribute{
+				Description:         "The progress percent of the cloud snapshot being archived up to the cloud.",
+				MarkdownDescription: "The progress percent of the cloud snapshot being archived up to the cloud.",
+				Computed:            true,
+			},
+			"number_of_volumes": schema.Int64Attribute{
+				Description:         "The number of source volumes in the cloud snapshot.",
+				MarkdownDescription: "The number of source volumes in the cloud snapshot.",
+				Computed:            true,
+			},
+			"encryption_enabled": schema.BoolAttribute{
+				Description:         "Whether encryption is enabled for the cloud snapshot.",
+				MarkdownDescription: "Whether encryption is enabled for the cloud snapshot.",
+				Computed:            true,
+			},
+			"compression_enabled": schema.BoolAttribute{
+				Description:         "Whether compression is enabled for the cloud snapshot.",
+				MarkdownDescription: "Whether compression is enabled for the cloud snapshot.",
+				Computed:            true,
+			},
+			"orphaned": schema.BoolAttribute{
+				Description:         "Whether the storage group the cloud snapshot was taken of no longer exists.",
+				MarkdownDescription: "Whether the storage group the cloud snapshot was taken of no longer exists.",
+				Computed:            true,
+			},
+			"recovered_storage_groups": schema.ListAttribute{
+				Description:         "The storage groups the cloud snapshot has been restored to.",
+				MarkdownDescription: "The storage groups the cloud snapshot has been restored to.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"storage_group": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description:         "Name of the storage group you would like to take a cloud snapshot of.",
+						MarkdownDescription: "Name of the storage group you would like to take a cloud snapshot of.",
+						Required:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cloudSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *cloudSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating cloud snapshot")
+	var plan models.CloudSnapshotResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.StorageGroup == nil || plan.StorageGroup.Name.ValueString() == "" {
+		resp.Diagnostics.AddError(
+			"Error creating cloud snapshot",
+			fmt.Sprintf("Could not create cloud snapshot %s with error: %s", plan.Name.ValueString(), "storage group name cannot be empty"),
+		)
+		return
+	}
+
+	if plan.Restore != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cloud snapshot",
+			fmt.Sprintf("Could not create cloud snapshot %s with error: %s", plan.Name.ValueString(), "restore can only be set once the cloud snapshot has been archived"),
+		)
+		return
+	}
+
+	snapshot, _, err := helper.CreateCloudSnapshot(ctx, *r.client, plan)
+	if err != nil {
+		errStr := constants.CreateCloudSnapshotDetailErrorMsg + plan.Name.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating cloud snapshot", msgStr,
+		)
+		return
+	}
+	tflog.Debug(ctx, "create cloud snapshot response", map[string]interface{}{
+		"snapshot": snapshot,
+	})
+
+	state := plan
+	// The expiry is only known when it is configured
+	if state.ExpiryTimeDays.IsUnknown() {
+		state.ExpiryTimeDays = types.Int64Null()
+	}
+	errState := helper.UpdateCloudSnapshotResourceState(ctx, snapshot, &state)
+	if errState != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cloud snapshot",
+			errState.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create cloud snapshot completed")
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *cloudSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading cloud snapshot")
+	var state models.CloudSnapshotResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, _, err := helper.GetCloudSnapshot(ctx, *r.client, state.StorageGroup.Name.ValueString(), state.ID.ValueString())
+	if err != nil {
+		errStr := constants.ReadCloudSnapshotDetailsErrorMsg + state.ID.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud snapshot", msgStr,
+		)
+		return
+	}
+
+	errState := helper.UpdateCloudSnapshotResourceState(ctx, snapshot, &state)
+	if errState != nil {
+		resp.Diagnostics.AddError(
+			"Error reading cloud snapshot",
+			errState.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "read cloud snapshot completed")
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Supported updates: restore.
+func (r *cloudSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating cloud snapshot")
+	var plan, state models.CloudSnapshotResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// prompt error on change in the cloud snapshot's storage group, name or cloud provider, as they can't be updated after the creation.
+	// Changes of the expiry are ignored at plan time.
+	if !plan.StorageGroup.Name.Equal(state.StorageGroup.Name) || !plan.Name.Equal(state.Name) ||
+		!plan.CloudProviderID.Equal(state.CloudProviderID) {
+		resp.Diagnostics.AddError(
+			"cloud snapshot's storage group, name or cloud_provider_id cannot be updated after creation.",
+			"unexpected error: cloud snapshot's storage group, name or cloud_provider_id change is not supported",
+		)
+		return
+	}
+
+	sgName := state.StorageGroup.Name.ValueString()
+	cloudSnapshotID := state.ID.ValueString()
+	if plan.Restore != nil && (state.Restore == nil || !plan.Restore.TargetStorageGroup.Equal(state.Restore.TargetStorageGroup)) {
+		tflog.Debug(ctx, fmt.Sprintf("restoring cloud snapshot %s to storage group %s", cloudSnapshotID, plan.Restore.TargetStorageGroup.ValueString()))
+		_, _, err := helper.RestoreCloudSnapshot(ctx, *r.client, sgName, cloudSnapshotID, *plan.Restore)
+		if err != nil {
+			errStr := constants.UpdateCloudSnapshotDetailsErrorMsg + cloudSnapshotID + " with error: "
+			msgStr := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error updating cloud snapshot", msgStr,
+			)
+			return
+		}
+	}
+
+	snapshot, _, err := helper.GetCloudSnapshot(ctx, *r.client, sgName, cloudSnapshotID)
+	if err != nil {
+		errStr := constants.ReadCloudSnapshotDetailsErrorMsg + cloudSnapshotID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud snapshot", msgStr,
+		)
+		return
+	}
+
+	errState := helper.UpdateCloudSnapshotResourceState(ctx, snapshot, &plan)
+	if errState != nil {
+		resp.Diagnostics.AddError(
+			"Error updating cloud snapshot",
+			errState.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "update cloud snapshot completed")
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *cloudSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting cloud snapshot")
+	var state models.CloudSnapshotResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := helper.DeleteCloudSnapshot(ctx, *r.client, state.StorageGroup.Name.ValueString(), state.ID.ValueString())
+	if err != nil {
+		errStr := constants.DeleteCloudSnapshotDetailsErrorMsg + state.ID.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error deleting cloud snapshot", msgStr,
+		)
+		return
+	}
+	tflog.Info(ctx, "delete cloud snapshot completed")
+}
+
+// ImportState imports the resource using the 'storage_group_name.cloud_snapshot_id' ID.
+func (r *cloudSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing cloud snapshot")
+	ids := strings.Split(req.ID, ".")
+	if len(ids) != 2 {
+		resp.Diagnostics.AddError(
+			"Error importing cloud snapshot",
+			"The import ID must be 'storage_group_name.cloud_snapshot_id'",
+		)
+		return
+	}
+
+	snapshot, _, err := helper.GetCloudSnapshot(ctx, *r.client, ids[0], ids[1])
+	if err != nil {
+		errStr := constants.ReadCloudSnapshotDetailsErrorMsg + ids[1] + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error importing cloud snapshot", msgStr,
+		)
+		return
+	}
+
+	state := models.CloudSnapshotResourceModel{
+		StorageGroup: &models.FilterTypeSnapshot{
+			Name: types.StringValue(ids[0]),
+		},
+		ExpiryTimeDays: types.Int64Null(),
+	}
+	errState := helper.UpdateCloudSnapshotResourceState(ctx, snapshot, &state)
+	if errState != nil {
+		resp.Diagnostics.AddError(
+			"Error importing cloud snapshot",
+			errState.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
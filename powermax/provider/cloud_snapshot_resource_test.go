@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCloudSnapshotResource(t *testing.T) {
+	var cloudSnapshotTerraformName = "powermax_cloud_snapshot.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + cloudSnapshotConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cloudSnapshotTerraformName, "name", "tfacc_cloud_snapshot"),
+					resource.TestCheckResourceAttr(cloudSnapshotTerraformName, "storage_group.name", "tfacc_sg_cloud_snapshot"),
+					resource.TestCheckResourceAttrSet(cloudSnapshotTerraformName, "expiry_date"),
+					resource.TestCheckResourceAttrSet(cloudSnapshotTerraformName, "state"),
+				),
+			},
+			// Changes of the expiry are ignored with a warning
+			{
+				Config: ProviderConfig + cloudSnapshotUpdateExpiryConfig,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cloudSnapshotTerraformName, "expiry_time_days", "1"),
+				),
+			},
+			// Update error on fields which cannot be changed
+			{
+				Config:      ProviderConfig + cloudSnapshotUpdateNameConfig,
+				ExpectError: regexp.MustCompile(`.*cannot be updated after creation*.`),
+			},
+			// Read test Error
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetCloudSnapshot).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + cloudSnapshotConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Restore Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.RestoreCloudSnapshot).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + cloudSnapshotRestoreConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccCloudSnapshotResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateCloudSnapshot).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + cloudSnapshotConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccCloudSnapshotResourceCreateWithRestoreError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + cloudSnapshotRestoreConfig,
+				ExpectError: regexp.MustCompile(`.*restore can only be set once the cloud snapshot has been archived*.`),
+			},
+		},
+	})
+}
+
+var cloudSnapshotConfig = `
+resource "powermax_cloud_snapshot" "test" {
+	storage_group {
+		name = "tfacc_sg_cloud_snapshot"
+	}
+	name = "tfacc_cloud_snapshot"
+	cloud_provider_id = "tfacc_cloud_provider"
+	expiry_time_days = 1
+}
+`
+
+var cloudSnapshotUpdateExpiryConfig = `
+resource "powermax_cloud_snapshot" "test" {
+	storage_group {
+		name = "tfacc_sg_cloud_snapshot"
+	}
+	name = "tfacc_cloud_snapshot"
+	cloud_provider_id = "tfacc_cloud_provider"
+	expiry_time_days = 2
+}
+`
+
+var cloudSnapshotUpdateNameConfig = `
+resource "powermax_cloud_snapshot" "test" {
+	storage_group {
+		name = "tfacc_sg_cloud_snapshot"
+	}
+	name = "tfacc_cloud_snapshot_renamed"
+	cloud_provider_id = "tfacc_cloud_provider"
+	expiry_time_days = 1
+}
+`
+
+var cloudSnapshotRestoreConfig = `
+resource "powermax_cloud_snapshot" "test" {
+	storage_group {
+		name = "tfacc_sg_cloud_snapshot"
+	}
+	name = "tfacc_cloud_snapshot"
+	cloud_provider_id = "tfacc_cloud_provider"
+	expiry_time_days = 1
+	restore = {
+		target_storage_group = "tfacc_sg_cloud_snapshot_restored"
+	}
+}
+`
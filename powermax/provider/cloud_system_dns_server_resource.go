@@ -0,0 +1,258 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &CloudSystemDNSServer{}
+	_ resource.ResourceWithConfigure   = &CloudSystemDNSServer{}
+	_ resource.ResourceWithImportState = &CloudSystemDNSServer{}
+)
+
+// NewCloudSystemDNSServer is a helper function to simplify the provider implementation.
+func NewCloudSystemDNSServer() resource.Resource {
+	return &CloudSystemDNSServer{}
+}
+
+// CloudSystemDNSServer defines the resource implementation.
+type CloudSystemDNSServer struct {
+	client *client.Client
+}
+
+// Schema Resource schema.
+func (r *CloudSystemDNSServer) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for managing the DNS servers of the cloud system in PowerMax array. The cloud system uses these DNS servers to resolve the endpoints of the cloud providers.",
+		Description:         "Resource for managing the DNS servers of the cloud system in PowerMax array. The cloud system uses these DNS servers to resolve the endpoints of the cloud providers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The ID of the DNS server.",
+				MarkdownDescription: "The ID of the DNS server.",
+			},
+			"ip_address": schema.StringAttribute{
+				Required:            true,
+				Description:         "The IP address of the DNS server. (Update Supported)",
+				MarkdownDescription: "The IP address of the DNS server. (Update Supported)",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// Metadata Resource metadata.
+func (r *CloudSystemDNSServer) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_system_dns_server"
+}
+
+// Configure CloudSystemDNSServer.
+func (r *CloudSystemDNSServer) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = pmaxClient
+}
+
+// Create CloudSystemDNSServer.
+func (r *CloudSystemDNSServer) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating cloud system DNS server")
+
+	var plan models.CloudSystemDNSServerResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dnsID := plan.IPAddress.ValueString()
+	_, _, err := helper.CreateCloudSystemDNSServer(ctx, *r.client, dnsID)
+	if err != nil {
+		errStr := constants.CreateCloudSystemDNSServerDetailErrorMsg + dnsID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating cloud system DNS server", msgStr,
+		)
+		return
+	}
+
+	dnsResponse, _, err := helper.GetCloudSystemDNSServer(ctx, *r.client, dnsID)
+	if err != nil {
+		errStr := constants.ReadCloudSystemDNSServerDetailsErrorMsg + dnsID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating cloud system DNS server", msgStr,
+		)
+		return
+	}
+
+	dnsState := models.CloudSystemDNSServerResourceModel{}
+	helper.UpdateCloudSystemDNSServerState(&dnsState, dnsResponse)
+
+	diags = resp.State.Set(ctx, dnsState)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create cloud system DNS server completed")
+}
+
+// Read CloudSystemDNSServer.
+func (r *CloudSystemDNSServer) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading cloud system DNS server")
+	var dnsState models.CloudSystemDNSServerResourceModel
+	diags := req.State.Get(ctx, &dnsState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dnsID := dnsState.ID.ValueString()
+	tflog.Debug(ctx, "getting cloud system DNS server by ID", map[string]interface{}{
+		"symmetrixID": r.client.SymmetrixID,
+		"dnsID":       dnsID,
+	})
+	dnsResponse, _, err := helper.GetCloudSystemDNSServer(ctx, *r.client, dnsID)
+	if err != nil {
+		errStr := constants.ReadCloudSystemDNSServerDetailsErrorMsg + dnsID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud system DNS server", msgStr,
+		)
+		return
+	}
+
+	helper.UpdateCloudSystemDNSServerState(&dnsState, dnsResponse)
+
+	diags = resp.State.Set(ctx, dnsState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "read cloud system DNS server completed")
+}
+
+// Update CloudSystemDNSServer
+// Supported updates: ip_address.
+func (r *CloudSystemDNSServer) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating cloud system DNS server")
+	var dnsPlan, dnsState models.CloudSystemDNSServerResourceModel
+	diags := req.State.Get(ctx, &dnsState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &dnsPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dnsID := dnsState.ID.ValueString()
+	_, _, err := helper.ModifyCloudSystemDNSServer(ctx, *r.client, dnsID, dnsPlan.IPAddress.ValueString())
+	if err != nil {
+		errStr := constants.UpdateCloudSystemDNSServerDetailsErrorMsg + dnsID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error updating cloud system DNS server", msgStr,
+		)
+		return
+	}
+
+	// The DNS server is identified by its IP address
+	dnsID = dnsPlan.IPAddress.ValueString()
+	dnsResponse, _, err := helper.GetCloudSystemDNSServer(ctx, *r.client, dnsID)
+	if err != nil {
+		errStr := constants.ReadCloudSystemDNSServerDetailsErrorMsg + dnsID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud system DNS server", msgStr,
+		)
+		return
+	}
+
+	helper.UpdateCloudSystemDNSServerState(&dnsState, dnsResponse)
+
+	diags = resp.State.Set(ctx, dnsState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "update cloud system DNS server completed")
+}
+
+// Delete CloudSystemDNSServer.
+func (r *CloudSystemDNSServer) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting cloud system DNS server")
+	var dnsState models.CloudSystemDNSServerResourceModel
+	diags := req.State.Get(ctx, &dnsState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	dnsID := dnsState.ID.ValueString()
+	tflog.Debug(ctx, "calling delete cloud system DNS server on pmax client", map[string]interface{}{
+		"symmetrixID": r.client.SymmetrixID,
+		"dnsID":       dnsID,
+	})
+
+	_, err := helper.DeleteCloudSystemDNSServer(ctx, *r.client, dnsID)
+	if err != nil {
+		errStr := constants.DeleteCloudSystemDNSServerDetailsErrorMsg + dnsID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error deleting cloud system DNS server", msgStr,
+		)
+	}
+	tflog.Info(ctx, "delete cloud system DNS server completed")
+}
+
+// ImportState import resource.
+func (r *CloudSystemDNSServer) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing cloud system DNS server state")
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var createCloudSystemDNSServerConfig = `
+resource "powermax_cloud_system_dns_server" "test_dns_server" {
+	ip_address = "10.0.0.53"
+}
+`
+var updateCloudSystemDNSServerConfig = `
+resource "powermax_cloud_system_dns_server" "test_dns_server" {
+	# This will be updated
+	ip_address = "10.0.0.54"
+}
+`
+
+func TestAccCloudSystemDNSServerResource(t *testing.T) {
+	var dnsServerTerraformName = "powermax_cloud_system_dns_server.test_dns_server"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + createCloudSystemDNSServerConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dnsServerTerraformName, "ip_address", "10.0.0.53"),
+					resource.TestCheckResourceAttr(dnsServerTerraformName, "id", "10.0.0.53"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      dnsServerTerraformName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: ProviderConfig + updateCloudSystemDNSServerConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dnsServerTerraformName, "ip_address", "10.0.0.54"),
+					resource.TestCheckResourceAttr(dnsServerTerraformName, "id", "10.0.0.54"),
+				),
+			},
+			// Read test Error
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetCloudSystemDNSServer).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + updateCloudSystemDNSServerConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Modify Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.ModifyCloudSystemDNSServer).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudSystemDNSServerConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// auto checks delete to clean up the test
+		},
+	})
+}
+
+func TestAccCloudSystemDNSServerResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateCloudSystemDNSServer).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudSystemDNSServerConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
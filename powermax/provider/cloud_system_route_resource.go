@@ -0,0 +1,294 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &CloudSystemRoute{}
+	_ resource.ResourceWithConfigure   = &CloudSystemRoute{}
+	_ resource.ResourceWithImportState = &CloudSystemRoute{}
+)
+
+// NewCloudSystemRoute is a helper function to simplify the provider implementation.
+func NewCloudSystemRoute() resource.Resource {
+	return &CloudSystemRoute{}
+}
+
+// CloudSystemRoute defines the resource implementation.
+type CloudSystemRoute struct {
+	client *client.Client
+}
+
+// Schema Resource schema.
+func (r *CloudSystemRoute) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for managing the static routes of the cloud system in PowerMax array. The cloud system uses these routes to reach the cloud providers.",
+		Description:         "Resource for managing the static routes of the cloud system in PowerMax array. The cloud system uses these routes to reach the cloud providers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The ID of the route.",
+				MarkdownDescription: "The ID of the route.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"destination_ip_address": schema.StringAttribute{
+				Required:            true,
+				Description:         "The destination IP address of the route. (Update Supported)",
+				MarkdownDescription: "The destination IP address of the route. (Update Supported)",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				Required:            true,
+				Description:         "The netmask prefix number of the route. (Update Supported)",
+				MarkdownDescription: "The netmask prefix number of the route. (Update Supported)",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9]{1,3}$`),
+						"must be a netmask prefix number",
+					),
+				},
+			},
+			"gateway_ip_address": schema.StringAttribute{
+				Required:            true,
+				Description:         "The gateway IP address of the route. (Update Supported)",
+				MarkdownDescription: "The gateway IP address of the route. (Update Supported)",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"interface_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "The ID of the interface the route is associated with. (Update Supported)",
+				MarkdownDescription: "The ID of the interface the route is associated with. (Update Supported)",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Metadata Resource metadata.
+func (r *CloudSystemRoute) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_system_route"
+}
+
+// Configure CloudSystemRoute.
+func (r *CloudSystemRoute) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = pmaxClient
+}
+
+// Create CloudSystemRoute.
+func (r *CloudSystemRoute) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating cloud system route")
+
+	var plan models.CloudSystemRouteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeName := plan.DestinationIPAddress.ValueString() + "/" + plan.Prefix.ValueString()
+	_, _, err := helper.CreateCloudSystemRoute(ctx, *r.client, plan)
+	if err != nil {
+		errStr := constants.CreateCloudSystemRouteDetailErrorMsg + routeName + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating cloud system route", msgStr,
+		)
+		return
+	}
+
+	// The create response does not carry the route ID, look the new route up instead
+	routeResponse, err := helper.FindCloudSystemRoute(ctx, *r.client, plan)
+	if err != nil {
+		errStr := constants.ReadCloudSystemRouteDetailsErrorMsg + routeName + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating cloud system route", msgStr,
+		)
+		return
+	}
+
+	routeState := models.CloudSystemRouteResourceModel{}
+	helper.UpdateCloudSystemRouteState(&routeState, routeResponse)
+
+	diags = resp.State.Set(ctx, routeState)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create cloud system route completed")
+}
+
+// Read CloudSystemRoute.
+func (r *CloudSystemRoute) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading cloud system route")
+	var routeState models.CloudSystemRouteResourceModel
+	diags := req.State.Get(ctx, &routeState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeID := routeState.ID.ValueString()
+	tflog.Debug(ctx, "getting cloud system route by ID", map[string]interface{}{
+		"symmetrixID": r.client.SymmetrixID,
+		"routeID":     routeID,
+	})
+	routeResponse, _, err := helper.GetCloudSystemRoute(ctx, *r.client, routeID)
+	if err != nil {
+		errStr := constants.ReadCloudSystemRouteDetailsErrorMsg + routeID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud system route", msgStr,
+		)
+		return
+	}
+
+	helper.UpdateCloudSystemRouteState(&routeState, routeResponse)
+
+	diags = resp.State.Set(ctx, routeState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "read cloud system route completed")
+}
+
+// Update CloudSystemRoute
+// Supported updates: destination_ip_address, prefix, gateway_ip_address, interface_id.
+func (r *CloudSystemRoute) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating cloud system route")
+	var routePlan, routeState models.CloudSystemRouteResourceModel
+	diags := req.State.Get(ctx, &routeState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &routePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeID := routeState.ID.ValueString()
+	_, _, err := helper.ModifyCloudSystemRoute(ctx, *r.client, routeID, routePlan)
+	if err != nil {
+		errStr := constants.UpdateCloudSystemRouteDetailsErrorMsg + routeID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error updating cloud system route", msgStr,
+		)
+		return
+	}
+
+	routeResponse, _, err := helper.GetCloudSystemRoute(ctx, *r.client, routeID)
+	if err != nil {
+		errStr := constants.ReadCloudSystemRouteDetailsErrorMsg + routeID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud system route", msgStr,
+		)
+		return
+	}
+
+	helper.UpdateCloudSystemRouteState(&routeState, routeResponse)
+
+	diags = resp.State.Set(ctx, routeState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "update cloud system route completed")
+}
+
+// Delete CloudSystemRoute.
+func (r *CloudSystemRoute) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting cloud system route")
+	var routeState models.CloudSystemRouteResourceModel
+	diags := req.State.Get(ctx, &routeState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	routeID := routeState.ID.ValueString()
+	tflog.Debug(ctx, "calling delete cloud system route on pmax client", map[string]interface{}{
+		"symmetrixID": r.client.SymmetrixID,
+		"routeID":     routeID,
+	})
+
+	_, err := helper.DeleteCloudSystemRoute(ctx, *r.client, routeID)
+	if err != nil {
+		errStr := constants.DeleteCloudSystemRouteDetailsErrorMsg + routeID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error deleting cloud system route", msgStr,
+		)
+	}
+	tflog.Info(ctx, "delete cloud system route completed")
+}
+
+// ImportState import resource.
+func (r *CloudSystemRoute) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing cloud system route state")
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
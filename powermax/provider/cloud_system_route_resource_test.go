@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var createCloudSystemRouteConfig = `
+resource "powermax_cloud_system_route" "test_route" {
+	destination_ip_address = "10.10.0.0"
+	prefix = "16"
+	gateway_ip_address = "10.0.0.1"
+}
+`
+var updateCloudSystemRouteConfig = `
+resource "powermax_cloud_system_route" "test_route" {
+	destination_ip_address = "10.10.0.0"
+	# This will be updated
+	prefix = "24"
+	gateway_ip_address = "10.0.0.1"
+}
+`
+
+func TestAccCloudSystemRouteResource(t *testing.T) {
+	var routeTerraformName = "powermax_cloud_system_route.test_route"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + createCloudSystemRouteConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(routeTerraformName, "destination_ip_address", "10.10.0.0"),
+					resource.TestCheckResourceAttr(routeTerraformName, "prefix", "16"),
+					resource.TestCheckResourceAttrSet(routeTerraformName, "id"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      routeTerraformName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: ProviderConfig + updateCloudSystemRouteConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(routeTerraformName, "prefix", "24"),
+				),
+			},
+			// Read test Error
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetCloudSystemRoute).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + updateCloudSystemRouteConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Modify Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.ModifyCloudSystemRoute).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudSystemRouteConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// auto checks delete to clean up the test
+		},
+	})
+}
+
+func TestAccCloudSystemRouteResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateCloudSystemRoute).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudSystemRouteConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccCloudSystemRouteResourceLookupError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.ListCloudSystemRoutes).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudSystemRouteConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
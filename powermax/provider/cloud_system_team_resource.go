@@ -0,0 +1,308 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &CloudSystemTeam{}
+	_ resource.ResourceWithConfigure   = &CloudSystemTeam{}
+	_ resource.ResourceWithImportState = &CloudSystemTeam{}
+)
+
+// NewCloudSystemTeam is a helper function to simplify the provider implementation.
+func NewCloudSystemTeam() resource.Resource {
+	return &CloudSystemTeam{}
+}
+
+// CloudSystemTeam defines the resource implementation.
+type CloudSystemTeam struct {
+	client *client.Client
+}
+
+// Schema Resource schema.
+func (r *CloudSystemTeam) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for managing the NIC teams of the cloud system in PowerMax array. A NIC team bonds several interfaces of the cloud system behind a single IP address.",
+		Description:         "Resource for managing the NIC teams of the cloud system in PowerMax array. A NIC team bonds several interfaces of the cloud system behind a single IP address.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The ID of the NIC team.",
+				MarkdownDescription: "The ID of the NIC team.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Required:            true,
+				Description:         "The IP address of the NIC team. (Update Supported)",
+				MarkdownDescription: "The IP address of the NIC team. (Update Supported)",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"prefix": schema.Int64Attribute{
+				Required:            true,
+				Description:         "The netmask prefix number of the NIC team. (Update Supported)",
+				MarkdownDescription: "The netmask prefix number of the NIC team. (Update Supported)",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 128),
+				},
+			},
+			"interface_ids": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				Description:         "The IDs of the interfaces in the NIC team. (Update Supported)",
+				MarkdownDescription: "The IDs of the interfaces in the NIC team. (Update Supported)",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The state of the NIC team.",
+				MarkdownDescription: "The state of the NIC team.",
+			},
+			"mac_address": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The MAC address of the default interface in the NIC team.",
+				MarkdownDescription: "The MAC address of the default interface in the NIC team.",
+			},
+			"mtu": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The MTU of the default interface in the NIC team.",
+				MarkdownDescription: "The MTU of the default interface in the NIC team.",
+			},
+			"num_of_routes": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The number of routes associated with the default interface in the NIC team.",
+				MarkdownDescription: "The number of routes associated with the default interface in the NIC team.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The type of the default interface in the NIC team.",
+				MarkdownDescription: "The type of the default interface in the NIC team.",
+			},
+			"ip_source": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The IP source of the default interface in the NIC team.",
+				MarkdownDescription: "The IP source of the default interface in the NIC team.",
+			},
+		},
+	}
+}
+
+// Metadata Resource metadata.
+func (r *CloudSystemTeam) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_system_team"
+}
+
+// Configure CloudSystemTeam.
+func (r *CloudSystemTeam) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = pmaxClient
+}
+
+// Create CloudSystemTeam.
+func (r *CloudSystemTeam) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating cloud system team")
+
+	var plan models.CloudSystemTeamResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	interfaceIDs := make([]string, len(plan.InterfaceIDs.Elements()))
+	resp.Diagnostics.Append(plan.InterfaceIDs.ElementsAs(ctx, &interfaceIDs, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamResponse, _, err := helper.CreateCloudSystemTeam(ctx, *r.client, plan, interfaceIDs)
+	if err != nil {
+		errStr := constants.CreateCloudSystemTeamDetailErrorMsg + plan.IPAddress.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating cloud system team", msgStr,
+		)
+		return
+	}
+	tflog.Debug(ctx, "create cloud system team response", map[string]interface{}{
+		"teamResponse": teamResponse,
+	})
+
+	teamState := plan
+	helper.UpdateCloudSystemTeamState(&teamState, teamResponse)
+
+	diags = resp.State.Set(ctx, teamState)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create cloud system team completed")
+}
+
+// Read CloudSystemTeam.
+func (r *CloudSystemTeam) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading cloud system team")
+	var teamState models.CloudSystemTeamResourceModel
+	diags := req.State.Get(ctx, &teamState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamID := teamState.ID.ValueString()
+	tflog.Debug(ctx, "getting cloud system team by ID", map[string]interface{}{
+		"symmetrixID": r.client.SymmetrixID,
+		"teamID":      teamID,
+	})
+	teamResponse, _, err := helper.GetCloudSystemTeam(ctx, *r.client, teamID)
+	if err != nil {
+		errStr := constants.ReadCloudSystemTeamDetailsErrorMsg + teamID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading cloud system team", msgStr,
+		)
+		return
+	}
+
+	helper.UpdateCloudSystemTeamState(&teamState, teamResponse)
+
+	diags = resp.State.Set(ctx, teamState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "read cloud system team completed")
+}
+
+// Update CloudSystemTeam
+// Supported updates: ip_address, prefix, interface_ids.
+func (r *CloudSystemTeam) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating cloud system team")
+	var teamPlan, teamState models.CloudSystemTeamResourceModel
+	diags := req.State.Get(ctx, &teamState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &teamPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	interfaceIDs := make([]string, len(teamPlan.InterfaceIDs.Elements()))
+	resp.Diagnostics.Append(teamPlan.InterfaceIDs.ElementsAs(ctx, &interfaceIDs, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamID := teamState.ID.ValueString()
+	teamResponse, _, err := helper.ModifyCloudSystemTeam(ctx, *r.client, teamID, teamPlan, interfaceIDs)
+	if err != nil {
+		errStr := constants.UpdateCloudSystemTeamDetailsErrorMsg + teamID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error updating cloud system team", msgStr,
+		)
+		return
+	}
+
+	teamState = teamPlan
+	helper.UpdateCloudSystemTeamState(&teamState, teamResponse)
+
+	diags = resp.State.Set(ctx, teamState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "update cloud system team completed")
+}
+
+// Delete CloudSystemTeam.
+func (r *CloudSystemTeam) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting cloud system team")
+	var teamState models.CloudSystemTeamResourceModel
+	diags := req.State.Get(ctx, &teamState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	teamID := teamState.ID.ValueString()
+	tflog.Debug(ctx, "calling delete cloud system team on pmax client", map[string]interface{}{
+		"symmetrixID": r.client.SymmetrixID,
+		"teamID":      teamID,
+	})
+
+	_, err := helper.DeleteCloudSystemTeam(ctx, *r.client, teamID)
+	if err != nil {
+		errStr := constants.DeleteCloudSystemTeamDetailsErrorMsg + teamID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error deleting cloud system team", msgStr,
+		)
+	}
+	tflog.Info(ctx, "delete cloud system team completed")
+}
+
+// ImportState import resource.
+// The interfaces of the team are not returned by the array and have to be set in the configuration after the import.
+func (r *CloudSystemTeam) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing cloud system team state")
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var createCloudSystemTeamConfig = `
+resource "powermax_cloud_system_team" "test_team" {
+	ip_address = "10.0.1.10"
+	prefix = 24
+	interface_ids = ["eth2", "eth3"]
+}
+`
+var updateCloudSystemTeamConfig = `
+resource "powermax_cloud_system_team" "test_team" {
+	# This will be updated
+	ip_address = "10.0.1.11"
+	prefix = 24
+	interface_ids = ["eth2", "eth3"]
+}
+`
+
+func TestAccCloudSystemTeamResource(t *testing.T) {
+	var teamTerraformName = "powermax_cloud_system_team.test_team"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + createCloudSystemTeamConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(teamTerraformName, "ip_address", "10.0.1.10"),
+					resource.TestCheckResourceAttr(teamTerraformName, "prefix", "24"),
+					resource.TestCheckResourceAttr(teamTerraformName, "interface_ids.#", "2"),
+					resource.TestCheckResourceAttrSet(teamTerraformName, "id"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:            teamTerraformName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"interface_ids"},
+			},
+			// Update testing
+			{
+				Config: ProviderConfig + updateCloudSystemTeamConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(teamTerraformName, "ip_address", "10.0.1.11"),
+				),
+			},
+			// Read test Error
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetCloudSystemTeam).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + updateCloudSystemTeamConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Modify Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.ModifyCloudSystemTeam).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudSystemTeamConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// auto checks delete to clean up the test
+		},
+	})
+}
+
+func TestAccCloudSystemTeamResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateCloudSystemTeam).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createCloudSystemTeamConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
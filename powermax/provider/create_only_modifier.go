@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// createOnlyModifier keeps the state value of an attribute which is only used when the resource is created,
+// for changes which cannot be applied in place and should not recreate the resource, and warns that the change is ignored.
+type createOnlyModifier struct {
+	// resourceName names the resource in the warning
+	resourceName string
+	// hint tells how to get the change done instead
+	hint string
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m createOnlyModifier) Description(_ context.Context) string {
+	return "Only used on create, later changes are ignored."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m createOnlyModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyList keeps the state value of a create only list attribute.
+func (m createOnlyModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if !req.PlanValue.IsUnknown() && !req.PlanValue.Equal(req.StateValue) {
+		m.addWarning(req.Path, &resp.Diagnostics)
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// PlanModifyBool keeps the state value of a create only bool attribute.
+func (m createOnlyModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if !req.PlanValue.IsUnknown() && !req.PlanValue.Equal(req.StateValue) {
+		m.addWarning(req.Path, &resp.Diagnostics)
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// PlanModifyInt64 keeps the state value of a create only int64 attribute.
+func (m createOnlyModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if !req.PlanValue.IsUnknown() && !req.PlanValue.Equal(req.StateValue) {
+		m.addWarning(req.Path, &resp.Diagnostics)
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// addWarning warns that the change of a create only attribute is ignored.
+func (m createOnlyModifier) addWarning(attributePath path.Path, diags *diag.Diagnostics) {
+	diags.AddAttributeWarning(
+		attributePath,
+		"Change ignored",
+		fmt.Sprintf("%s is only used when the %s is created, the change is ignored. %s", attributePath, m.resourceName, m.hint),
+	)
+}
@@ -0,0 +1,242 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &cuImageDataSource{}
+	_ datasource.DataSourceWithConfigure = &cuImageDataSource{}
+)
+
+// NewCuImageDataSource is a helper function to simplify the provider implementation.
+func NewCuImageDataSource() datasource.DataSource {
+	return &cuImageDataSource{}
+}
+
+// cuImageDataSource is the data source implementation.
+type cuImageDataSource struct {
+	client *client.Client
+}
+
+func (d *cuImageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cu_image"
+}
+
+func (d *cuImageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for reading the CU images of the FICON splits of a PowerMax array.",
+		Description:         "Data source for reading the CU images of the FICON splits of a PowerMax array.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"cu_images": schema.ListNestedAttribute{
+				Description:         "List of CU images",
+				MarkdownDescription: "List of CU images",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"split_id": schema.StringAttribute{
+							Description:         "The ID of the split of the CU image.",
+							MarkdownDescription: "The ID of the split of the CU image.",
+							Computed:            true,
+						},
+						"ssid": schema.StringAttribute{
+							Description:         "The SSID of the CU image.",
+							MarkdownDescription: "The SSID of the CU image.",
+							Computed:            true,
+						},
+						"image_number": schema.StringAttribute{
+							Description:         "The number of the CU image.",
+							MarkdownDescription: "The number of the CU image.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "The status of the CU image.",
+							MarkdownDescription: "The status of the CU image.",
+							Computed:            true,
+						},
+						"num_mapped_volumes": schema.Int64Attribute{
+							Description:         "The number of volumes mapped to the CU image.",
+							MarkdownDescription: "The number of volumes mapped to the CU image.",
+							Computed:            true,
+						},
+						"num_storage_groups": schema.Int64Attribute{
+							Description:         "The number of storage groups with volumes mapped to the CU image.",
+							MarkdownDescription: "The number of storage groups with volumes mapped to the CU image.",
+							Computed:            true,
+						},
+						"num_base_addresses": schema.Int64Attribute{
+							Description:         "The number of base addresses of the CU image.",
+							MarkdownDescription: "The number of base addresses of the CU image.",
+							Computed:            true,
+						},
+						"num_available_base_addresses": schema.Int64Attribute{
+							Description:         "The number of base addresses still available on the CU image.",
+							MarkdownDescription: "The number of base addresses still available on the CU image.",
+							Computed:            true,
+						},
+						"next_available_base_address": schema.StringAttribute{
+							Description:         "The next base address available for a volume mapping.",
+							MarkdownDescription: "The next base address available for a volume mapping.",
+							Computed:            true,
+						},
+						"num_aliases": schema.Int64Attribute{
+							Description:         "The number of aliases of the CU image.",
+							MarkdownDescription: "The number of aliases of the CU image.",
+							Computed:            true,
+						},
+						"start_alias_address": schema.StringAttribute{
+							Description:         "The start address of the alias range, empty when no range is assigned.",
+							MarkdownDescription: "The start address of the alias range, empty when no range is assigned.",
+							Computed:            true,
+						},
+						"end_alias_address": schema.StringAttribute{
+							Description:         "The end address of the alias range, empty when no range is assigned.",
+							MarkdownDescription: "The end address of the alias range, empty when no range is assigned.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"split_ids": schema.SetAttribute{
+						Description:         "The IDs of the splits to list the CU images of, all the splits when not set.",
+						MarkdownDescription: "The IDs of the splits to list the CU images of, all the splits when not set.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"ssids": schema.SetAttribute{
+						Description:         "The SSIDs of the CU images to list.",
+						MarkdownDescription: "The SSIDs of the CU images to list.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *cuImageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider is not config
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Config Failure",
+			fmt.Sprintf("Expected client, %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *cuImageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.CuImageDataSourceModel
+	var plan models.CuImageDataSourceModel
+	tflog.Info(ctx, "Attempting to read CU images")
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var splitIDs, ssids []string
+	if plan.CuImageFilter != nil {
+		for _, id := range plan.CuImageFilter.SplitIDs {
+			splitIDs = append(splitIDs, id.ValueString())
+		}
+		for _, ssid := range plan.CuImageFilter.SSIDs {
+			ssids = append(ssids, ssid.ValueString())
+		}
+	}
+	if len(splitIDs) == 0 {
+		list, _, err := helper.ListSplits(ctx, *d.client)
+		if err != nil {
+			errStr := constants.ReadCuImages + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the list of splits",
+				message,
+			)
+			return
+		}
+		splitIDs = list.SplitId
+	}
+
+	state.CuImages = []models.CuImageDetailModel{}
+	for _, splitID := range splitIDs {
+		list, _, err := helper.ListCUImages(ctx, *d.client, splitID)
+		if err != nil {
+			errStr := constants.ReadCuImages + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the list of CU images",
+				message,
+			)
+			return
+		}
+		for _, ssid := range list.CuImageSSID {
+			if len(ssids) > 0 && !helper.StringInSlice(ssid, ssids) {
+				continue
+			}
+			cuImage, _, err := helper.GetCUImage(ctx, *d.client, splitID, ssid)
+			if err != nil {
+				errStr := constants.ReadCuImages + " with error: "
+				message := helper.GetErrorString(err, errStr)
+				resp.Diagnostics.AddError(
+					"Error getting the CU image details",
+					message,
+				)
+				return
+			}
+			var detail models.CuImageDetailModel
+			helper.UpdateCuImageDetailState(&detail, splitID, cuImage)
+			state.CuImages = append(state.CuImages, detail)
+		}
+	}
+	state.ID = types.StringValue("cu-image-datasource")
+	state.CuImageFilter = plan.CuImageFilter
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCuImageDataSource(t *testing.T) {
+	var cuImageTerraformName = "data.powermax_cu_image.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + cuImageDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(cuImageTerraformName, "cu_images.#"),
+				),
+			},
+			{
+				Config: ProviderConfig + cuImageFilterDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cuImageTerraformName, "cu_images.#", "1"),
+					resource.TestCheckResourceAttr(cuImageTerraformName, "cu_images.0.ssid", "F0A0"),
+					resource.TestCheckResourceAttr(cuImageTerraformName, "cu_images.0.split_id", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCuImageDataSourceListError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.ListCUImages).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + cuImageDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+var cuImageDatasourceConfig = `
+data "powermax_cu_image" "test" {
+}
+`
+
+var cuImageFilterDatasourceConfig = cuImageConfig + `
+data "powermax_cu_image" "test" {
+	filter {
+		split_ids = [powermax_cu_image.test.split_id]
+		ssids     = [powermax_cu_image.test.cu_image_ssid]
+	}
+}
+`
@@ -0,0 +1,409 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &cuImageResource{}
+	_ resource.ResourceWithConfigure   = &cuImageResource{}
+	_ resource.ResourceWithImportState = &cuImageResource{}
+)
+
+// NewCuImageResource is a helper function to simplify the provider implementation.
+func NewCuImageResource() resource.Resource {
+	return &cuImageResource{}
+}
+
+// cuImageResource is the resource implementation.
+type cuImageResource struct {
+	client *client.Client
+}
+
+// Metadata returns the resource type name.
+func (r *cuImageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cu_image"
+}
+
+// Schema defines the schema for the resource.
+func (r *cuImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for managing CU images of a FICON split in PowerMax array. A CU image is created with CKD volumes mapped to it, and the array removes it when its last volume is unmapped, which is what deleting this resource does.",
+		Description:         "Resource for managing CU images of a FICON split in PowerMax array. A CU image is created with CKD volumes mapped to it, and the array removes it when its last volume is unmapped, which is what deleting this resource does.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "The ID of the CU image, in the format 'split_id.cu_image_ssid'.",
+				MarkdownDescription: "The ID of the CU image, in the format 'split_id.cu_image_ssid'.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"split_id": schema.StringAttribute{
+				Description:         "The ID of the FICON split to create the CU image under.",
+				MarkdownDescription: "The ID of the FICON split to create the CU image under.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cu_image_ssid": schema.StringAttribute{
+				Description:         "The SSID of the CU image.",
+				MarkdownDescription: "The SSID of the CU image.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cu_image_number": schema.StringAttribute{
+				Description:         "The number of the CU image.",
+				MarkdownDescription: "The number of the CU image.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"start_base_address": schema.StringAttribute{
+				Description:         "The starting base address of the volumes mapped when the CU image is created. Volumes added later are mapped from the next available base address.",
+				MarkdownDescription: "The starting base address of the volumes mapped when the CU image is created. Volumes added later are mapped from the next available base address.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"volume_ids": schema.SetAttribute{
+				Description:         "The IDs of the CKD volumes mapped to the CU image. (Update Supported)",
+				MarkdownDescription: "The IDs of the CKD volumes mapped to the CU image. (Update Supported)",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"start_alias_address": schema.StringAttribute{
+				Description:         "The start address of the alias range of the CU image, in hex. (Update Supported)",
+				MarkdownDescription: "The start address of the alias range of the CU image, in hex. (Update Supported)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("end_alias_address")),
+				},
+			},
+			"end_alias_address": schema.StringAttribute{
+				Description:         "The end address of the alias range of the CU image, in hex. (Update Supported)",
+				MarkdownDescription: "The end address of the alias range of the CU image, in hex. (Update Supported)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("start_alias_address")),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description:         "The status of the CU image.",
+				MarkdownDescription: "The status of the CU image.",
+				Computed:            true,
+			},
+			"num_mapped_volumes": schema.Int64Attribute{
+				Description:         "The number of volumes mapped to the CU image.",
+				MarkdownDescription: "The number of volumes mapped to the CU image.",
+				Computed:            true,
+			},
+			"num_storage_groups": schema.Int64Attribute{
+				Description:         "The number of storage groups with volumes mapped to the CU image.",
+				MarkdownDescription: "The number of storage groups with volumes mapped to the CU image.",
+				Computed:            true,
+			},
+			"num_base_addresses": schema.Int64Attribute{
+				Description:         "The number of base addresses of the CU image.",
+				MarkdownDescription: "The number of base addresses of the CU image.",
+				Computed:            true,
+			},
+			"num_available_base_addresses": schema.Int64Attribute{
+				Description:         "The number of base addresses still available on the CU image.",
+				MarkdownDescription: "The number of base addresses still available on the CU image.",
+				Computed:            true,
+			},
+			"next_available_base_address": schema.StringAttribute{
+				Description:         "The next base address available for a volume mapping.",
+				MarkdownDescription: "The next base address available for a volume mapping.",
+				Computed:            true,
+			},
+			"num_aliases": schema.Int64Attribute{
+				Description:         "The number of aliases of the CU image.",
+				MarkdownDescription: "The number of aliases of the CU image.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cuImageResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// readCuImage reads the CU image and the volumes mapped to it into the state.
+func (r *cuImageResource) readCuImage(ctx context.Context, state *models.CuImageResourceModel) (*http.Response, error) {
+	splitID := state.SplitID.ValueString()
+	ssid := state.CuImageSSID.ValueString()
+	cuImage, resp, err := helper.GetCUImage(ctx, *r.client, splitID, ssid)
+	if err != nil {
+		return resp, err
+	}
+	volumeIDs, err := helper.GetCUImageVolumeIDs(ctx, *r.client, ssid)
+	if err != nil {
+		return nil, err
+	}
+	diags := helper.UpdateCuImageResourceState(ctx, state, splitID, cuImage, volumeIDs)
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not set the volumes of CU image %s", ssid)
+	}
+	return nil, nil
+}
+
+// Create creates the CU image with the volumes mapped to it and assigns the alias range.
+func (r *cuImageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating CU image")
+	var plan models.CuImageResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var volumeIDs []string
+	resp.Diagnostics.Append(plan.VolumeIDs.ElementsAs(ctx, &volumeIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	splitID := plan.SplitID.ValueString()
+	ssid := plan.CuImageSSID.ValueString()
+	errStr := constants.CreateCuImageDetailErrorMsg + ssid + " with error: "
+	_, _, err := helper.CreateCUImage(ctx, *r.client, plan, volumeIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating CU image", helper.GetErrorString(err, errStr))
+		return
+	}
+
+	if !plan.StartAliasAddress.IsNull() {
+		err = helper.AssignCUImageAliasRange(ctx, *r.client, splitID, ssid, plan.StartAliasAddress.ValueString(), plan.EndAliasAddress.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating CU image", helper.GetErrorString(err, errStr))
+			// Fall through so that the CU image is saved in the state and can be destroyed
+		}
+	}
+
+	state := plan
+	_, err = r.readCuImage(ctx, &state)
+	if err != nil {
+		errStr := constants.ReadCuImageDetailErrorMsg + ssid + " with error: "
+		resp.Diagnostics.AddError("Error reading CU image", helper.GetErrorString(err, errStr))
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create CU image completed")
+}
+
+// Read refreshes the Terraform state with the latest data, the resource is removed when the CU image no longer exists.
+func (r *cuImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading CU image")
+	var state models.CuImageResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.readCuImage(ctx, &state)
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		tflog.Info(ctx, "CU image no longer exists")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		errStr := constants.ReadCuImageDetailErrorMsg + state.CuImageSSID.ValueString() + " with error: "
+		resp.Diagnostics.AddError("Error reading CU image", helper.GetErrorString(err, errStr))
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "read CU image completed")
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Supported updates: volume_ids, start_alias_address, end_alias_address.
+func (r *cuImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating CU image")
+	var plan, state models.CuImageResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	splitID := state.SplitID.ValueString()
+	ssid := state.CuImageSSID.ValueString()
+	errStr := constants.UpdateCuImageDetailErrorMsg + ssid + " with error: "
+	var planVolumes, stateVolumes []string
+	resp.Diagnostics.Append(plan.VolumeIDs.ElementsAs(ctx, &planVolumes, false)...)
+	resp.Diagnostics.Append(state.VolumeIDs.ElementsAs(ctx, &stateVolumes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	toMap, toUnmap := helper.GetStringSliceChanges(planVolumes, stateVolumes)
+
+	// Map the new volumes first, so that the CU image is never left without volumes
+	if len(toMap) > 0 {
+		err := helper.MapCUImageVolumes(ctx, *r.client, splitID, ssid, state.NextAvailableBaseAddress.ValueString(), toMap)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating CU image", helper.GetErrorString(err, errStr))
+		}
+	}
+	if len(toUnmap) > 0 && !resp.Diagnostics.HasError() {
+		err := helper.UnmapCUImageVolumes(ctx, *r.client, splitID, ssid, toUnmap)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating CU image", helper.GetErrorString(err, errStr))
+		}
+	}
+
+	if plan.StartAliasAddress.ValueString() != state.StartAliasAddress.ValueString() || plan.EndAliasAddress.ValueString() != state.EndAliasAddress.ValueString() {
+		var err error
+		if !state.StartAliasAddress.IsNull() {
+			err = helper.RemoveCUImageAliasRange(ctx, *r.client, splitID, ssid, state.StartAliasAddress.ValueString(), state.EndAliasAddress.ValueString())
+		}
+		if err == nil && !plan.StartAliasAddress.IsNull() {
+			err = helper.AssignCUImageAliasRange(ctx, *r.client, splitID, ssid, plan.StartAliasAddress.ValueString(), plan.EndAliasAddress.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating CU image", helper.GetErrorString(err, errStr))
+		}
+	}
+
+	state.StartBaseAddress = plan.StartBaseAddress
+	_, err := r.readCuImage(ctx, &state)
+	if err != nil {
+		errStr := constants.ReadCuImageDetailErrorMsg + ssid + " with error: "
+		resp.Diagnostics.AddError("Error reading CU image", helper.GetErrorString(err, errStr))
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "update CU image completed")
+}
+
+// Delete removes the alias range and unmaps all the volumes of the CU image, which removes the CU image from the array.
+func (r *cuImageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting CU image")
+	var state models.CuImageResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	splitID := state.SplitID.ValueString()
+	ssid := state.CuImageSSID.ValueString()
+	errStr := constants.DeleteCuImageDetailErrorMsg + ssid + " with error: "
+	if !state.StartAliasAddress.IsNull() {
+		err := helper.RemoveCUImageAliasRange(ctx, *r.client, splitID, ssid, state.StartAliasAddress.ValueString(), state.EndAliasAddress.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error deleting CU image", helper.GetErrorString(err, errStr))
+			return
+		}
+	}
+	// Unmap the volumes mapped on the array, including the ones mapped outside of Terraform
+	volumeIDs, err := helper.GetCUImageVolumeIDs(ctx, *r.client, ssid)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting CU image", helper.GetErrorString(err, errStr))
+		return
+	}
+	if len(volumeIDs) > 0 {
+		err = helper.UnmapCUImageVolumes(ctx, *r.client, splitID, ssid, volumeIDs)
+		if err != nil {
+			resp.Diagnostics.AddError("Error deleting CU image", helper.GetErrorString(err, errStr))
+			return
+		}
+	}
+	tflog.Info(ctx, "delete CU image completed")
+}
+
+// ImportState imports a CU image, the import ID is 'split_id.cu_image_ssid'.
+func (r *cuImageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing CU image")
+	splitID, ssid, err := helper.ParseCuImageID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing CU image", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("split_id"), splitID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cu_image_ssid"), ssid)...)
+	tflog.Info(ctx, "import CU image completed")
+}
@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCuImageResource(t *testing.T) {
+	var cuImageTerraformName = "powermax_cu_image.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + cuImageConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cuImageTerraformName, "id", "0.F0A0"),
+					resource.TestCheckResourceAttr(cuImageTerraformName, "volume_ids.#", "1"),
+					resource.TestCheckResourceAttr(cuImageTerraformName, "num_mapped_volumes", "1"),
+					resource.TestCheckResourceAttrSet(cuImageTerraformName, "status"),
+				),
+			},
+			// Map another volume and assign an alias range
+			{
+				Config: ProviderConfig + cuImageUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cuImageTerraformName, "volume_ids.#", "2"),
+					resource.TestCheckResourceAttr(cuImageTerraformName, "start_alias_address", "F0"),
+					resource.TestCheckResourceAttr(cuImageTerraformName, "end_alias_address", "FF"),
+				),
+			},
+			// Import test
+			{
+				Config:                  ProviderConfig + cuImageUpdateConfig,
+				ResourceName:            cuImageTerraformName,
+				ImportState:             true,
+				ImportStateId:           "0.F0A0",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"start_base_address"},
+			},
+		},
+	})
+}
+
+func TestAccCuImageResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateCUImage).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + cuImageConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccCuImageResourceImportError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        ProviderConfig + cuImageConfig,
+				ResourceName:  "powermax_cu_image.test",
+				ImportState:   true,
+				ImportStateId: "F0A0",
+				ExpectError:   regexp.MustCompile(`.*import ID must be*.`),
+			},
+		},
+	})
+}
+
+var cuImageVolumesConfig = `
+resource "powermax_storagegroup" "ckd" {
+	name   = "tfacc_ckd_sg"
+	srp_id = "SRP_1"
+}
+
+resource "powermax_volume" "ckd_1" {
+	vol_name  = "tfacc_ckd_vol_1"
+	size      = 1113
+	cap_unit  = "CYL"
+	emulation = "CKD-3390"
+	sg_name   = powermax_storagegroup.ckd.name
+}
+
+resource "powermax_volume" "ckd_2" {
+	vol_name  = "tfacc_ckd_vol_2"
+	size      = 1113
+	cap_unit  = "CYL"
+	emulation = "CKD-3390"
+	sg_name   = powermax_storagegroup.ckd.name
+}
+`
+
+var cuImageConfig = cuImageVolumesConfig + `
+resource "powermax_cu_image" "test" {
+	split_id           = "0"
+	cu_image_ssid      = "F0A0"
+	cu_image_number    = "A0"
+	start_base_address = "00"
+	volume_ids         = [powermax_volume.ckd_1.id]
+}
+`
+
+var cuImageUpdateConfig = cuImageVolumesConfig + `
+resource "powermax_cu_image" "test" {
+	split_id            = "0"
+	cu_image_ssid       = "F0A0"
+	cu_image_number     = "A0"
+	start_base_address  = "00"
+	volume_ids          = [powermax_volume.ckd_1.id, powermax_volume.ckd_2.id]
+	start_alias_address = "F0"
+	end_alias_address   = "FF"
+}
+`
@@ -163,6 +163,19 @@ func (p *PmaxProvider) Resources(ctx context.Context) []func() resource.Resource
 		NewVolumeResource,
 		NewSnapshotResource,
 		NewSnapshotPolicy,
+		NewVirtualWitness,
+		NewStorageGroupClone,
+		NewCloudSnapshotResource,
+		NewCloudProvider,
+		NewCloudSystemDNSServer,
+		NewCloudSystemRoute,
+		NewCloudSystemTeam,
+		NewSnapshotSetResource,
+		NewSnapshotRestoreResource,
+		NewSnapshotPolicyAttachmentResource,
+		NewStorageGroupCascadeResource,
+		NewVolumeSetResource,
+		NewCuImageResource,
 	}
 }
 
@@ -178,6 +191,14 @@ func (p *PmaxProvider) DataSources(ctx context.Context) []func() datasource.Data
 		NewSnapshotDataSource,
 		NewPortDataSource,
 		NewSnapshotPolicyDataSource,
+		NewVirtualWitnessDataSource,
+		NewCloudSnapshotDataSource,
+		NewCloudProviderDataSource,
+		NewSnapshotComplianceDataSource,
+		NewSnapshotHistoryDataSource,
+		NewCuImageDataSource,
+		NewSplitDataSource,
+		NewTagsDataSource,
 	}
 }
 
@@ -0,0 +1,318 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &snapshotComplianceDataSource{}
+	_ datasource.DataSourceWithConfigure = &snapshotComplianceDataSource{}
+)
+
+// NewSnapshotComplianceDataSource is a helper function to simplify the provider implementation.
+func NewSnapshotComplianceDataSource() datasource.DataSource {
+	return &snapshotComplianceDataSource{}
+}
+
+// snapshotComplianceDataSource is the data source implementation.
+type snapshotComplianceDataSource struct {
+	client *client.Client
+}
+
+func (d *snapshotComplianceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_compliance"
+}
+
+func (d *snapshotComplianceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for the SnapVX snapshot compliance of StorageGroups in PowerMax array. The compliance tells whether a storage group has enough good snapshots to meet the compliance thresholds of its snapshot policies.",
+		Description:         "Data source for the SnapVX snapshot compliance of StorageGroups in PowerMax array. The compliance tells whether a storage group has enough good snapshots to meet the compliance thresholds of its snapshot policies.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"storage_groups": schema.ListNestedAttribute{
+				Description:         "List of storage group snapshot compliances",
+				MarkdownDescription: "List of storage group snapshot compliances",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"storage_group_name": schema.StringAttribute{
+							Description:         "The name of the storage group.",
+							MarkdownDescription: "The name of the storage group.",
+							Computed:            true,
+						},
+						"compliance": schema.StringAttribute{
+							Description:         "The overall snapshot compliance of the storage group, one of GREEN, YELLOW, RED or NONE.",
+							MarkdownDescription: "The overall snapshot compliance of the storage group, one of GREEN, YELLOW, RED or NONE.",
+							Computed:            true,
+						},
+						"policy_count": schema.Int64Attribute{
+							Description:         "The number of snapshot policies associated with the storage group.",
+							MarkdownDescription: "The number of snapshot policies associated with the storage group.",
+							Computed:            true,
+						},
+						"last_good_snapshot_time": schema.StringAttribute{
+							Description:         "The time of the newest snapshot policy snapshot of the storage group which has neither failed nor expired.",
+							MarkdownDescription: "The time of the newest snapshot policy snapshot of the storage group which has neither failed nor expired.",
+							Computed:            true,
+						},
+						"last_good_snapshot_timestamp": schema.Int64Attribute{
+							Description:         "The UTC timestamp of the newest snapshot policy snapshot of the storage group which has neither failed nor expired.",
+							MarkdownDescription: "The UTC timestamp of the newest snapshot policy snapshot of the storage group which has neither failed nor expired.",
+							Computed:            true,
+						},
+						"policies": schema.ListNestedAttribute{
+							Description:         "The compliance of the storage group for each of its snapshot policies.",
+							MarkdownDescription: "The compliance of the storage group for each of its snapshot policies.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"snapshot_policy_name": schema.StringAttribute{
+										Description:         "The name of the snapshot policy.",
+										MarkdownDescription: "The name of the snapshot policy.",
+										Computed:            true,
+									},
+									"compliance": schema.StringAttribute{
+										Description:         "The compliance of the storage group for the snapshot policy, one of GREEN, YELLOW, RED or NONE.",
+										MarkdownDescription: "The compliance of the storage group for the snapshot policy, one of GREEN, YELLOW, RED or NONE.",
+										Computed:            true,
+									},
+									"calculation_time": schema.StringAttribute{
+										Description:         "The time the compliance was calculated.",
+										MarkdownDescription: "The time the compliance was calculated.",
+										Computed:            true,
+									},
+									"compliance_count_warning": schema.Int64Attribute{
+										Description:         "The threshold of good snapshots for the compliance to change from normal to warning.",
+										MarkdownDescription: "The threshold of good snapshots for the compliance to change from normal to warning.",
+										Computed:            true,
+									},
+									"compliance_count_critical": schema.Int64Attribute{
+										Description:         "The threshold of good snapshots for the compliance to change from warning to critical.",
+										MarkdownDescription: "The threshold of good snapshots for the compliance to change from warning to critical.",
+										Computed:            true,
+									},
+									"snapshots_in_time_window": schema.Int64Attribute{
+										Description:         "The number of snapshots in the compliance time window.",
+										MarkdownDescription: "The number of snapshots in the compliance time window.",
+										Computed:            true,
+									},
+									"total_snapshots": schema.Int64Attribute{
+										Description:         "The total number of snapshots of the storage group for the snapshot policy.",
+										MarkdownDescription: "The total number of snapshots of the storage group for the snapshot policy.",
+										Computed:            true,
+									},
+									"suspended": schema.BoolAttribute{
+										Description:         "Whether the snapshot policy is suspended for the storage group.",
+										MarkdownDescription: "Whether the snapshot policy is suspended for the storage group.",
+										Computed:            true,
+									},
+									"last_good_snapshot_time": schema.StringAttribute{
+										Description:         "The time of the newest snapshot created by the snapshot policy which has neither failed nor expired.",
+										MarkdownDescription: "The time of the newest snapshot created by the snapshot policy which has neither failed nor expired.",
+										Computed:            true,
+									},
+									"last_good_snapshot_timestamp": schema.Int64Attribute{
+										Description:         "The UTC timestamp of the newest snapshot created by the snapshot policy which has neither failed nor expired.",
+										MarkdownDescription: "The UTC timestamp of the newest snapshot created by the snapshot policy which has neither failed nor expired.",
+										Computed:            true,
+									},
+									"error_messages": schema.ListNestedAttribute{
+										Description:         "The compliance error messages of the snapshot policy.",
+										MarkdownDescription: "The compliance error messages of the snapshot policy.",
+										Computed:            true,
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"time": schema.StringAttribute{
+													Description:         "The time of the error message.",
+													MarkdownDescription: "The time of the error message.",
+													Computed:            true,
+												},
+												"error_message": schema.StringAttribute{
+													Description:         "The compliance error message.",
+													MarkdownDescription: "The compliance error message.",
+													Computed:            true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"storage_group_names": schema.SetAttribute{
+						Description:         "The names of the storage groups to report the compliance of. Defaults to all the storage groups associated with a snapshot policy.",
+						MarkdownDescription: "The names of the storage groups to report the compliance of. Defaults to all the storage groups associated with a snapshot policy.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *snapshotComplianceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider is not config
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Config Failure",
+			fmt.Sprintf("Expected client, %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *snapshotComplianceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.SnapshotComplianceDataSourceModel
+	var plan models.SnapshotComplianceDataSourceModel
+	tflog.Info(ctx, "Attempting to read snapshot compliance")
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sgNames []string
+	if plan.SnapshotComplianceFilter != nil && len(plan.SnapshotComplianceFilter.StorageGroupNames) > 0 {
+		for _, name := range plan.SnapshotComplianceFilter.StorageGroupNames {
+			sgNames = append(sgNames, name.ValueString())
+		}
+	} else {
+		names, err := helper.GetSnapshotPolicyStorageGroupNames(ctx, *d.client)
+		if err != nil {
+			errStr := constants.ReadSnapshotCompliance + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the storage groups with snapshot policies",
+				message,
+			)
+			return
+		}
+		sgNames = names
+	}
+
+	// The policies are shared between storage groups, only read each of them once
+	policies := map[string]*pmax.SnapshotPolicy{}
+	state.StorageGroups = []models.StorageGroupSnapshotComplianceModel{}
+	for _, sgName := range sgNames {
+		compliance, _, err := helper.GetStorageGroupSnapshotCompliance(ctx, *d.client, sgName)
+		if err != nil {
+			errStr := constants.ReadSnapshotCompliance + " of storage group " + sgName + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the snapshot compliance",
+				message,
+			)
+			return
+		}
+
+		policyStorageGroups := map[string]*pmax.SnapshotPolicyStorageGroup{}
+		for _, slCompliance := range compliance.SlCompliance {
+			policyName := slCompliance.SlName
+			if _, ok := policies[policyName]; !ok {
+				policy, _, err := helper.GetSnapshotPolicy(ctx, *d.client, policyName)
+				if err != nil {
+					errStr := constants.ReadSnapshotCompliance + " of snapshot policy " + policyName + " with error: "
+					message := helper.GetErrorString(err, errStr)
+					resp.Diagnostics.AddError(
+						"Error getting the snapshot policy details",
+						message,
+					)
+					return
+				}
+				policies[policyName] = policy
+			}
+			policySg, _, err := helper.GetSnapshotPolicyStorageGroup(ctx, *d.client, policyName, sgName)
+			if err != nil {
+				errStr := constants.ReadSnapshotCompliance + " of storage group " + sgName + " with error: "
+				message := helper.GetErrorString(err, errStr)
+				resp.Diagnostics.AddError(
+					"Error getting the snapshot policy storage group details",
+					message,
+				)
+				return
+			}
+			policyStorageGroups[policyName] = policySg
+		}
+
+		snapshots, _, err := helper.GetStorageGroupSnapshots(ctx, *d.client, sgName)
+		if err != nil {
+			errStr := constants.ReadSnapshotCompliance + " of storage group " + sgName + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the list of snapshots",
+				message,
+			)
+			return
+		}
+		lastGoodSnapshots := map[string]*pmax.SnapVXSnapshotGeneration{}
+		for _, snapshotName := range snapshots.SlSnapshotName {
+			lastGood, err := helper.GetLastGoodSnapshot(ctx, *d.client, sgName, snapshotName)
+			if err != nil {
+				errStr := constants.ReadSnapshotCompliance + " of storage group " + sgName + " with error: "
+				message := helper.GetErrorString(err, errStr)
+				resp.Diagnostics.AddError(
+					"Error getting the snapshot details",
+					message,
+				)
+				return
+			}
+			lastGoodSnapshots[snapshotName] = lastGood
+		}
+
+		var detail models.StorageGroupSnapshotComplianceModel
+		helper.UpdateSnapshotComplianceState(&detail, compliance, policies, policyStorageGroups, lastGoodSnapshots)
+		state.StorageGroups = append(state.StorageGroups, detail)
+	}
+	state.ID = types.StringValue("snapshot-compliance-datasource")
+	state.SnapshotComplianceFilter = plan.SnapshotComplianceFilter
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
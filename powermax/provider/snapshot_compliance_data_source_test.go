@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSnapshotComplianceDataSource(t *testing.T) {
+	var snapshotComplianceTerraformName = "data.powermax_snapshot_compliance.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + snapshotComplianceDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotComplianceTerraformName, "storage_groups.#", "1"),
+					resource.TestCheckResourceAttr(snapshotComplianceTerraformName, "storage_groups.0.storage_group_name", "tfacc_sp_sg1"),
+					resource.TestCheckResourceAttrSet(snapshotComplianceTerraformName, "storage_groups.0.compliance"),
+					resource.TestCheckResourceAttr(snapshotComplianceTerraformName, "storage_groups.0.policies.0.snapshot_policy_name", "tfacc_compliance_sp"),
+					resource.TestCheckResourceAttr(snapshotComplianceTerraformName, "storage_groups.0.policies.0.compliance_count_critical", "29"),
+				),
+			},
+			{
+				Config: ProviderConfig + snapshotComplianceAllDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(snapshotComplianceTerraformName, "storage_groups.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotComplianceDataSourceError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetStorageGroupSnapshotCompliance).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotComplianceFilterOnlyDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotComplianceDataSourceListError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetSnapshotPolicyStorageGroupNames).Return(nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotComplianceAllDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotComplianceDataSourceSnapshotError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetStorageGroupSnapshots).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotComplianceDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+var snapshotComplianceDatasourceConfig = `
+resource "powermax_snapshotpolicy" "compliance_sp" {
+	snapshot_policy_name = "tfacc_compliance_sp"
+	interval = "1 Day"
+	compliance_count_critical = 29
+}
+
+resource "powermax_snapshot_policy_attachment" "compliance_sp" {
+	storage_group_name = "tfacc_sp_sg1"
+	snapshot_policy_name = powermax_snapshotpolicy.compliance_sp.snapshot_policy_name
+}
+
+data "powermax_snapshot_compliance" "test" {
+	filter {
+		storage_group_names = [powermax_snapshot_policy_attachment.compliance_sp.storage_group_name]
+	}
+}
+`
+
+var snapshotComplianceFilterOnlyDatasourceConfig = `
+data "powermax_snapshot_compliance" "test" {
+	filter {
+		storage_group_names = ["tfacc_sp_sg1"]
+	}
+}
+`
+
+var snapshotComplianceAllDatasourceConfig = `
+data "powermax_snapshot_compliance" "test" {
+}
+`
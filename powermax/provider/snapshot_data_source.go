@@ -128,6 +128,74 @@ func (d *snapshotDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 								},
 							},
 						},
+						"source_volume_details": schema.ListNestedAttribute{
+							Description:         "The source volumes of the snapshot generation, each with the volumes it is linked to.",
+							MarkdownDescription: "The source volumes of the snapshot generation, each with the volumes it is linked to.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Description:         "The name of the source volume",
+										MarkdownDescription: "The name of the source volume",
+										Computed:            true,
+									},
+									"capacity": schema.Int64Attribute{
+										Description:         "The capacity of the source volume in cylinders",
+										MarkdownDescription: "The capacity of the source volume in cylinders",
+										Computed:            true,
+									},
+									"capacity_gb": schema.Float64Attribute{
+										Description:         "The capacity of the source volume in GB",
+										MarkdownDescription: "The capacity of the source volume in GB",
+										Computed:            true,
+									},
+									"linked": schema.BoolAttribute{
+										Description:         "Set if the source volume is linked to a target volume",
+										MarkdownDescription: "Set if the source volume is linked to a target volume",
+										Computed:            true,
+									},
+									"linked_volumes": schema.ListNestedAttribute{
+										Description:         "The target volumes the source volume is linked to",
+										MarkdownDescription: "The target volumes the source volume is linked to",
+										Computed:            true,
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"storage_group_name": schema.StringAttribute{
+													Description:         "The linked storage group name",
+													MarkdownDescription: "The linked storage group name",
+													Computed:            true,
+												},
+												"linked_volume_name": schema.StringAttribute{
+													Description:         "The linked volume name",
+													MarkdownDescription: "The linked volume name",
+													Computed:            true,
+												},
+												"tracks": schema.Int64Attribute{
+													Description:         "The number of tracks of the link",
+													MarkdownDescription: "The number of tracks of the link",
+													Computed:            true,
+												},
+												"track_size": schema.Int64Attribute{
+													Description:         "The size of the tracks",
+													MarkdownDescription: "The size of the tracks",
+													Computed:            true,
+												},
+												"percentage_copied": schema.Int64Attribute{
+													Description:         "The percentage of tracks copied to the linked volume",
+													MarkdownDescription: "The percentage of tracks copied to the linked volume",
+													Computed:            true,
+												},
+												"defined": schema.BoolAttribute{
+													Description:         "Set when the link has been fully defined",
+													MarkdownDescription: "Set when the link has been fully defined",
+													Computed:            true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
 						"num_storage_group_volumes": schema.Int64Attribute{
 							Description:         "The number of non-gatekeeper storage group volumes",
 							MarkdownDescription: "The number of non-gatekeeper storage group volumes",
@@ -37,6 +37,7 @@ func TestAccSnapshotDataSource(t *testing.T) {
 				Config: ProviderConfig + snapshotDatasourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet(snapshotTerraformName, "snapshots.#"),
+					resource.TestCheckResourceAttrSet(snapshotTerraformName, "snapshots.0.source_volume_details.0.name"),
 				),
 			},
 		},
@@ -0,0 +1,309 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &snapshotHistoryDataSource{}
+	_ datasource.DataSourceWithConfigure = &snapshotHistoryDataSource{}
+)
+
+// NewSnapshotHistoryDataSource is a helper function to simplify the provider implementation.
+func NewSnapshotHistoryDataSource() datasource.DataSource {
+	return &snapshotHistoryDataSource{}
+}
+
+// snapshotHistoryDataSource is the data source implementation.
+type snapshotHistoryDataSource struct {
+	client *client.Client
+}
+
+func (d *snapshotHistoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_history"
+}
+
+func (d *snapshotHistoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for the SnapVX snapshot generation history of a StorageGroup or volume in PowerMax array. Every generation of every snapshot is listed with its snap ID, age, expiry, secure state and link targets, so that cleanup jobs can compute the generations to terminate.",
+		Description:         "Data source for the SnapVX snapshot generation history of a StorageGroup or volume in PowerMax array. Every generation of every snapshot is listed with its snap ID, age, expiry, secure state and link targets, so that cleanup jobs can compute the generations to terminate.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"storage_group_name": schema.StringAttribute{
+				Description:         "The name of the storage group to list the snapshot generations of. Exactly one of storage_group_name and volume_id must be set.",
+				MarkdownDescription: "The name of the storage group to list the snapshot generations of. Exactly one of storage_group_name and volume_id must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("volume_id")),
+				},
+			},
+			"volume_id": schema.StringAttribute{
+				Description:         "The ID of the volume to list the snapshot generations of. The generations of all the storage groups of the volume which include the volume are listed.",
+				MarkdownDescription: "The ID of the volume to list the snapshot generations of. The generations of all the storage groups of the volume which include the volume are listed.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"generations": schema.ListNestedAttribute{
+				Description:         "The snapshot generations, ordered by storage group, snapshot name and generation, newest first.",
+				MarkdownDescription: "The snapshot generations, ordered by storage group, snapshot name and generation, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"storage_group_name": schema.StringAttribute{
+							Description:         "The storage group of the snapshot.",
+							MarkdownDescription: "The storage group of the snapshot.",
+							Computed:            true,
+						},
+						"snapshot_name": schema.StringAttribute{
+							Description:         "The name of the snapshot.",
+							MarkdownDescription: "The name of the snapshot.",
+							Computed:            true,
+						},
+						"snapid": schema.Int64Attribute{
+							Description:         "The unique snap ID of the generation, it does not change when newer snapshots are created or terminated.",
+							MarkdownDescription: "The unique snap ID of the generation, it does not change when newer snapshots are created or terminated.",
+							Computed:            true,
+						},
+						"generation": schema.Int64Attribute{
+							Description:         "The generation number, 0 is the newest.",
+							MarkdownDescription: "The generation number, 0 is the newest.",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description:         "The timestamp of the generation.",
+							MarkdownDescription: "The timestamp of the generation.",
+							Computed:            true,
+						},
+						"timestamp_utc": schema.Int64Attribute{
+							Description:         "The timestamp of the generation in milliseconds since 1970.",
+							MarkdownDescription: "The timestamp of the generation in milliseconds since 1970.",
+							Computed:            true,
+						},
+						"age_hours": schema.Int64Attribute{
+							Description:         "The number of full hours since the generation was created.",
+							MarkdownDescription: "The number of full hours since the generation was created.",
+							Computed:            true,
+						},
+						"time_to_live_expiry_date": schema.StringAttribute{
+							Description:         "When the generation will expire once it is not linked.",
+							MarkdownDescription: "When the generation will expire once it is not linked.",
+							Computed:            true,
+						},
+						"secure_expiry_date": schema.StringAttribute{
+							Description:         "When the secure generation will expire.",
+							MarkdownDescription: "When the secure generation will expire.",
+							Computed:            true,
+						},
+						"secure": schema.BoolAttribute{
+							Description:         "Set if the generation is a secure snapshot, it cannot be terminated before its secure expiry date.",
+							MarkdownDescription: "Set if the generation is a secure snapshot, it cannot be terminated before its secure expiry date.",
+							Computed:            true,
+						},
+						"expired": schema.BoolAttribute{
+							Description:         "Set if the generation has expired.",
+							MarkdownDescription: "Set if the generation has expired.",
+							Computed:            true,
+						},
+						"linked": schema.BoolAttribute{
+							Description:         "Set if the generation is linked.",
+							MarkdownDescription: "Set if the generation is linked.",
+							Computed:            true,
+						},
+						"restored": schema.BoolAttribute{
+							Description:         "Set if the generation is restored.",
+							MarkdownDescription: "Set if the generation is restored.",
+							Computed:            true,
+						},
+						"state": schema.ListAttribute{
+							Description:         "The state of the generation.",
+							MarkdownDescription: "The state of the generation.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"source_volumes": schema.ListAttribute{
+							Description:         "The source volumes of the generation.",
+							MarkdownDescription: "The source volumes of the generation.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"linked_targets": schema.ListNestedAttribute{
+							Description:         "The link targets of the generation.",
+							MarkdownDescription: "The link targets of the generation.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"storage_group_name": schema.StringAttribute{
+										Description:         "The linked storage group.",
+										MarkdownDescription: "The linked storage group.",
+										Computed:            true,
+									},
+									"source_volume_name": schema.StringAttribute{
+										Description:         "The source volume.",
+										MarkdownDescription: "The source volume.",
+										Computed:            true,
+									},
+									"linked_volume_name": schema.StringAttribute{
+										Description:         "The linked volume.",
+										MarkdownDescription: "The linked volume.",
+										Computed:            true,
+									},
+									"percentage_copied": schema.Int64Attribute{
+										Description:         "The percentage of tracks copied to the linked volume.",
+										MarkdownDescription: "The percentage of tracks copied to the linked volume.",
+										Computed:            true,
+									},
+									"defined": schema.BoolAttribute{
+										Description:         "Set when the link has been fully defined.",
+										MarkdownDescription: "Set when the link has been fully defined.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"name_prefix": schema.StringAttribute{
+						Description:         "Only list the generations of the snapshots whose name starts with the prefix.",
+						MarkdownDescription: "Only list the generations of the snapshots whose name starts with the prefix.",
+						Optional:            true,
+					},
+					"older_than_hours": schema.Int64Attribute{
+						Description:         "Only list the generations which are at least this number of hours old.",
+						MarkdownDescription: "Only list the generations which are at least this number of hours old.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"newer_than_hours": schema.Int64Attribute{
+						Description:         "Only list the generations which are less than this number of hours old.",
+						MarkdownDescription: "Only list the generations which are less than this number of hours old.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"expired": schema.BoolAttribute{
+						Description:         "Only list the expired generations when true, or the generations which have not expired when false.",
+						MarkdownDescription: "Only list the expired generations when true, or the generations which have not expired when false.",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *snapshotHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider is not config
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Config Failure",
+			fmt.Sprintf("Expected client, %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *snapshotHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.SnapshotHistoryDataSourceModel
+	var plan models.SnapshotHistoryDataSourceModel
+	tflog.Info(ctx, "Attempting to read snapshot history")
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sgNames := []string{plan.StorageGroupName.ValueString()}
+	volumeID := plan.VolumeID.ValueString()
+	if volumeID != "" {
+		names, err := helper.GetVolumeStorageGroupNames(ctx, *d.client, volumeID)
+		if err != nil {
+			errStr := constants.ReadSnapshotHistory + " of volume " + volumeID + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the storage groups of the volume",
+				message,
+			)
+			return
+		}
+		sgNames = names
+	}
+
+	// All the generations are aged against the same time
+	now := time.Now()
+	state.Generations = []models.SnapshotHistoryGenerationModel{}
+	for _, sgName := range sgNames {
+		generations, err := helper.GetStorageGroupSnapshotHistory(ctx, *d.client, sgName, volumeID, plan.SnapshotHistoryFilter, now)
+		if err != nil {
+			errStr := constants.ReadSnapshotHistory + " of storage group " + sgName + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the snapshot history",
+				message,
+			)
+			return
+		}
+		state.Generations = append(state.Generations, generations...)
+	}
+	state.ID = types.StringValue("snapshot-history-datasource")
+	state.StorageGroupName = plan.StorageGroupName
+	state.VolumeID = plan.VolumeID
+	state.SnapshotHistoryFilter = plan.SnapshotHistoryFilter
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSnapshotHistoryDataSource(t *testing.T) {
+	var snapshotHistoryTerraformName = "data.powermax_snapshot_history.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + snapshotHistoryDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotHistoryTerraformName, "generations.#", "1"),
+					resource.TestCheckResourceAttr(snapshotHistoryTerraformName, "generations.0.storage_group_name", "tfacc_sg_snapshot"),
+					resource.TestCheckResourceAttr(snapshotHistoryTerraformName, "generations.0.snapshot_name", "tfacc_snapshot_history"),
+					resource.TestCheckResourceAttr(snapshotHistoryTerraformName, "generations.0.generation", "0"),
+					resource.TestCheckResourceAttr(snapshotHistoryTerraformName, "generations.0.expired", "false"),
+					resource.TestCheckResourceAttrPair(snapshotHistoryTerraformName, "generations.0.snapid", "powermax_snapshot.history", "snapid"),
+				),
+			},
+			{
+				Config: ProviderConfig + snapshotHistoryOlderDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotHistoryTerraformName, "generations.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotHistoryDataSourceError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetSnapshotIDMapping).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotHistoryFilterOnlyDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotHistoryDataSourceVolumeError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetVolumeStorageGroupNames).Return(nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotHistoryVolumeDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotHistoryDataSourceSourceError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + snapshotHistoryNoSourceDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*Invalid Attribute Combination*.`),
+			},
+		},
+	})
+}
+
+var snapshotHistorySnapshotConfig = `
+resource "powermax_snapshot" "history" {
+	storage_group {
+		name = "tfacc_sg_snapshot"
+	}
+	snapshot_actions {
+		name = "tfacc_snapshot_history"
+	}
+}
+`
+
+var snapshotHistoryDatasourceConfig = snapshotHistorySnapshotConfig + `
+data "powermax_snapshot_history" "test" {
+	storage_group_name = powermax_snapshot.history.storage_group.name
+	filter {
+		name_prefix = powermax_snapshot.history.name
+		expired = false
+	}
+}
+`
+
+var snapshotHistoryOlderDatasourceConfig = snapshotHistorySnapshotConfig + `
+data "powermax_snapshot_history" "test" {
+	storage_group_name = powermax_snapshot.history.storage_group.name
+	filter {
+		name_prefix = powermax_snapshot.history.name
+		older_than_hours = 24
+	}
+}
+`
+
+var snapshotHistoryFilterOnlyDatasourceConfig = `
+data "powermax_snapshot_history" "test" {
+	storage_group_name = "tfacc_sg_snapshot"
+}
+`
+
+var snapshotHistoryVolumeDatasourceConfig = `
+data "powermax_snapshot_history" "test" {
+	volume_id = "00001"
+}
+`
+
+var snapshotHistoryNoSourceDatasourceConfig = `
+data "powermax_snapshot_history" "test" {
+	filter {
+		expired = true
+	}
+}
+`
@@ -0,0 +1,290 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &snapshotPolicyAttachmentResource{}
+	_ resource.ResourceWithConfigure   = &snapshotPolicyAttachmentResource{}
+	_ resource.ResourceWithImportState = &snapshotPolicyAttachmentResource{}
+)
+
+// NewSnapshotPolicyAttachmentResource is a helper function to simplify the provider implementation.
+func NewSnapshotPolicyAttachmentResource() resource.Resource {
+	return &snapshotPolicyAttachmentResource{}
+}
+
+// snapshotPolicyAttachmentResource is the resource implementation.
+type snapshotPolicyAttachmentResource struct {
+	client *client.Client
+}
+
+// Metadata returns the resource type name.
+func (r *snapshotPolicyAttachmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_policy_attachment"
+}
+
+// Schema defines the schema for the resource.
+func (r *snapshotPolicyAttachmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for attaching a storage group to a snapshot policy in PowerMax array, from the storage group side. It lets the owner of a storage group protect it with a snapshot policy managed elsewhere. Do not also list the storage group in the storage_groups of the powermax_snapshotpolicy resource, leave storage_groups unset on the policies which are attached to with this resource.",
+		Description:         "Resource for attaching a storage group to a snapshot policy in PowerMax array, from the storage group side. It lets the owner of a storage group protect it with a snapshot policy managed elsewhere. Do not also list the storage group in the storage_groups of the powermax_snapshotpolicy resource, leave storage_groups unset on the policies which are attached to with this resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "The ID of the attachment, in the format 'storage_group_name.snapshot_policy_name'.",
+				MarkdownDescription: "The ID of the attachment, in the format 'storage_group_name.snapshot_policy_name'.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_group_name": schema.StringAttribute{
+				Description:         "The name of the storage group to attach.",
+				MarkdownDescription: "The name of the storage group to attach.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_policy_name": schema.StringAttribute{
+				Description:         "The name of the snapshot policy to attach the storage group to.",
+				MarkdownDescription: "The name of the snapshot policy to attach the storage group to.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"suspended": schema.BoolAttribute{
+				Description:         "Suspend the snapshot policy for this storage group only. When not set the suspended state is left as is. (Update Supported)",
+				MarkdownDescription: "Suspend the snapshot policy for this storage group only. When not set the suspended state is left as is. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"compliance": schema.StringAttribute{
+				Description:         "The compliance of the storage group for the snapshot policy, GREEN, YELLOW, RED or NONE.",
+				MarkdownDescription: "The compliance of the storage group for the snapshot policy, GREEN, YELLOW, RED or NONE.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *snapshotPolicyAttachmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create attaches the storage group to the snapshot policy.
+func (r *snapshotPolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating snapshot policy attachment")
+	var plan models.SnapshotPolicyAttachmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sgName := plan.StorageGroupName.ValueString()
+	policyName := plan.SnapshotPolicyName.ValueString()
+	err := helper.AttachSnapshotPolicy(ctx, *r.client, sgName, policyName)
+	if err != nil {
+		errStr := constants.AttachSnapshotPolicyDetailErrorMsg + policyName + " to storage group " + sgName + " with error: "
+		resp.Diagnostics.AddError("Error creating snapshot policy attachment", helper.GetErrorString(err, errStr))
+		return
+	}
+
+	if !plan.Suspended.IsUnknown() && plan.Suspended.ValueBool() {
+		err = helper.SuspendOrResumeStorageGroupSnapshotPolicy(ctx, *r.client, sgName, policyName, true)
+		if err != nil {
+			errStr := constants.AttachSnapshotPolicyDetailErrorMsg + policyName + " to storage group " + sgName + " with error: "
+			resp.Diagnostics.AddError("Error creating snapshot policy attachment", helper.GetErrorString(err, errStr))
+			// Detach again so that a retry starts from scratch
+			errDetach := helper.DetachSnapshotPolicy(ctx, *r.client, sgName, policyName)
+			if errDetach != nil {
+				resp.Diagnostics.AddError("Error cleaning up snapshot policy attachment", errDetach.Error())
+			}
+			return
+		}
+	}
+
+	policySg, _, err := helper.GetSnapshotPolicyStorageGroup(ctx, *r.client, policyName, sgName)
+	if err != nil {
+		errStr := constants.ReadSnapshotPolicyAttachmentDetailErrorMsg + policyName + " with error: "
+		resp.Diagnostics.AddError("Error reading snapshot policy attachment", helper.GetErrorString(err, errStr))
+		return
+	}
+	helper.UpdateSnapshotPolicyAttachmentState(&plan, policySg)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create snapshot policy attachment completed")
+}
+
+// Read refreshes the Terraform state with the latest data, the resource is removed when the storage group is no longer attached.
+func (r *snapshotPolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading snapshot policy attachment")
+	var state models.SnapshotPolicyAttachmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sgName := state.StorageGroupName.ValueString()
+	policyName := state.SnapshotPolicyName.ValueString()
+	storageGroup, _, err := helper.GetStorageGroup(ctx, *r.client, sgName)
+	if err != nil {
+		errStr := constants.ReadSnapshotPolicyAttachmentDetailErrorMsg + policyName + " with error: "
+		resp.Diagnostics.AddError("Error reading snapshot policy attachment", helper.GetErrorString(err, errStr))
+		return
+	}
+	if !helper.StringInSlice(policyName, storageGroup.SnapshotPolicies) {
+		tflog.Info(ctx, "storage group is no longer attached to the snapshot policy")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	policySg, _, err := helper.GetSnapshotPolicyStorageGroup(ctx, *r.client, policyName, sgName)
+	if err != nil {
+		errStr := constants.ReadSnapshotPolicyAttachmentDetailErrorMsg + policyName + " with error: "
+		resp.Diagnostics.AddError("Error reading snapshot policy attachment", helper.GetErrorString(err, errStr))
+		return
+	}
+	helper.UpdateSnapshotPolicyAttachmentState(&state, policySg)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "read snapshot policy attachment completed")
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Supported updates: suspended.
+func (r *snapshotPolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating snapshot policy attachment")
+	var plan, state models.SnapshotPolicyAttachmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sgName := state.StorageGroupName.ValueString()
+	policyName := state.SnapshotPolicyName.ValueString()
+	if !plan.Suspended.IsUnknown() && !plan.Suspended.IsNull() && plan.Suspended.ValueBool() != state.Suspended.ValueBool() {
+		err := helper.SuspendOrResumeStorageGroupSnapshotPolicy(ctx, *r.client, sgName, policyName, plan.Suspended.ValueBool())
+		if err != nil {
+			errStr := constants.AttachSnapshotPolicyDetailErrorMsg + policyName + " to storage group " + sgName + " with error: "
+			resp.Diagnostics.AddError("Error updating snapshot policy attachment", helper.GetErrorString(err, errStr))
+			return
+		}
+	}
+
+	policySg, _, err := helper.GetSnapshotPolicyStorageGroup(ctx, *r.client, policyName, sgName)
+	if err != nil {
+		errStr := constants.ReadSnapshotPolicyAttachmentDetailErrorMsg + policyName + " with error: "
+		resp.Diagnostics.AddError("Error reading snapshot policy attachment", helper.GetErrorString(err, errStr))
+		return
+	}
+	helper.UpdateSnapshotPolicyAttachmentState(&state, policySg)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "update snapshot policy attachment completed")
+}
+
+// Delete detaches the storage group from the snapshot policy and removes the Terraform state on success.
+func (r *snapshotPolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting snapshot policy attachment")
+	var state models.SnapshotPolicyAttachmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sgName := state.StorageGroupName.ValueString()
+	policyName := state.SnapshotPolicyName.ValueString()
+	err := helper.DetachSnapshotPolicy(ctx, *r.client, sgName, policyName)
+	if err != nil {
+		errStr := constants.DetachSnapshotPolicyDetailErrorMsg + policyName + " from storage group " + sgName + " with error: "
+		resp.Diagnostics.AddError("Error deleting snapshot policy attachment", helper.GetErrorString(err, errStr))
+		return
+	}
+	tflog.Info(ctx, "delete snapshot policy attachment completed")
+}
+
+// ImportState imports the attachment of a storage group with a snapshot policy, the import ID is 'storage_group_name.snapshot_policy_name'.
+func (r *snapshotPolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing snapshot policy attachment")
+	sgName, policyName, err := helper.ParseSnapshotPolicyAttachmentID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing snapshot policy attachment", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("storage_group_name"), sgName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("snapshot_policy_name"), policyName)...)
+	tflog.Info(ctx, "import snapshot policy attachment completed")
+}
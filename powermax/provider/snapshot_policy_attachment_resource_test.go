@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSnapshotPolicyAttachmentResource(t *testing.T) {
+	var attachmentTerraformName = "powermax_snapshot_policy_attachment.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test, the policy without storage_groups must not plan to detach the storage group
+			{
+				Config: ProviderConfig + snapshotPolicyAttachmentConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(attachmentTerraformName, "id", "tfacc_sp_sg2.tfacc_attachment_sp"),
+					resource.TestCheckResourceAttr(attachmentTerraformName, "suspended", "false"),
+					resource.TestCheckResourceAttrSet(attachmentTerraformName, "compliance"),
+				),
+			},
+			// Suspend the policy for the storage group
+			{
+				Config: ProviderConfig + snapshotPolicyAttachmentSuspendConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(attachmentTerraformName, "suspended", "true"),
+				),
+			},
+			// Import test
+			{
+				Config:            ProviderConfig + snapshotPolicyAttachmentSuspendConfig,
+				ResourceName:      attachmentTerraformName,
+				ImportState:       true,
+				ImportStateId:     "tfacc_sp_sg2.tfacc_attachment_sp",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSnapshotPolicyAttachmentResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.AttachSnapshotPolicy).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotPolicyAttachmentConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotPolicyAttachmentResourceSuspendError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.SuspendOrResumeStorageGroupSnapshotPolicy).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotPolicyAttachmentSuspendConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotPolicyAttachmentResourceImportError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        ProviderConfig + snapshotPolicyAttachmentConfig,
+				ResourceName:  "powermax_snapshot_policy_attachment.test",
+				ImportState:   true,
+				ImportStateId: "tfacc_sp_sg2",
+				ExpectError:   regexp.MustCompile(`.*import ID must be*.`),
+			},
+		},
+	})
+}
+
+var snapshotPolicyAttachmentPolicyConfig = `
+resource "powermax_snapshotpolicy" "attachment_sp" {
+	snapshot_policy_name = "tfacc_attachment_sp"
+	interval = "1 Day"
+}
+`
+
+var snapshotPolicyAttachmentConfig = snapshotPolicyAttachmentPolicyConfig + `
+resource "powermax_snapshot_policy_attachment" "test" {
+	storage_group_name = "tfacc_sp_sg2"
+	snapshot_policy_name = powermax_snapshotpolicy.attachment_sp.snapshot_policy_name
+}
+`
+
+var snapshotPolicyAttachmentSuspendConfig = snapshotPolicyAttachmentPolicyConfig + `
+resource "powermax_snapshot_policy_attachment" "test" {
+	storage_group_name = "tfacc_sp_sg2"
+	snapshot_policy_name = powermax_snapshotpolicy.attachment_sp.snapshot_policy_name
+	suspended = true
+}
+`
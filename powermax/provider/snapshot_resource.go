@@ -19,19 +19,24 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"strings"
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/constants"
 	"terraform-provider-powermax/powermax/helper"
 	"terraform-provider-powermax/powermax/models"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -39,6 +44,7 @@ import (
 var _ resource.Resource = &snapshotResource{}
 var _ resource.ResourceWithConfigure = &snapshotResource{}
 var _ resource.ResourceWithImportState = &snapshotResource{}
+var _ resource.ResourceWithUpgradeState = &snapshotResource{}
 
 // NewSnapshotResource is a helper function to simplify the provider implementation.
 func NewSnapshotResource() resource.Resource {
@@ -57,16 +63,23 @@ func (r *snapshotResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 // Schema Resource schema.
 func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = snapshotResourceSchema()
+	// Version 1 replaced the constant ID with the symmetrix_id/storage_group_name/snapshot_name/snapid ID
+	resp.Schema.Version = 1
+}
 
-	resp.Schema = schema.Schema{
+// snapshotResourceSchema returns the snapshot resource schema.
+func snapshotResourceSchema() schema.Schema {
+	return schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Resource for managing Snapshots in PowerMax array. PowerMax Snaphots is a local replication solution that is designed to nondisruptively create point-in-time copies (snapshots) of critical data. ",
 		Description:         "Resource for managing Snapshots in PowerMax array. PowerMax Snaphots is a local replication solution that is designed to nondisruptively create point-in-time copies (snapshots) of critical data.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Identifier",
-				Computed:    true,
+				Description:         "The ID of the snapshot, in the format 'symmetrix_id/storage_group_name/snapshot_name/snapid'.",
+				MarkdownDescription: "The ID of the snapshot, in the format 'symmetrix_id/storage_group_name/snapshot_name/snapid'.",
+				Computed:            true,
 			},
 
 			"name": schema.StringAttribute{
@@ -131,6 +144,74 @@ func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 					},
 				},
 			},
+			"source_volume_details": schema.ListNestedAttribute{
+				Description:         "The source volumes of the snapshot generation, each with the volumes it is linked to.",
+				MarkdownDescription: "The source volumes of the snapshot generation, each with the volumes it is linked to.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description:         "The name of the source volume",
+							MarkdownDescription: "The name of the source volume",
+							Computed:            true,
+						},
+						"capacity": schema.Int64Attribute{
+							Description:         "The capacity of the source volume in cylinders",
+							MarkdownDescription: "The capacity of the source volume in cylinders",
+							Computed:            true,
+						},
+						"capacity_gb": schema.Float64Attribute{
+							Description:         "The capacity of the source volume in GB",
+							MarkdownDescription: "The capacity of the source volume in GB",
+							Computed:            true,
+						},
+						"linked": schema.BoolAttribute{
+							Description:         "Set if the source volume is linked to a target volume",
+							MarkdownDescription: "Set if the source volume is linked to a target volume",
+							Computed:            true,
+						},
+						"linked_volumes": schema.ListNestedAttribute{
+							Description:         "The target volumes the source volume is linked to",
+							MarkdownDescription: "The target volumes the source volume is linked to",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"storage_group_name": schema.StringAttribute{
+										Description:         "The linked storage group name",
+										MarkdownDescription: "The linked storage group name",
+										Computed:            true,
+									},
+									"linked_volume_name": schema.StringAttribute{
+										Description:         "The linked volume name",
+										MarkdownDescription: "The linked volume name",
+										Computed:            true,
+									},
+									"tracks": schema.Int64Attribute{
+										Description:         "The number of tracks of the link",
+										MarkdownDescription: "The number of tracks of the link",
+										Computed:            true,
+									},
+									"track_size": schema.Int64Attribute{
+										Description:         "The size of the tracks",
+										MarkdownDescription: "The size of the tracks",
+										Computed:            true,
+									},
+									"percentage_copied": schema.Int64Attribute{
+										Description:         "The percentage of tracks copied to the linked volume",
+										MarkdownDescription: "The percentage of tracks copied to the linked volume",
+										Computed:            true,
+									},
+									"defined": schema.BoolAttribute{
+										Description:         "Set when the link has been fully defined",
+										MarkdownDescription: "Set when the link has been fully defined",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"num_storage_group_volumes": schema.Int64Attribute{
 				Description:         "The number of non-gatekeeper storage group volumes",
 				MarkdownDescription: "The number of non-gatekeeper storage group volumes",
@@ -244,6 +325,16 @@ func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				Optional:            true,
 			},
+			"delete_behavior": schema.StringAttribute{
+				Description:         "How the snapshot is deleted, defaults to `standard`. `standard` fails when the snapshot is still linked, `unlink` unlinks all the linked storage groups first and `unlink_after_copy` waits for the copy to the linked storage groups to complete before unlinking them, it fails right away for links which were not created in copy mode. An active restore session is always terminated and secure snapshots cannot be deleted before their secure time expires. (Update Supported)",
+				MarkdownDescription: "How the snapshot is deleted, defaults to `standard`. `standard` fails when the snapshot is still linked, `unlink` unlinks all the linked storage groups first and `unlink_after_copy` waits for the copy to the linked storage groups to complete before unlinking them, it fails right away for links which were not created in copy mode. An active restore session is always terminated and secure snapshots cannot be deleted before their secure time expires. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(helper.SnapshotDeleteStandard),
+				Validators: []validator.String{
+					stringvalidator.OneOf(helper.SnapshotDeleteStandard, helper.SnapshotDeleteUnlink, helper.SnapshotDeleteUnlinkAfterCopy),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"storage_group": schema.SingleNestedBlock{
@@ -264,8 +355,9 @@ func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 					},
 					"restore": schema.SingleNestedAttribute{
 						Optional:            true,
-						Description:         "Restore a snapshot generation. (Update Supported)",
-						MarkdownDescription: "Restore a snapshot generation. (Update Supported)",
+						DeprecationMessage:  "Use the powermax_snapshot_restore resource to restore a snapshot generation.",
+						Description:         "Restore a snapshot generation. Prefer the powermax_snapshot_restore resource, which confirms and guards the restore and terminates the restore session. (Update Supported)",
+						MarkdownDescription: "Restore a snapshot generation. Prefer the powermax_snapshot_restore resource, which confirms and guards the restore and terminates the restore session. (Update Supported)",
 						Attributes: map[string]schema.Attribute{
 							"enable": schema.BoolAttribute{
 								Description:         "enable defaults to false. Flag to enable restore on the snapshot",
@@ -322,6 +414,37 @@ func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 								Computed:            true,
 								Default:             booldefault.StaticBool(false),
 							},
+							"relink": schema.BoolAttribute{
+								Description:         "relink defaults to false. If true, the target storage group is relinked to this snapshot generation from the generation it is currently linked to, without unlinking it first.",
+								MarkdownDescription: "relink defaults to false. If true, the target storage group is relinked to this snapshot generation from the generation it is currently linked to, without unlinking it first.",
+								Optional:            true,
+								Computed:            true,
+								Default:             booldefault.StaticBool(false),
+							},
+							"wait_for_copy": schema.BoolAttribute{
+								Description:         "wait_for_copy defaults to false. If true, waits until the background copy to the target volume(s) has completed. Can only be set when copy is true.",
+								MarkdownDescription: "wait_for_copy defaults to false. If true, waits until the background copy to the target volume(s) has completed. Can only be set when copy is true.",
+								Optional:            true,
+								Computed:            true,
+								Default:             booldefault.StaticBool(false),
+							},
+							"wait_for_defined": schema.BoolAttribute{
+								Description:         "wait_for_defined defaults to false. If true, waits until the link to the target volume(s) has been fully defined.",
+								MarkdownDescription: "wait_for_defined defaults to false. If true, waits until the link to the target volume(s) has been fully defined.",
+								Optional:            true,
+								Computed:            true,
+								Default:             booldefault.StaticBool(false),
+							},
+							"wait_timeout_minutes": schema.Int64Attribute{
+								Description:         "wait_timeout_minutes defaults to 60. The number of minutes to wait for the link to be copied or defined before failing.",
+								MarkdownDescription: "wait_timeout_minutes defaults to 60. The number of minutes to wait for the link to be copied or defined before failing.",
+								Optional:            true,
+								Computed:            true,
+								Default:             int64default.StaticInt64(60),
+								Validators: []validator.Int64{
+									int64validator.AtLeast(1),
+								},
+							},
 						},
 					},
 					"time_to_live": schema.SingleNestedAttribute{
@@ -402,6 +525,40 @@ func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 	}
 }
 
+// UpgradeState upgrades the snapshot state from the prior schema versions.
+func (r *snapshotResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 used the constant "snapshot-resource" ID and had none of the link wait, relink,
+		// delete_behavior and source_volume_details attributes, so its raw JSON is upgraded
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if r.client == nil {
+					resp.Diagnostics.AddError(
+						"Error upgrading snapshot state",
+						"The provider must be configured to upgrade the snapshot state",
+					)
+					return
+				}
+				var rawState map[string]interface{}
+				if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+					resp.Diagnostics.AddError("Error upgrading snapshot state", err.Error())
+					return
+				}
+				if err := helper.UpgradeSnapshotResourceRawState(r.client.SymmetrixID, rawState); err != nil {
+					resp.Diagnostics.AddError("Error upgrading snapshot state", err.Error())
+					return
+				}
+				upgradedState, err := json.Marshal(rawState)
+				if err != nil {
+					resp.Diagnostics.AddError("Error upgrading snapshot state", err.Error())
+					return
+				}
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgradedState}
+			},
+		},
+	}
+}
+
 // Configure the resource.
 func (r *snapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -463,6 +620,12 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// Link the new snapshot, the snapshot exists at this point so the error is reported once it is saved to the state
+	var linkErr error
+	if plan.Snapshot.Link != nil && plan.Snapshot.Link.Enable.ValueBool() {
+		linkErr = helper.LinkSnapshot(ctx, *r.client, plan.StorageGroup.Name.ValueString(), &plan, val.Snapids[0])
+	}
+
 	// Get the new Snapshot
 	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *r.client, plan.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString(), val.Snapids[0])
 	if err != nil {
@@ -482,7 +645,8 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 		)
 		return
 	}
-	state.ID = types.StringValue("snapshot-resource")
+	state.ID = types.StringValue(helper.SnapshotResourceID(r.client.SymmetrixID, plan.StorageGroup.Name.ValueString(), state.Name.ValueString(), state.Snapid.ValueInt64()))
+	state.DeleteBehavior = plan.DeleteBehavior
 	state.StorageGroup = plan.StorageGroup
 	state.Snapshot = plan.Snapshot
 	// Save plan into Terraform state
@@ -490,6 +654,14 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if linkErr != nil {
+		errStr := fmt.Sprintf("Could not link snapshot %s with error:", plan.Snapshot.Name)
+		msgStr := helper.GetErrorString(linkErr, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating snapshot",
+			msgStr,
+		)
+	}
 }
 
 // Read a snapshot.
@@ -519,6 +691,7 @@ func (r *snapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 		)
 		return
 	}
+	state.ID = types.StringValue(helper.SnapshotResourceID(r.client.SymmetrixID, state.StorageGroup.Name.ValueString(), state.Name.ValueString(), state.Snapid.ValueInt64()))
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -572,6 +745,8 @@ func (r *snapshotResource) Update(ctx context.Context, req resource.UpdateReques
 		)
 		return
 	}
+	state.ID = types.StringValue(helper.SnapshotResourceID(r.client.SymmetrixID, plan.StorageGroup.Name.ValueString(), state.Name.ValueString(), state.Snapid.ValueInt64()))
+	state.DeleteBehavior = plan.DeleteBehavior
 	state.StorageGroup = plan.StorageGroup
 	state.Snapshot = plan.Snapshot
 	// Save plan into Terraform state
@@ -591,8 +766,7 @@ func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	deleteParam := r.client.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotSnapID(ctx, r.client.SymmetrixID, state.StorageGroup.Name.ValueString(), state.Name.ValueString(), state.Snapid.ValueInt64())
-	_, err := deleteParam.Execute()
+	err := helper.DeleteSnapshot(ctx, *r.client, &state)
 	if err != nil {
 		errStr := fmt.Sprintf("Could not delete snapshot %s with error:", state.Name)
 		msgStr := helper.GetErrorString(err, errStr)
@@ -607,26 +781,7 @@ func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 // ImportState imports a Snapshot.
 func (r *snapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Info(ctx, "importing snapshot")
-	id := req.ID
-	ids := strings.Split(id, ".")
-	tflog.Info(ctx, fmt.Sprintf("id: %s ids %v length %v", id, ids, len(ids)))
-	sgName := ""
-	snapshotName := ""
-	if len(ids) >= 2 {
-		sgName = ids[0]
-		snapshotName = ids[1]
-	} else {
-		resp.Diagnostics.AddError(
-			"Error importing snapshot",
-			"The import ID must be 'storage_group_name.snapshot_name'",
-		)
-		return
-	}
-
-	var state models.SnapshotResourceModel
-	// Get the snapID Id
-	snapIDParam := r.client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupSnapshotSnapIDs(ctx, r.client.SymmetrixID, sgName, snapshotName)
-	val, _, err := snapIDParam.Execute()
+	sgName, snapshotName, snapID, err := helper.ParseSnapshotImportID(ctx, *r.client, req.ID)
 	if err != nil {
 		errStr := constants.ReadSnapshots + " with error: "
 		message := helper.GetErrorString(err, errStr)
@@ -636,10 +791,13 @@ func (r *snapshotResource) ImportState(ctx context.Context, req resource.ImportS
 		)
 		return
 	}
+	tflog.Info(ctx, fmt.Sprintf("id: %s storage group: %s snapshot: %s snapid: %d", req.ID, sgName, snapshotName, snapID))
+
+	var state models.SnapshotResourceModel
 	// Get the details
-	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *r.client, sgName, snapshotName, val.Snapids[0])
+	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *r.client, sgName, snapshotName, snapID)
 	if err != nil {
-		errStr := fmt.Sprintf("Could not find snapshot %s with error:", state.Name)
+		errStr := fmt.Sprintf("Could not find snapshot %s with error:", snapshotName)
 		msgStr := helper.GetErrorString(err, errStr)
 		resp.Diagnostics.AddError(
 			"Error importing snapshot",
@@ -655,7 +813,8 @@ func (r *snapshotResource) ImportState(ctx context.Context, req resource.ImportS
 		)
 		return
 	}
-	state.ID = types.StringValue("snapshot-resource")
+	state.ID = types.StringValue(helper.SnapshotResourceID(r.client.SymmetrixID, sgName, snapshotName, snapID))
+	state.DeleteBehavior = types.StringValue(helper.SnapshotDeleteStandard)
 	state.Snapshot = &models.SnapshotResourceFields{
 		Name:      basetypes.NewStringValue(snapshotName),
 		Bothsides: basetypes.NewBoolValue(false),
@@ -18,13 +18,21 @@ limitations under the License.
 package provider
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 	"regexp"
+	"strings"
+	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/helper"
 	"testing"
 
 	. "github.com/bytedance/mockey"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccSnapshotResourceA(t *testing.T) {
@@ -51,6 +59,29 @@ func TestAccSnapshotResourceA(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// Import a specific generation
+			{
+				ResourceName:      snapshotTerraformName,
+				ImportStateId:     "tfacc_sg_snapshot.tfacc_snapshot_1.generation:0",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import by the resource ID
+			{
+				ResourceName:      snapshotTerraformName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import a specific snapid
+			{
+				ResourceName: snapshotTerraformName,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[snapshotTerraformName]
+					return fmt.Sprintf("tfacc_sg_snapshot.tfacc_snapshot_1.snapid:%s", rs.Primary.Attributes["snapid"]), nil
+				},
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			// Update Link and Rename
 			{
 				Config: ProviderConfig + SnapshotResourceLinkConfig,
@@ -157,6 +188,171 @@ func TestAccSnapshotResourceModifyError(t *testing.T) {
 	})
 }
 
+func TestAccSnapshotResourceCreateLinkWait(t *testing.T) {
+	var snapshotTerraformName = "powermax_snapshot.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with link and wait for the link to be defined
+			{
+				Config: ProviderConfig + SnapshotResourceLinkWaitConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotTerraformName, "name", "tfacc_snapshot_wait"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "linked_storage_group.#", "1"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "linked", "true"),
+					resource.TestCheckResourceAttrPair(snapshotTerraformName, "source_volume_details.#", snapshotTerraformName, "source_volume.#"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "source_volume_details.0.linked", "true"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "source_volume_details.0.linked_volumes.0.defined", "true"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "snapshot_actions.link.wait_for_defined", "true"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "snapshot_actions.link.wait_timeout_minutes", "10"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSnapshotResourceLinkWaitError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.WaitForSnapshotLink).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + SnapshotResourceLinkWaitConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotResourceWaitForCopyError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + SnapshotResourceWaitForCopyNoCopyConfig,
+				ExpectError: regexp.MustCompile(`.*wait_for_copy*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotResourceDeleteUnlink(t *testing.T) {
+	var snapshotTerraformName = "powermax_snapshot.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a linked snapshot, the delete unlinks it first
+			{
+				Config: ProviderConfig + SnapshotResourceDeleteUnlinkConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotTerraformName, "linked", "true"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "delete_behavior", "unlink"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSnapshotResourceDeleteUnlinkAfterCopyNoCopy(t *testing.T) {
+	var snapshotTerraformName = "powermax_snapshot.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + SnapshotResourceUnlinkAfterCopyNoCopyConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotTerraformName, "linked", "true"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "delete_behavior", "unlink_after_copy"),
+				),
+			},
+			// A nocopy link fails the delete right away instead of waiting for a copy which never completes
+			{
+				Config:      ProviderConfig,
+				ExpectError: regexp.MustCompile(`.*without copy and its copy will never complete*.`),
+			},
+			// The snapshot is kept, switching to unlink lets the delete in TestCase clean it up
+			{
+				Config: ProviderConfig + strings.Replace(SnapshotResourceUnlinkAfterCopyNoCopyConfig, `"unlink_after_copy"`, `"unlink"`, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotTerraformName, "delete_behavior", "unlink"),
+				),
+			},
+		},
+	})
+}
+
+func TestSnapshotResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &snapshotResource{client: &client.Client{SymmetrixID: "000120001234"}}
+	req := fwresource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(snapshotResourceStateV0)}}
+	resp := fwresource.UpgradeStateResponse{}
+	r.UpgradeState(ctx)[0].StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected upgrade error: %v", resp.Diagnostics)
+	}
+
+	// The upgraded state must be a valid version 1 state
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	upgraded, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("the upgraded state does not match the schema: %s", err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := upgraded.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	var id, deleteBehavior string
+	if err := attrs["id"].As(&id); err != nil {
+		t.Fatal(err)
+	}
+	if id != "000120001234/tfacc_sg_snapshot/tfacc_snapshot/3" {
+		t.Errorf("expected the composite ID, got %s", id)
+	}
+	if err := attrs["delete_behavior"].As(&deleteBehavior); err != nil {
+		t.Fatal(err)
+	}
+	if deleteBehavior != helper.SnapshotDeleteStandard {
+		t.Errorf("expected delete_behavior %s, got %s", helper.SnapshotDeleteStandard, deleteBehavior)
+	}
+	var actions, link map[string]tftypes.Value
+	if err := attrs["snapshot_actions"].As(&actions); err != nil {
+		t.Fatal(err)
+	}
+	if err := actions["link"].As(&link); err != nil {
+		t.Fatal(err)
+	}
+	var waitTimeout = new(big.Float)
+	if err := link["wait_timeout_minutes"].As(&waitTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if minutes, _ := waitTimeout.Int64(); minutes != 60 {
+		t.Errorf("expected wait_timeout_minutes 60, got %d", minutes)
+	}
+}
+
+func TestAccSnapshotResourceDeleteBehaviorError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + SnapshotResourceDeleteBehaviorErrorConfig,
+				ExpectError: regexp.MustCompile(`.*Attribute delete_behavior value must be one of*.`),
+			},
+		},
+	})
+}
+
 func TestAccSnapshotResourceSGNameError(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -247,6 +443,13 @@ func TestAccSnapshotResourceImportError(t *testing.T) {
 				ExpectError:   regexp.MustCompile(`.*Error importing snapshot*.`),
 				ImportStateId: "badsnapshot",
 			},
+			{
+				Config:        ProviderConfig + SnapshotResourceConfigSgNameError,
+				ResourceName:  "powermax_snapshot.test",
+				ImportState:   true,
+				ExpectError:   regexp.MustCompile(`.*Error importing snapshot*.`),
+				ImportStateId: "tfacc_sg_snapshot.tfacc_snapshot_1.generation:abc",
+			},
 			{
 				PreConfig: func() {
 					FunctionMocker = Mock(helper.GetSnapshotSnapIDSG).Return(nil, nil, fmt.Errorf("mock error")).Build()
@@ -338,6 +541,107 @@ resource "powermax_snapshot" "test" {
 }
 `
 
+var SnapshotResourceLinkWaitConfig = `
+resource "powermax_snapshot" "test" {
+	storage_group {
+		name = "tfacc_sg_snapshot"
+	}
+	snapshot_actions {
+		name = "tfacc_snapshot_wait"
+		link = {
+		   enable = true
+		   target_storage_group = "tfacc_test_target_snapshot_sg"
+		   no_compression = true
+		   wait_for_defined = true
+		   wait_timeout_minutes = 10
+		}
+		time_to_live = {
+		   enable = true
+		   time_in_hours = true
+		   time_to_live = 1
+		}
+	}
+}
+`
+
+var SnapshotResourceWaitForCopyNoCopyConfig = `
+resource "powermax_snapshot" "test" {
+	storage_group {
+		name = "tfacc_sg_snapshot"
+	}
+	snapshot_actions {
+		name = "tfacc_snapshot_wait_copy"
+		link = {
+		   enable = true
+		   target_storage_group = "tfacc_test_target_snapshot_sg"
+		   copy = false
+		   wait_for_copy = true
+		}
+		time_to_live = {
+		   enable = true
+		   time_in_hours = true
+		   time_to_live = 1
+		}
+	}
+}
+`
+
+var SnapshotResourceDeleteUnlinkConfig = `
+resource "powermax_snapshot" "test" {
+	delete_behavior = "unlink"
+	storage_group {
+		name = "tfacc_sg_snapshot"
+	}
+	snapshot_actions {
+		name = "tfacc_snapshot_delete_unlink"
+		link = {
+		   enable = true
+		   target_storage_group = "tfacc_test_target_snapshot_sg"
+		   no_compression = true
+		}
+		time_to_live = {
+		   enable = true
+		   time_in_hours = true
+		   time_to_live = 1
+		}
+	}
+}
+`
+
+var SnapshotResourceUnlinkAfterCopyNoCopyConfig = `
+resource "powermax_snapshot" "test" {
+	delete_behavior = "unlink_after_copy"
+	storage_group {
+		name = "tfacc_sg_snapshot"
+	}
+	snapshot_actions {
+		name = "tfacc_snapshot_unlink_nocopy"
+		link = {
+		   enable = true
+		   target_storage_group = "tfacc_test_target_snapshot_sg"
+		   copy = false
+		}
+		time_to_live = {
+		   enable = true
+		   time_in_hours = true
+		   time_to_live = 1
+		}
+	}
+}
+`
+
+var SnapshotResourceDeleteBehaviorErrorConfig = `
+resource "powermax_snapshot" "test" {
+	delete_behavior = "force"
+	storage_group {
+		name = "tfacc_sg_snapshot"
+	}
+	snapshot_actions {
+		name = "tfacc_snapshot_delete_error"
+	}
+}
+`
+
 var SnapshotResourceUnlinkConfig = `
 resource "powermax_snapshot" "test" {
 	storage_group {
@@ -383,3 +687,53 @@ resource "powermax_snapshot" "test" {
 	}
 }
 `
+
+// snapshotResourceStateV0 is a snapshot state written by version 0 of the schema
+var snapshotResourceStateV0 = `{
+	"id": "snapshot-resource",
+	"name": "tfacc_snapshot",
+	"generation": 0,
+	"snapid": 3,
+	"timestamp": "Mon Jul 17 10:15:02 2023",
+	"timestamp_utc": "Mon Jul 17 10:15:02 UTC 2023",
+	"state": ["Established"],
+	"num_source_volumes": 1,
+	"source_volume": [{"name": "0012A", "capacity": 547, "capacity_gb": 1.0}],
+	"num_storage_group_volumes": 1,
+	"tracks": 0,
+	"non_shared_tracks": 0,
+	"time_to_live_expiry_date": "Mon Jul 17 11:15:02 2023",
+	"secure_expiry_date": "",
+	"expired": false,
+	"linked": true,
+	"restored": false,
+	"linked_storage_group_names": ["tfacc_test_target_snapshot_sg"],
+	"linked_storage_group": [{
+		"name": "tfacc_test_target_snapshot_sg",
+		"source_volume_name": "0012A",
+		"linked_volume_name": "0012B",
+		"tracks": 0,
+		"track_size": 128,
+		"percentage_copied": 0,
+		"linked_creation_timestamp": "Mon Jul 17 10:15:10 2023",
+		"defined": false,
+		"background_define_in_progress": false
+	}],
+	"persistent": false,
+	"storage_group": {"name": "tfacc_sg_snapshot"},
+	"snapshot_actions": {
+		"name": "tfacc_snapshot",
+		"restore": null,
+		"link": {
+			"target_storage_group": "tfacc_test_target_snapshot_sg",
+			"enable": true,
+			"no_compression": true,
+			"remote": false,
+			"copy": false
+		},
+		"time_to_live": {"enable": true, "time_in_hours": true, "time_to_live": 1},
+		"secure": null,
+		"both_sides": false,
+		"remote": false
+	}
+}`
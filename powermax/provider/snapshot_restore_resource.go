@@ -0,0 +1,376 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &snapshotRestoreResource{}
+	_ resource.ResourceWithConfigure      = &snapshotRestoreResource{}
+	_ resource.ResourceWithValidateConfig = &snapshotRestoreResource{}
+)
+
+// NewSnapshotRestoreResource is a helper function to simplify the provider implementation.
+func NewSnapshotRestoreResource() resource.Resource {
+	return &snapshotRestoreResource{}
+}
+
+// snapshotRestoreResource is the resource implementation.
+type snapshotRestoreResource struct {
+	client *client.Client
+}
+
+// Metadata returns the resource type name.
+func (r *snapshotRestoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_restore"
+}
+
+// Schema defines the schema for the resource.
+func (r *snapshotRestoreResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	windowTime := []validator.String{
+		stringvalidator.RegexMatches(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`), "must be a UTC time in the HH:MM format"),
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for restoring a snapshot generation to its storage group in PowerMax array. The restore overwrites the data of the storage group, it is performed once when the resource is created and must be confirmed with the storage group name. The create waits for the restore to complete and then terminates the restore session.",
+		Description:         "Resource for restoring a snapshot generation to its storage group in PowerMax array. The restore overwrites the data of the storage group, it is performed once when the resource is created and must be confirmed with the storage group name. The create waits for the restore to complete and then terminates the restore session.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "The ID of the snapshot restore, in the format 'symmetrix_id/storage_group_name/snapshot_name/snapid'.",
+				MarkdownDescription: "The ID of the snapshot restore, in the format 'symmetrix_id/storage_group_name/snapshot_name/snapid'.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_group_name": schema.StringAttribute{
+				Description:         "The name of the storage group the snapshot is restored to.",
+				MarkdownDescription: "The name of the storage group the snapshot is restored to.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_name": schema.StringAttribute{
+				Description:         "The name of the snapshot to restore.",
+				MarkdownDescription: "The name of the snapshot to restore.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapid": schema.Int64Attribute{
+				Description:         "The snap ID of the snapshot generation to restore.",
+				MarkdownDescription: "The snap ID of the snapshot generation to restore.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"confirm": schema.StringAttribute{
+				Description:         "Must be set to the storage group name to confirm that the data of the storage group will be overwritten by the snapshot. (Update Supported)",
+				MarkdownDescription: "Must be set to the storage group name to confirm that the data of the storage group will be overwritten by the snapshot. (Update Supported)",
+				Required:            true,
+			},
+			"remote": schema.BoolAttribute{
+				Description:         "Acknowledges that the data will be propagated to the remote mirror of the SRDF volumes, defaults to false.",
+				MarkdownDescription: "Acknowledges that the data will be propagated to the remote mirror of the SRDF volumes, defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"require_unmasked": schema.BoolAttribute{
+				Description:         "Refuse the restore when the storage group is in a masking view, so that it is not overwritten while a host can access it, defaults to true. (Update Supported)",
+				MarkdownDescription: "Refuse the restore when the storage group is in a masking view, so that it is not overwritten while a host can access it, defaults to true. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"restore_window": schema.SingleNestedAttribute{
+				Description:         "The UTC time window in which the restore is allowed, for example the quiesce window of the application. The window wraps around midnight when end is before start. (Update Supported)",
+				MarkdownDescription: "The UTC time window in which the restore is allowed, for example the quiesce window of the application. The window wraps around midnight when end is before start. (Update Supported)",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"start": schema.StringAttribute{
+						Description:         "The UTC start of the window, in the HH:MM format.",
+						MarkdownDescription: "The UTC start of the window, in the HH:MM format.",
+						Required:            true,
+						Validators:          windowTime,
+					},
+					"end": schema.StringAttribute{
+						Description:         "The UTC end of the window, in the HH:MM format.",
+						MarkdownDescription: "The UTC end of the window, in the HH:MM format.",
+						Required:            true,
+						Validators:          windowTime,
+					},
+				},
+			},
+			"wait_timeout_minutes": schema.Int64Attribute{
+				Description:         "The number of minutes to wait for the restore to complete, defaults to 60. (Update Supported)",
+				MarkdownDescription: "The number of minutes to wait for the restore to complete, defaults to 60. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(60),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"terminate_after_restore": schema.BoolAttribute{
+				Description:         "Terminate the restore session once the restore has completed, defaults to true. When false the restore session is terminated when the resource is destroyed. (Update Supported)",
+				MarkdownDescription: "Terminate the restore session once the restore has completed, defaults to true. When false the restore session is terminated when the resource is destroyed. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"restore_completed_at": schema.StringAttribute{
+				Description:         "When the restore completed.",
+				MarkdownDescription: "When the restore completed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"session_terminated": schema.BoolAttribute{
+				Description:         "Set once the restore session has been terminated.",
+				MarkdownDescription: "Set once the restore session has been terminated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *snapshotRestoreResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that the restore is confirmed with the storage group name.
+func (r *snapshotRestoreResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config models.SnapshotRestoreResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Confirm.IsUnknown() || config.StorageGroupName.IsUnknown() {
+		return
+	}
+	if config.Confirm.ValueString() != config.StorageGroupName.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("confirm"),
+			"Snapshot restore not confirmed",
+			fmt.Sprintf("confirm must be set to the storage group name %s to restore the snapshot over its data", config.StorageGroupName.ValueString()),
+		)
+	}
+}
+
+// checkRestoreGuards checks that the storage group can be restored to now.
+func (r *snapshotRestoreResource) checkRestoreGuards(ctx context.Context, plan models.SnapshotRestoreResourceModel) error {
+	if plan.RestoreWindow != nil {
+		inWindow, err := helper.InRestoreWindow(time.Now(), plan.RestoreWindow.Start.ValueString(), plan.RestoreWindow.End.ValueString())
+		if err != nil {
+			return err
+		}
+		if !inWindow {
+			return fmt.Errorf("the restore is only allowed between %s and %s UTC", plan.RestoreWindow.Start.ValueString(), plan.RestoreWindow.End.ValueString())
+		}
+	}
+	if plan.RequireUnmasked.ValueBool() {
+		storageGroup, _, err := helper.GetStorageGroup(ctx, *r.client, plan.StorageGroupName.ValueString())
+		if err != nil {
+			return err
+		}
+		if len(storageGroup.Maskingview) > 0 {
+			return fmt.Errorf("storage group %s is in masking view(s) %s, remove it from them or set require_unmasked to false", plan.StorageGroupName.ValueString(), strings.Join(storageGroup.Maskingview, ", "))
+		}
+	}
+	return nil
+}
+
+// Create restores the snapshot, waits for the restore to complete and terminates the restore session.
+func (r *snapshotRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating snapshot restore")
+	var plan models.SnapshotRestoreResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sgName := plan.StorageGroupName.ValueString()
+	snapshotName := plan.SnapshotName.ValueString()
+	snapID := plan.Snapid.ValueInt64()
+	errStr := constants.RestoreSnapshotDetailErrorMsg + snapshotName + " with error: "
+	err := r.checkRestoreGuards(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring snapshot", helper.GetErrorString(err, errStr))
+		return
+	}
+	_, _, err = helper.GetSnapshotSnapIDSG(ctx, *r.client, sgName, snapshotName, snapID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring snapshot", helper.GetErrorString(err, errStr))
+		return
+	}
+	err = helper.RestoreSnapshot(ctx, *r.client, sgName, snapshotName, snapID, plan.Remote.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring snapshot", helper.GetErrorString(err, errStr))
+		return
+	}
+
+	// The restore session exists from here, the state is saved even on failure so that destroy terminates it
+	plan.ID = types.StringValue(helper.SnapshotResourceID(r.client.SymmetrixID, sgName, snapshotName, snapID))
+	plan.SessionTerminated = types.BoolValue(false)
+	plan.RestoreCompletedAt = types.StringNull()
+	timeout := time.Duration(plan.WaitTimeoutMinutes.ValueInt64()) * time.Minute
+	err = helper.WaitForSnapshotRestore(ctx, *r.client, sgName, snapshotName, snapID, timeout)
+	if err == nil {
+		plan.RestoreCompletedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+		if plan.TerminateAfterRestore.ValueBool() {
+			err = helper.TerminateSnapshotRestore(ctx, *r.client, sgName, snapshotName, snapID)
+			if err != nil {
+				errStr = constants.TerminateSnapshotRestoreDetailErrorMsg + snapshotName + " with error: "
+			} else {
+				plan.SessionTerminated = types.BoolValue(true)
+			}
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring snapshot", helper.GetErrorString(err, errStr))
+		return
+	}
+	tflog.Info(ctx, "create snapshot restore completed")
+}
+
+// Read keeps the state, the restore is a one time operation.
+func (r *snapshotRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading snapshot restore")
+	var state models.SnapshotRestoreResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "read snapshot restore completed")
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Supported updates: confirm, require_unmasked, restore_window, wait_timeout_minutes, terminate_after_restore.
+// None of them restore the snapshot again, the restore session is terminated when terminate_after_restore is set.
+func (r *snapshotRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating snapshot restore")
+	var plan, state models.SnapshotRestoreResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.SessionTerminated = state.SessionTerminated
+	if plan.TerminateAfterRestore.ValueBool() && !state.SessionTerminated.ValueBool() {
+		err := helper.TerminateSnapshotRestore(ctx, *r.client, state.StorageGroupName.ValueString(), state.SnapshotName.ValueString(), state.Snapid.ValueInt64())
+		if err != nil {
+			errStr := constants.TerminateSnapshotRestoreDetailErrorMsg + state.SnapshotName.ValueString() + " with error: "
+			resp.Diagnostics.AddError("Error updating snapshot restore", helper.GetErrorString(err, errStr))
+			return
+		}
+		plan.SessionTerminated = types.BoolValue(true)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "update snapshot restore completed")
+}
+
+// Delete terminates the restore session if it is still active and removes the Terraform state on success.
+func (r *snapshotRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting snapshot restore")
+	var state models.SnapshotRestoreResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.SessionTerminated.ValueBool() {
+		err := helper.TerminateSnapshotRestore(ctx, *r.client, state.StorageGroupName.ValueString(), state.SnapshotName.ValueString(), state.Snapid.ValueInt64())
+		if err != nil {
+			errStr := constants.TerminateSnapshotRestoreDetailErrorMsg + state.SnapshotName.ValueString() + " with error: "
+			resp.Diagnostics.AddError("Error deleting snapshot restore", helper.GetErrorString(err, errStr))
+			return
+		}
+	}
+	tflog.Info(ctx, "delete snapshot restore completed")
+}
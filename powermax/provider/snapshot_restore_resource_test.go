@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSnapshotRestoreResource(t *testing.T) {
+	var snapshotRestoreTerraformName = "powermax_snapshot_restore.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + snapshotRestoreConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotRestoreTerraformName, "storage_group_name", "tfacc_sg_snapshot"),
+					resource.TestCheckResourceAttr(snapshotRestoreTerraformName, "session_terminated", "true"),
+					resource.TestCheckResourceAttrSet(snapshotRestoreTerraformName, "restore_completed_at"),
+					resource.TestCheckResourceAttrPair(snapshotRestoreTerraformName, "snapid", "powermax_snapshot.test", "snapid"),
+				),
+			},
+			// Update does not restore again
+			{
+				Config: ProviderConfig + snapshotRestoreUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotRestoreTerraformName, "wait_timeout_minutes", "30"),
+					resource.TestCheckResourceAttr(snapshotRestoreTerraformName, "session_terminated", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotRestoreResourceConfirmError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + snapshotRestoreConfirmErrorConfig,
+				ExpectError: regexp.MustCompile(`.*confirm must be set to the storage group name*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotRestoreResourceWindowError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + snapshotRestoreWindowErrorConfig,
+				ExpectError: regexp.MustCompile(`.*HH:MM*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotRestoreResourceMaskedError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetStorageGroup).Return(&pmax.StorageGroup{StorageGroupId: "tfacc_sg_restore", Maskingview: []string{"tfacc_mv"}}, nil, nil).Build()
+				},
+				Config:      ProviderConfig + snapshotRestoreConfig,
+				ExpectError: regexp.MustCompile(`.*is in masking view*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotRestoreResourceRestoreError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.RestoreSnapshot).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotRestoreConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotRestoreResourceWaitError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.WaitForSnapshotRestore).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotRestoreConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+var snapshotRestoreSnapshotConfig = `
+resource "powermax_snapshot" "test" {
+	storage_group {
+		name = "tfacc_sg_snapshot"
+	}
+	snapshot_actions {
+		name = "tfacc_snapshot_restore"
+	}
+}
+`
+
+var snapshotRestoreConfig = snapshotRestoreSnapshotConfig + `
+resource "powermax_snapshot_restore" "test" {
+	storage_group_name = "tfacc_sg_snapshot"
+	snapshot_name = powermax_snapshot.test.name
+	snapid = powermax_snapshot.test.snapid
+	confirm = "tfacc_sg_snapshot"
+}
+`
+
+var snapshotRestoreUpdateConfig = snapshotRestoreSnapshotConfig + `
+resource "powermax_snapshot_restore" "test" {
+	storage_group_name = "tfacc_sg_snapshot"
+	snapshot_name = powermax_snapshot.test.name
+	snapid = powermax_snapshot.test.snapid
+	confirm = "tfacc_sg_snapshot"
+	wait_timeout_minutes = 30
+}
+`
+
+var snapshotRestoreConfirmErrorConfig = `
+resource "powermax_snapshot_restore" "test" {
+	storage_group_name = "tfacc_sg_snapshot"
+	snapshot_name = "tfacc_snapshot_restore"
+	snapid = 0
+	confirm = "yes"
+}
+`
+
+var snapshotRestoreWindowErrorConfig = `
+resource "powermax_snapshot_restore" "test" {
+	storage_group_name = "tfacc_sg_snapshot"
+	snapshot_name = "tfacc_snapshot_restore"
+	snapid = 0
+	confirm = "tfacc_sg_snapshot"
+	restore_window = {
+		start = "1:00"
+		end = "03:00"
+	}
+}
+`
@@ -0,0 +1,421 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &snapshotSetResource{}
+	_ resource.ResourceWithConfigure   = &snapshotSetResource{}
+	_ resource.ResourceWithImportState = &snapshotSetResource{}
+	_ resource.ResourceWithModifyPlan  = &snapshotSetResource{}
+)
+
+// NewSnapshotSetResource is a helper function to simplify the provider implementation.
+func NewSnapshotSetResource() resource.Resource {
+	return &snapshotSetResource{}
+}
+
+// snapshotSetResource is the resource implementation.
+type snapshotSetResource struct {
+	client *client.Client
+}
+
+// Metadata returns the resource type name.
+func (r *snapshotSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *snapshotSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for managing rolling generations of a snapshot of a storage group in PowerMax array. Each apply creates a new generation of the snapshot and terminates the oldest generations beyond the retention count.",
+		Description:         "Resource for managing rolling generations of a snapshot of a storage group in PowerMax array. Each apply creates a new generation of the snapshot and terminates the oldest generations beyond the retention count.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "The ID of the snapshot set, in the format 'storage_group_name.snapshot_name'.",
+				MarkdownDescription: "The ID of the snapshot set, in the format 'storage_group_name.snapshot_name'.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_group_name": schema.StringAttribute{
+				Description:         "The name of the storage group the snapshot generations are taken of.",
+				MarkdownDescription: "The name of the storage group the snapshot generations are taken of.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_name": schema.StringAttribute{
+				Description:         "The name of the snapshot. Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed.",
+				MarkdownDescription: "The name of the snapshot. Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"retention_count": schema.Int64Attribute{
+				Description:         "The number of generations to keep. The oldest generations beyond this count are terminated. (Update Supported)",
+				MarkdownDescription: "The number of generations to keep. The oldest generations beyond this count are terminated. (Update Supported)",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"time_to_live": schema.Int64Attribute{
+				Description:         "The time to live of the new generations, in days unless time_in_hours is set. (Update Supported)",
+				MarkdownDescription: "The time to live of the new generations, in days unless time_in_hours is set. (Update Supported)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"time_in_hours": schema.BoolAttribute{
+				Description:         "Set to true if the time_to_live is in hours instead of days, defaults to false. (Update Supported)",
+				MarkdownDescription: "Set to true if the time_to_live is in hours instead of days, defaults to false. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"rotate_on_apply": schema.BoolAttribute{
+				Description:         "Set to true to create a new generation on each apply, defaults to true. When false, a new generation is only created when triggers change. (Update Supported)",
+				MarkdownDescription: "Set to true to create a new generation on each apply, defaults to true. When false, a new generation is only created when triggers change. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"triggers": schema.MapAttribute{
+				Description:         "Arbitrary values which create a new generation when they change, for example the version of an application about to be upgraded. (Update Supported)",
+				MarkdownDescription: "Arbitrary values which create a new generation when they change, for example the version of an application about to be upgraded. (Update Supported)",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"latest_snapid": schema.Int64Attribute{
+				Description:         "The snap ID of the newest generation.",
+				MarkdownDescription: "The snap ID of the newest generation.",
+				Computed:            true,
+			},
+			"generations": schema.ListNestedAttribute{
+				Description:         "The generations of the snapshot, newest first.",
+				MarkdownDescription: "The generations of the snapshot, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"generation": schema.Int64Attribute{
+							Description:         "The generation number of the snapshot, 0 is the newest.",
+							MarkdownDescription: "The generation number of the snapshot, 0 is the newest.",
+							Computed:            true,
+						},
+						"snapid": schema.Int64Attribute{
+							Description:         "The unique snap ID of the generation.",
+							MarkdownDescription: "The unique snap ID of the generation.",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description:         "The timestamp of the generation.",
+							MarkdownDescription: "The timestamp of the generation.",
+							Computed:            true,
+						},
+						"timestamp_utc": schema.Int64Attribute{
+							Description:         "The timestamp of the generation in milliseconds since 1970.",
+							MarkdownDescription: "The timestamp of the generation in milliseconds since 1970.",
+							Computed:            true,
+						},
+						"time_to_live_expiry_date": schema.StringAttribute{
+							Description:         "When the generation will expire once it is not linked.",
+							MarkdownDescription: "When the generation will expire once it is not linked.",
+							Computed:            true,
+						},
+						"expired": schema.BoolAttribute{
+							Description:         "Set if the secure time of the generation has expired.",
+							MarkdownDescription: "Set if the secure time of the generation has expired.",
+							Computed:            true,
+						},
+						"linked": schema.BoolAttribute{
+							Description:         "Set if the generation is SnapVX linked.",
+							MarkdownDescription: "Set if the generation is SnapVX linked.",
+							Computed:            true,
+						},
+						"restored": schema.BoolAttribute{
+							Description:         "Set if the generation is restored.",
+							MarkdownDescription: "Set if the generation is restored.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *snapshotSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan marks the generations as unknown when a new generation will be created on this apply,
+// so that every apply of a rotating snapshot set is planned as an update.
+func (r *snapshotSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan models.SnapshotSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.RotateOnApply.ValueBool() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("latest_snapid"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("generations"), types.ListUnknown(helper.SnapshotSetGenerationType))...)
+}
+
+// applySnapshotSet creates a new generation if asked to, terminates the generations beyond the retention count
+// and refreshes the state with the remaining generations.
+func (r *snapshotSetResource) applySnapshotSet(ctx context.Context, plan *models.SnapshotSetResourceModel, newGeneration bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sgName := plan.StorageGroupName.ValueString()
+	snapshotName := plan.SnapshotName.ValueString()
+	if newGeneration {
+		generation, _, err := helper.CreateSnapshotSetGeneration(ctx, *r.client, *plan)
+		if err != nil {
+			errStr := constants.CreateSnapshotSetDetailErrorMsg + snapshotName + " with error: "
+			msgStr := helper.GetErrorString(err, errStr)
+			diags.AddError("Error creating snapshot set generation", msgStr)
+			return diags
+		}
+		tflog.Debug(ctx, "created snapshot set generation", map[string]interface{}{
+			"snapshot": snapshotName,
+			"snapids":  generation.GetSnapId(),
+		})
+	}
+
+	_, err := helper.TerminateSnapshotSetGenerations(ctx, *r.client, sgName, snapshotName, plan.RetentionCount.ValueInt64())
+	if err != nil {
+		errStr := constants.TerminateSnapshotSetDetailsErrorMsg + snapshotName + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		diags.AddError("Error terminating snapshot set generations", msgStr)
+		return diags
+	}
+
+	generations, err := helper.GetSnapshotSetGenerations(ctx, *r.client, sgName, snapshotName)
+	if err != nil {
+		errStr := constants.ReadSnapshotSetDetailsErrorMsg + snapshotName + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		diags.AddError("Error reading snapshot set", msgStr)
+		return diags
+	}
+	err = helper.UpdateSnapshotSetState(ctx, generations, plan)
+	if err != nil {
+		diags.AddError("Error reading snapshot set", err.Error())
+	}
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *snapshotSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating snapshot set")
+	var plan models.SnapshotSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.applySnapshotSet(ctx, &plan, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create snapshot set completed")
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *snapshotSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading snapshot set")
+	var state models.SnapshotSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	generations, err := helper.GetSnapshotSetGenerations(ctx, *r.client, state.StorageGroupName.ValueString(), state.SnapshotName.ValueString())
+	if err != nil {
+		errStr := constants.ReadSnapshotSetDetailsErrorMsg + state.SnapshotName.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading snapshot set", msgStr,
+		)
+		return
+	}
+
+	errState := helper.UpdateSnapshotSetState(ctx, generations, &state)
+	if errState != nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot set",
+			errState.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "read snapshot set completed")
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Supported updates: retention_count, time_to_live, time_in_hours, rotate_on_apply, triggers.
+func (r *snapshotSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating snapshot set")
+	var plan, state models.SnapshotSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newGeneration := plan.RotateOnApply.ValueBool() || !plan.Triggers.Equal(state.Triggers)
+	resp.Diagnostics.Append(r.applySnapshotSet(ctx, &plan, newGeneration)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "update snapshot set completed")
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *snapshotSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting snapshot set")
+	var state models.SnapshotSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := helper.DeleteSnapshotSet(ctx, *r.client, state.StorageGroupName.ValueString(), state.SnapshotName.ValueString())
+	if err != nil {
+		errStr := constants.DeleteSnapshotSetDetailsErrorMsg + state.SnapshotName.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error deleting snapshot set", msgStr,
+		)
+		return
+	}
+	tflog.Info(ctx, "delete snapshot set completed")
+}
+
+// ImportState imports the resource using the 'storage_group_name.snapshot_name' ID.
+// The retention count is set to the number of existing generations.
+func (r *snapshotSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing snapshot set")
+	ids := strings.Split(req.ID, ".")
+	if len(ids) != 2 {
+		resp.Diagnostics.AddError(
+			"Error importing snapshot set",
+			"The import ID must be 'storage_group_name.snapshot_name'",
+		)
+		return
+	}
+
+	generations, err := helper.GetSnapshotSetGenerations(ctx, *r.client, ids[0], ids[1])
+	if err != nil {
+		errStr := constants.ReadSnapshotSetDetailsErrorMsg + ids[1] + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error importing snapshot set", msgStr,
+		)
+		return
+	}
+
+	state := models.SnapshotSetResourceModel{
+		StorageGroupName: types.StringValue(ids[0]),
+		SnapshotName:     types.StringValue(ids[1]),
+		RetentionCount:   types.Int64Value(int64(len(generations))),
+		TimeToLive:       types.Int64Null(),
+		TimeInHours:      types.BoolValue(false),
+		RotateOnApply:    types.BoolValue(true),
+		Triggers:         types.MapNull(types.StringType),
+	}
+	errState := helper.UpdateSnapshotSetState(ctx, generations, &state)
+	if errState != nil {
+		resp.Diagnostics.AddError(
+			"Error importing snapshot set",
+			errState.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "import snapshot set completed")
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSnapshotSetResource(t *testing.T) {
+	var snapshotSetTerraformName = "powermax_snapshot_set.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + snapshotSetConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotSetTerraformName, "id", "tfacc_sg_snapshot.tfacc_snapshot_set"),
+					resource.TestCheckResourceAttr(snapshotSetTerraformName, "generations.#", "1"),
+					resource.TestCheckResourceAttrSet(snapshotSetTerraformName, "generations.0.timestamp"),
+					resource.TestCheckResourceAttrSet(snapshotSetTerraformName, "latest_snapid"),
+				),
+				// Each apply creates a new generation
+				ExpectNonEmptyPlan: true,
+			},
+			// Rotate a second generation
+			{
+				Config: ProviderConfig + snapshotSetConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotSetTerraformName, "generations.#", "2"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// Rotate a third generation, the oldest one is terminated
+			{
+				Config: ProviderConfig + snapshotSetConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotSetTerraformName, "generations.#", "2"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// Only create a new generation when the triggers change, retention reduced to 1
+			{
+				Config: ProviderConfig + snapshotSetTriggerConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotSetTerraformName, "rotate_on_apply", "false"),
+					resource.TestCheckResourceAttr(snapshotSetTerraformName, "generations.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            snapshotSetTerraformName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotate_on_apply", "triggers", "time_to_live", "time_in_hours"},
+			},
+			// Terminate Error
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.TerminateSnapshotSetGenerations).Return(nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotSetConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Read Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.GetSnapshotSetGenerations).Return(nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotSetTriggerConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotSetResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateSnapshotSetGeneration).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotSetConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotSetResourceImportError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        ProviderConfig + snapshotSetConfig,
+				ResourceName:  "powermax_snapshot_set.test",
+				ImportState:   true,
+				ImportStateId: "tfacc_sg_snapshot",
+				ExpectError:   regexp.MustCompile(`.*The import ID must be*.`),
+			},
+		},
+	})
+}
+
+var snapshotSetConfig = `
+resource "powermax_snapshot_set" "test" {
+	storage_group_name = "tfacc_sg_snapshot"
+	snapshot_name = "tfacc_snapshot_set"
+	retention_count = 2
+	time_to_live = 1
+	time_in_hours = true
+}
+`
+
+var snapshotSetTriggerConfig = `
+resource "powermax_snapshot_set" "test" {
+	storage_group_name = "tfacc_sg_snapshot"
+	snapshot_name = "tfacc_snapshot_set"
+	retention_count = 1
+	time_to_live = 1
+	time_in_hours = true
+	rotate_on_apply = false
+	triggers = {
+		version = "1"
+	}
+}
+`
@@ -21,11 +21,15 @@ import (
 	"context"
 	"fmt"
 	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
 	"terraform-provider-powermax/powermax/helper"
 	"terraform-provider-powermax/powermax/models"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -59,6 +63,11 @@ func (d *snapshotPolicyDataSource) Schema(_ context.Context, _ datasource.Schema
 				Description: "Identifier",
 				Computed:    true,
 			},
+			"expand_storage_groups": schema.BoolAttribute{
+				Description:         "Set to list the storage groups associated with each snapshot policy, with their compliance and last snapshot time",
+				MarkdownDescription: "Set to list the storage groups associated with each snapshot policy, with their compliance and last snapshot time",
+				Optional:            true,
+			},
 			"snapshot_policies": schema.ListNestedAttribute{
 				Description:         "List of Snapshot Policies",
 				MarkdownDescription: "List of Snapshot Policies",
@@ -130,6 +139,50 @@ func (d *snapshotPolicyDataSource) Schema(_ context.Context, _ datasource.Schema
 							MarkdownDescription: "The type of Snapshots that are created with the policy, local or cloud",
 							Computed:            true,
 						},
+						"storage_groups": schema.ListNestedAttribute{
+							Description:         "The storage groups associated with the snapshot policy, only listed when expand_storage_groups is set",
+							MarkdownDescription: "The storage groups associated with the snapshot policy, only listed when expand_storage_groups is set",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"storage_group_name": schema.StringAttribute{
+										Description:         "The name of the storage group",
+										MarkdownDescription: "The name of the storage group",
+										Computed:            true,
+									},
+									"compliance": schema.StringAttribute{
+										Description:         "The compliance of the storage group for the snapshot policy, GREEN, YELLOW, RED or NONE",
+										MarkdownDescription: "The compliance of the storage group for the snapshot policy, GREEN, YELLOW, RED or NONE",
+										Computed:            true,
+									},
+									"snapshots_in_time_window": schema.Int64Attribute{
+										Description:         "The number of snapshots in the compliance time window",
+										MarkdownDescription: "The number of snapshots in the compliance time window",
+										Computed:            true,
+									},
+									"total_snapshots": schema.Int64Attribute{
+										Description:         "The total number of snapshots of the storage group for the snapshot policy",
+										MarkdownDescription: "The total number of snapshots of the storage group for the snapshot policy",
+										Computed:            true,
+									},
+									"suspended": schema.BoolAttribute{
+										Description:         "Set if the snapshot policy is suspended for the storage group",
+										MarkdownDescription: "Set if the snapshot policy is suspended for the storage group",
+										Computed:            true,
+									},
+									"last_snapshot_time": schema.StringAttribute{
+										Description:         "The time the snapshot policy last created a snapshot of the storage group",
+										MarkdownDescription: "The time the snapshot policy last created a snapshot of the storage group",
+										Computed:            true,
+									},
+									"last_snapshot_timestamp": schema.Int64Attribute{
+										Description:         "The time the snapshot policy last created a snapshot of the storage group, in milliseconds since 1970",
+										MarkdownDescription: "The time the snapshot policy last created a snapshot of the storage group, in milliseconds since 1970",
+										Computed:            true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -141,6 +194,41 @@ func (d *snapshotPolicyDataSource) Schema(_ context.Context, _ datasource.Schema
 						Optional:    true,
 						ElementType: types.StringType,
 					},
+					"type": schema.StringAttribute{
+						Description:         "Only list the snapshot policies of the type, local or cloud",
+						MarkdownDescription: "Only list the snapshot policies of the type, local or cloud",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOfCaseInsensitive("local", "cloud"),
+						},
+					},
+					"suspended": schema.BoolAttribute{
+						Description:         "Only list the suspended snapshot policies when true, or the active ones when false",
+						MarkdownDescription: "Only list the suspended snapshot policies when true, or the active ones when false",
+						Optional:            true,
+					},
+					"min_interval_minutes": schema.Int64Attribute{
+						Description:         "Only list the snapshot policies which run at least this number of minutes apart",
+						MarkdownDescription: "Only list the snapshot policies which run at least this number of minutes apart",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"max_interval_minutes": schema.Int64Attribute{
+						Description:         "Only list the snapshot policies which run at most this number of minutes apart",
+						MarkdownDescription: "Only list the snapshot policies which run at most this number of minutes apart",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"tags": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Description:         "Only list the snapshot policies associated with a storage group carrying any of the tags",
+						MarkdownDescription: "Only list the snapshot policies associated with a storage group carrying any of the tags",
+						Optional:            true,
+					},
 				},
 			},
 		},
@@ -193,6 +281,23 @@ func (d *snapshotPolicyDataSource) Read(ctx context.Context, req datasource.Read
 			snapshotPolicyIds = append(snapshotPolicyIds, ids.ValueString())
 		}
 	}
+	// Storage groups carrying the filter tags
+	var taggedStorageGroups []string
+	filterTags := state.SnapshotPolicyFilter != nil && len(state.SnapshotPolicyFilter.Tags) > 0
+	if filterTags {
+		var tags []string
+		for _, tag := range state.SnapshotPolicyFilter.Tags {
+			tags = append(tags, tag.ValueString())
+		}
+		var err error
+		taggedStorageGroups, err = helper.GetTaggedStorageGroupNames(ctx, *d.client, tags)
+		if err != nil {
+			errStr := ""
+			msgStr := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError("Error reading tagged storage groups", msgStr)
+			return
+		}
+	}
 	for _, id := range snapshotPolicyIds {
 		snapshotPolicyResponse, _, err := helper.GetSnapshotPolicy(ctx, *d.client, id)
 		if err != nil || snapshotPolicyResponse == nil {
@@ -201,6 +306,21 @@ func (d *snapshotPolicyDataSource) Read(ctx context.Context, req datasource.Read
 			resp.Diagnostics.AddError("Error reading snapshot policy with id", msgStr)
 			continue
 		}
+		if !helper.MatchSnapshotPolicyFilter(snapshotPolicyResponse, state.SnapshotPolicyFilter) {
+			continue
+		}
+		if filterTags {
+			sgList, _, err := helper.GetSnapshotPolicyStorageGroups(ctx, *d.client, id)
+			if err != nil {
+				errStr := constants.ReadSnapshotPolicyStorageGroupsErrorMsg + id + " with error: "
+				msgStr := helper.GetErrorString(err, errStr)
+				resp.Diagnostics.AddError("Error reading snapshot policy storage groups", msgStr)
+				return
+			}
+			if !helper.AnyStringInSlice(sgList.Name, taggedStorageGroups) {
+				continue
+			}
+		}
 		var snapshotPolicy models.SnapshotPolicyModel
 		tflog.Debug(ctx, "Updating snapshot policy state")
 		// Copy values with the same fields
@@ -209,6 +329,16 @@ func (d *snapshotPolicyDataSource) Read(ctx context.Context, req datasource.Read
 			resp.Diagnostics.AddError("Error copying Snapshot Policies", errCpy.Error())
 			return
 		}
+		if state.ExpandStorageGroups.ValueBool() {
+			storageGroups, err := helper.GetSnapshotPolicyStorageGroupDetails(ctx, *d.client, id)
+			if err != nil {
+				errStr := constants.ReadSnapshotPolicyStorageGroupsErrorMsg + id + " with error: "
+				msgStr := helper.GetErrorString(err, errStr)
+				resp.Diagnostics.AddError("Error reading snapshot policy storage groups", msgStr)
+				return
+			}
+			snapshotPolicy.StorageGroups = storageGroups
+		}
 		state.SnapshotPolicies = append(state.SnapshotPolicies, snapshotPolicy)
 	}
 	state.ID = types.StringValue("snapshot-policy-datasource")
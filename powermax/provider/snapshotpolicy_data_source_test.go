@@ -58,6 +58,37 @@ func TestAccSnapshotPolicyDsFiltered(t *testing.T) {
 	})
 }
 
+func TestAccSnapshotPolicyDsExpanded(t *testing.T) {
+	var snapshotPolicyTerraformName = "data.powermax_snapshotpolicy.SnapshotPolicyExpanded"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + snapshotPolicyDsExpandedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotPolicyTerraformName, "snapshot_policies.#", "1"),
+					resource.TestCheckResourceAttr(snapshotPolicyTerraformName, "snapshot_policies.0.storage_groups.#", "1"),
+					resource.TestCheckResourceAttr(snapshotPolicyTerraformName, "snapshot_policies.0.storage_groups.0.storage_group_name", "tfacc_sp_sg1"),
+					resource.TestCheckResourceAttrSet(snapshotPolicyTerraformName, "snapshot_policies.0.storage_groups.0.compliance"),
+				),
+			},
+			{
+				Config: ProviderConfig + snapshotPolicyDsIntervalConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotPolicyTerraformName, "snapshot_policies.#", "0"),
+				),
+			},
+			{
+				Config: ProviderConfig + snapshotPolicyDsTagsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotPolicyTerraformName, "snapshot_policies.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSnapshotPolicyDsError(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -103,6 +134,22 @@ func TestAccSnapshotPolicyDsGetSpecificPolicyError(t *testing.T) {
 	})
 }
 
+func TestAccSnapshotPolicyDsStorageGroupError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetSnapshotPolicyStorageGroupDetails).Return(nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + snapshotPolicyDsExpandedAllConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
 func TestAccSnapshotPolicyDsMappingError(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -129,6 +176,54 @@ var snapshotPolicyDsFilteredConfig = `
 	}
   }
 `
+var snapshotPolicyDsExpandedSnapshotPolicy = `
+resource "powermax_snapshotpolicy" "expanded_sp" {
+	snapshot_policy_name = "tfacc_expanded_sp"
+	interval = "1 Day"
+}
+
+resource "powermax_snapshot_policy_attachment" "expanded_sp" {
+	storage_group_name = "tfacc_sp_sg1"
+	snapshot_policy_name = powermax_snapshotpolicy.expanded_sp.snapshot_policy_name
+}
+`
+
+var snapshotPolicyDsExpandedConfig = snapshotPolicyDsExpandedSnapshotPolicy + `
+data "powermax_snapshotpolicy" "SnapshotPolicyExpanded" {
+	expand_storage_groups = true
+	filter {
+		names = [powermax_snapshot_policy_attachment.expanded_sp.snapshot_policy_name]
+		type = "local"
+		suspended = false
+		min_interval_minutes = 60
+	}
+}
+`
+
+var snapshotPolicyDsIntervalConfig = snapshotPolicyDsExpandedSnapshotPolicy + `
+data "powermax_snapshotpolicy" "SnapshotPolicyExpanded" {
+	filter {
+		names = [powermax_snapshot_policy_attachment.expanded_sp.snapshot_policy_name]
+		max_interval_minutes = 60
+	}
+}
+`
+
+var snapshotPolicyDsTagsConfig = snapshotPolicyDsExpandedSnapshotPolicy + `
+data "powermax_snapshotpolicy" "SnapshotPolicyExpanded" {
+	filter {
+		names = [powermax_snapshot_policy_attachment.expanded_sp.snapshot_policy_name]
+		tags = ["tfacc_no_such_tag"]
+	}
+}
+`
+
+var snapshotPolicyDsExpandedAllConfig = `
+data "powermax_snapshotpolicy" "SnapshotPolicyExpanded" {
+	expand_storage_groups = true
+}
+`
+
 var snapshotPolicyAllDatasourceConfig = `
  data "powermax_snapshotpolicy" "SnapshotPolicyAll" {
   }
@@ -28,13 +28,16 @@ import (
 	"terraform-provider-powermax/powermax/models"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -106,29 +109,43 @@ func (r *SnapshotPolicy) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:             int64default.StaticInt64(420),
 			},
 			"provider_name": schema.StringAttribute{
-				Description:         "The name of the cloud provider associated with this policy. Only applies to cloud policies",
-				MarkdownDescription: "The name of the cloud provider associated with this policy. Only applies to cloud policies",
+				Description:         "The name of the cloud provider associated with this policy. Only applies to cloud policies. Changing the cloud provider replaces the snapshot policy.",
+				MarkdownDescription: "The name of the cloud provider associated with this policy. Only applies to cloud policies. Changing the cloud provider replaces the snapshot policy.",
 				Computed:            true,
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"retention_days": schema.Int64Attribute{
-				Description:         "The number of days that snapshots will be retained in the cloud for. Only applies to cloud policies",
-				MarkdownDescription: "The number of days that snapshots will be retained in the cloud for. Only applies to cloud policies",
+				Description:         "The number of days that snapshots will be retained in the cloud for. Only applies to cloud policies. Changing the retention replaces the snapshot policy.",
+				MarkdownDescription: "The number of days that snapshots will be retained in the cloud for. Only applies to cloud policies. Changing the retention replaces the snapshot policy.",
 				Computed:            true,
 				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"suspended": schema.BoolAttribute{
-				Description:         "Set if the snapshot policy has been suspended",
-				MarkdownDescription: "Set if the snapshot policy has been suspended",
+				Description:         "Set to true to suspend the snapshot policy, no snapshots are taken while it is suspended. Set to false to resume it. (Update Supported)",
+				MarkdownDescription: "Set to true to suspend the snapshot policy, no snapshots are taken while it is suspended. Set to false to resume it. (Update Supported)",
 				Computed:            true,
 				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"secure": schema.BoolAttribute{
-				Description:         "Set if the snapshot policy creates secure snapshots. (Update Supported)",
-				MarkdownDescription: "Set if the snapshot policy creates secure snapshots. (Update Supported)",
+				Description:         "Set if the snapshot policy creates secure snapshots. Changing secure replaces the snapshot policy.",
+				MarkdownDescription: "Set if the snapshot policy creates secure snapshots. Changing secure replaces the snapshot policy.",
 				Computed:            true,
 				Optional:            true,
 				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
 			},
 			"last_time_used": schema.StringAttribute{
 				Description:         "The last time that the snapshot policy was run",
@@ -174,9 +191,11 @@ func (r *SnapshotPolicy) Schema(ctx context.Context, req resource.SchemaRequest,
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
-				Description:         "The storage groups associated with the snapshot policy. This field cannot be set during create and is only valid for Edit/Update.If user wants to delete the snapshot policy all associated storage groups will also be unlinked from the Snapshot Policy. (Update Supported)",
-				MarkdownDescription: "The storage groups associated with the snapshot policy. This field cannot be set during create and is only valid for Edit/Update.If user wants to delete the snapshot policy all associated storage groups will also be unlinked from the Snapshot Policy. (Update Supported)",
-				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+				Description:         "The storage groups associated with the snapshot policy. This field cannot be set during create and is only valid for Edit/Update.If user wants to delete the snapshot policy all associated storage groups will also be unlinked from the Snapshot Policy. When not set the storage groups are not managed by this resource, so that they can be attached with the powermax_snapshot_policy_attachment resource. Set it to an empty set to detach all the storage groups. (Update Supported)",
+				MarkdownDescription: "The storage groups associated with the snapshot policy. This field cannot be set during create and is only valid for Edit/Update.If user wants to delete the snapshot policy all associated storage groups will also be unlinked from the Snapshot Policy. When not set the storage groups are not managed by this resource, so that they can be attached with the `powermax_snapshot_policy_attachment` resource. Set it to an empty set to detach all the storage groups. (Update Supported)",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -250,6 +269,29 @@ func (r *SnapshotPolicy) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Debug(ctx, "create snapshot policy response", map[string]interface{}{
 		"Create Snapshot Policy Response": snapPolicyCreateResp,
 	})
+	// A snapshot policy is always created running, suspend it afterwards if asked to
+	if planSnapPolicy.Suspended.ValueBool() {
+		snapPolicyCreateResp, _, err = helper.SuspendOrResumeSnapshotPolicy(ctx, *r.client, planSnapPolicy.SnapshotPolicyName.ValueString(), true)
+		if err != nil {
+			errStr := constants.CreateSnapPolicyDetailErrorMsg + planSnapPolicy.SnapshotPolicyName.ValueString() + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error suspending snapshot policy",
+				message,
+			)
+			// Attempt to cleanup after failure
+			_, err := helper.DeleteSnapshotPolicy(ctx, *r.client, planSnapPolicy.SnapshotPolicyName.ValueString())
+			if err != nil {
+				errStr := constants.CreateSnapPolicyDetailErrorMsg + planSnapPolicy.SnapshotPolicyName.ValueString() + "with error: "
+				message := helper.GetErrorString(err, errStr)
+				resp.Diagnostics.AddError(
+					"Error deleting the invalid snapshot policy, This may be a dangling resource and needs to be deleted manually",
+					message,
+				)
+			}
+			return
+		}
+	}
 	//Get Storage Groups associated with the snapshot policy
 	storageGroups, _, errStorageGroup := helper.GetSnapshotPolicyStorageGroups(ctx, *r.client, planSnapPolicy.SnapshotPolicyName.ValueString())
 	if errStorageGroup != nil {
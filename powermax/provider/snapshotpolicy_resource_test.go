@@ -87,6 +87,22 @@ func TestAccSnapshotPolicyResource(t *testing.T) {
 					resource.TestCheckResourceAttr(snapPolicyTerraformName, "storage_groups.#", "0"),
 				),
 			},
+			// Suspend Snapshot Policy
+			{
+				Config: ProviderConfig + SnapshotPolicyResourceSuspend,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapPolicyTerraformName, "snapshot_policy_name", "terraform_test_sp_remove"),
+					resource.TestCheckResourceAttr(snapPolicyTerraformName, "suspended", "true"),
+				),
+			},
+			// Resume Snapshot Policy
+			{
+				Config: ProviderConfig + SnapshotPolicyResourceResume,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapPolicyTerraformName, "snapshot_policy_name", "terraform_test_sp_remove"),
+					resource.TestCheckResourceAttr(snapPolicyTerraformName, "suspended", "false"),
+				),
+			},
 			// Modify Error Check
 			{
 				PreConfig: func() {
@@ -178,6 +194,47 @@ func TestAccSnapshotPolicyResourceCreateError(t *testing.T) {
 	})
 }
 
+func TestAccSnapshotPolicyResourceCreateSuspended(t *testing.T) {
+	var snapPolicyTerraformName = "powermax_snapshotpolicy.terraform_test_sp"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a suspended Snapshot Policy
+			{
+				Config: ProviderConfig + SnapshotPolicyResourceCreateSuspended,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapPolicyTerraformName, "suspended", "true"),
+				),
+			},
+			// Resume Error Check
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.SuspendOrResumeSnapshotPolicy).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + SnapshotPolicyResourceResumeCreated,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotPolicyResourceSuspendError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.SuspendOrResumeSnapshotPolicy).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + SnapshotPolicyResourceCreateSuspended,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
 func TestAccSnapshotPolicyResourceSgError(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -247,3 +304,37 @@ resource "powermax_snapshotpolicy" "terraform_test_sp" {
 	storage_groups = []
   }
 `
+var SnapshotPolicyResourceSuspend = `
+resource "powermax_snapshotpolicy" "terraform_test_sp" {
+	snapshot_policy_name = "terraform_test_sp_remove"
+	interval = "1 Day"
+	compliance_count_critical = 29
+	storage_groups = []
+	suspended = true
+  }
+`
+var SnapshotPolicyResourceResume = `
+resource "powermax_snapshotpolicy" "terraform_test_sp" {
+	snapshot_policy_name = "terraform_test_sp_remove"
+	interval = "1 Day"
+	compliance_count_critical = 29
+	storage_groups = []
+	suspended = false
+  }
+`
+var SnapshotPolicyResourceCreateSuspended = `
+resource "powermax_snapshotpolicy" "terraform_test_sp" {
+	snapshot_policy_name = "terraform_test_sp"
+	interval = "7 Days"
+	compliance_count_critical = 29
+	suspended = true
+  }
+`
+var SnapshotPolicyResourceResumeCreated = `
+resource "powermax_snapshotpolicy" "terraform_test_sp" {
+	snapshot_policy_name = "terraform_test_sp"
+	interval = "7 Days"
+	compliance_count_critical = 29
+	suspended = false
+  }
+`
@@ -0,0 +1,206 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &splitDataSource{}
+	_ datasource.DataSourceWithConfigure = &splitDataSource{}
+)
+
+// NewSplitDataSource is a helper function to simplify the provider implementation.
+func NewSplitDataSource() datasource.DataSource {
+	return &splitDataSource{}
+}
+
+// splitDataSource is the data source implementation.
+type splitDataSource struct {
+	client *client.Client
+}
+
+func (d *splitDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_split"
+}
+
+func (d *splitDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for reading the FICON splits of a PowerMax array. Splits are part of the array configuration and are read only: the REST API has no endpoint to create or delete a split, so there is no `powermax_split` resource. The CU images of a split are managed with the `powermax_cu_image` resource.",
+		Description:         "Data source for reading the FICON splits of a PowerMax array. Splits are part of the array configuration and are read only: the REST API has no endpoint to create or delete a split, so there is no powermax_split resource. The CU images of a split are managed with the powermax_cu_image resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"splits": schema.ListNestedAttribute{
+				Description:         "List of splits",
+				MarkdownDescription: "List of splits",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "The ID of the split.",
+							MarkdownDescription: "The ID of the split.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "The name of the split.",
+							MarkdownDescription: "The name of the split.",
+							Computed:            true,
+						},
+						"alpha_serial_num": schema.StringAttribute{
+							Description:         "The alpha serial number of the split.",
+							MarkdownDescription: "The alpha serial number of the split.",
+							Computed:            true,
+						},
+						"hyper_pav_state": schema.StringAttribute{
+							Description:         "Whether hyper PAV is Enabled or Disabled on the split.",
+							MarkdownDescription: "Whether hyper PAV is Enabled or Disabled on the split.",
+							Computed:            true,
+						},
+						"num_cu_images": schema.Int64Attribute{
+							Description:         "The number of CU images of the split.",
+							MarkdownDescription: "The number of CU images of the split.",
+							Computed:            true,
+						},
+						"num_ports": schema.Int64Attribute{
+							Description:         "The number of FICON ports assigned to the split.",
+							MarkdownDescription: "The number of FICON ports assigned to the split.",
+							Computed:            true,
+						},
+						"ports": schema.ListNestedAttribute{
+							Description:         "The FICON ports assigned to the split.",
+							MarkdownDescription: "The FICON ports assigned to the split.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"director_id": schema.StringAttribute{
+										Description:         "The ID of the director.",
+										MarkdownDescription: "The ID of the director.",
+										Computed:            true,
+									},
+									"port_id": schema.StringAttribute{
+										Description:         "The ID of the port.",
+										MarkdownDescription: "The ID of the port.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"ids": schema.SetAttribute{
+						Description:         "The IDs of the splits to list.",
+						MarkdownDescription: "The IDs of the splits to list.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *splitDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider is not config
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Config Failure",
+			fmt.Sprintf("Expected client, %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *splitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.SplitDataSourceModel
+	var plan models.SplitDataSourceModel
+	tflog.Info(ctx, "Attempting to read splits")
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var splitIDs []string
+	if plan.SplitFilter != nil && len(plan.SplitFilter.IDs) > 0 {
+		for _, id := range plan.SplitFilter.IDs {
+			splitIDs = append(splitIDs, id.ValueString())
+		}
+	} else {
+		list, _, err := helper.ListSplits(ctx, *d.client)
+		if err != nil {
+			errStr := constants.ReadSplits + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the list of splits",
+				message,
+			)
+			return
+		}
+		splitIDs = list.SplitId
+	}
+
+	state.Splits = []models.SplitDetailModel{}
+	for _, splitID := range splitIDs {
+		split, _, err := helper.GetSplit(ctx, *d.client, splitID)
+		if err != nil {
+			errStr := constants.ReadSplits + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the split details",
+				message,
+			)
+			return
+		}
+		var detail models.SplitDetailModel
+		helper.UpdateSplitDetailState(&detail, splitID, split)
+		state.Splits = append(state.Splits, detail)
+	}
+	state.ID = types.StringValue("split-datasource")
+	state.SplitFilter = plan.SplitFilter
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,317 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &storageGroupCascadeResource{}
+	_ resource.ResourceWithConfigure   = &storageGroupCascadeResource{}
+	_ resource.ResourceWithImportState = &storageGroupCascadeResource{}
+)
+
+// NewStorageGroupCascadeResource is a helper function to simplify the provider implementation.
+func NewStorageGroupCascadeResource() resource.Resource {
+	return &storageGroupCascadeResource{}
+}
+
+// storageGroupCascadeResource is the resource implementation.
+type storageGroupCascadeResource struct {
+	client *client.Client
+}
+
+// Metadata returns the resource type name.
+func (r *storageGroupCascadeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_group_cascade"
+}
+
+// Schema defines the schema for the resource.
+func (r *storageGroupCascadeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for managing the child storage groups of a cascaded storage group in PowerMax array. The parent and child storage groups must already exist, for example as powermax_storagegroup resources. The parent must not contain volumes or have a service level, the service level is set on the children. Mask the parent storage group, not the children. Deleting this resource removes the children from the parent, the storage groups themselves are kept.",
+		Description:         "Resource for managing the child storage groups of a cascaded storage group in PowerMax array. The parent and child storage groups must already exist, for example as powermax_storagegroup resources. The parent must not contain volumes or have a service level, the service level is set on the children. Mask the parent storage group, not the children. Deleting this resource removes the children from the parent, the storage groups themselves are kept.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "The ID of the cascade, the name of the parent storage group.",
+				MarkdownDescription: "The ID of the cascade, the name of the parent storage group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_storage_group_name": schema.StringAttribute{
+				Description:         "The name of the parent storage group.",
+				MarkdownDescription: "The name of the parent storage group.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"child_storage_group_names": schema.SetAttribute{
+				Description:         "The names of the child storage groups. Storage groups added to the parent outside of Terraform are removed on the next apply. (Update Supported)",
+				MarkdownDescription: "The names of the child storage groups. Storage groups added to the parent outside of Terraform are removed on the next apply. (Update Supported)",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"enable_compliance_alerts": schema.BoolAttribute{
+				Description:         "Enable compliance alerts on the child storage groups when they are added. (Update Supported)",
+				MarkdownDescription: "Enable compliance alerts on the child storage groups when they are added. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"force_remove": schema.BoolAttribute{
+				Description:         "Remove child storage groups even when the parent storage group is in a masking view. (Update Supported)",
+				MarkdownDescription: "Remove child storage groups even when the parent storage group is in a masking view. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *storageGroupCascadeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create adds the child storage groups to the parent storage group.
+func (r *storageGroupCascadeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating storage group cascade")
+	var plan models.StorageGroupCascadeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parentName := plan.ParentStorageGroupName.ValueString()
+	var childNames []string
+	resp.Diagnostics.Append(plan.ChildStorageGroupNames.ElementsAs(ctx, &childNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errStr := constants.AddChildStorageGroupsDetailErrorMsg + parentName + " with error: "
+	err := helper.ValidateChildStorageGroups(ctx, *r.client, parentName, childNames)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating storage group cascade", helper.GetErrorString(err, errStr))
+		return
+	}
+	err = helper.AddChildStorageGroups(ctx, *r.client, parentName, childNames, plan.EnableComplianceAlerts.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating storage group cascade", helper.GetErrorString(err, errStr))
+		return
+	}
+
+	parent, _, err := helper.GetStorageGroup(ctx, *r.client, parentName)
+	if err != nil {
+		errStr := constants.ReadStorageGroupCascadeDetailErrorMsg + parentName + " with error: "
+		resp.Diagnostics.AddError("Error reading storage group cascade", helper.GetErrorString(err, errStr))
+		return
+	}
+	resp.Diagnostics.Append(helper.UpdateStorageGroupCascadeState(ctx, &plan, parent)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create storage group cascade completed")
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *storageGroupCascadeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading storage group cascade")
+	var state models.StorageGroupCascadeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parentName := state.ParentStorageGroupName.ValueString()
+	parent, _, err := helper.GetStorageGroup(ctx, *r.client, parentName)
+	if err != nil {
+		errStr := constants.ReadStorageGroupCascadeDetailErrorMsg + parentName + " with error: "
+		resp.Diagnostics.AddError("Error reading storage group cascade", helper.GetErrorString(err, errStr))
+		return
+	}
+	resp.Diagnostics.Append(helper.UpdateStorageGroupCascadeState(ctx, &state, parent)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "read storage group cascade completed")
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Supported updates: child_storage_group_names, enable_compliance_alerts, force_remove.
+func (r *storageGroupCascadeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating storage group cascade")
+	var plan, state models.StorageGroupCascadeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parentName := state.ParentStorageGroupName.ValueString()
+	var planChildren, stateChildren []string
+	resp.Diagnostics.Append(plan.ChildStorageGroupNames.ElementsAs(ctx, &planChildren, false)...)
+	resp.Diagnostics.Append(state.ChildStorageGroupNames.ElementsAs(ctx, &stateChildren, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	toAdd, toRemove := helper.GetStringSliceChanges(planChildren, stateChildren)
+
+	if len(toAdd) > 0 {
+		errStr := constants.AddChildStorageGroupsDetailErrorMsg + parentName + " with error: "
+		err := helper.ValidateChildStorageGroups(ctx, *r.client, parentName, toAdd)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating storage group cascade", helper.GetErrorString(err, errStr))
+			return
+		}
+		err = helper.AddChildStorageGroups(ctx, *r.client, parentName, toAdd, plan.EnableComplianceAlerts.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating storage group cascade", helper.GetErrorString(err, errStr))
+			return
+		}
+	}
+	if len(toRemove) > 0 {
+		err := helper.RemoveChildStorageGroups(ctx, *r.client, parentName, toRemove, plan.ForceRemove.ValueBool())
+		if err != nil {
+			errStr := constants.RemoveChildStorageGroupsDetailErrorMsg + parentName + " with error: "
+			resp.Diagnostics.AddError("Error updating storage group cascade", helper.GetErrorString(err, errStr))
+			// Fall through so that the children already added are recorded in the state
+		}
+	}
+
+	parent, _, err := helper.GetStorageGroup(ctx, *r.client, parentName)
+	if err != nil {
+		errStr := constants.ReadStorageGroupCascadeDetailErrorMsg + parentName + " with error: "
+		resp.Diagnostics.AddError("Error reading storage group cascade", helper.GetErrorString(err, errStr))
+		return
+	}
+	plan.ParentStorageGroupName = state.ParentStorageGroupName
+	resp.Diagnostics.Append(helper.UpdateStorageGroupCascadeState(ctx, &plan, parent)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "update storage group cascade completed")
+}
+
+// Delete removes the child storage groups from the parent storage group and removes the Terraform state on success.
+func (r *storageGroupCascadeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting storage group cascade")
+	var state models.StorageGroupCascadeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parentName := state.ParentStorageGroupName.ValueString()
+	var childNames []string
+	resp.Diagnostics.Append(state.ChildStorageGroupNames.ElementsAs(ctx, &childNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errStr := constants.RemoveChildStorageGroupsDetailErrorMsg + parentName + " with error: "
+	parent, _, err := helper.GetStorageGroup(ctx, *r.client, parentName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting storage group cascade", helper.GetErrorString(err, errStr))
+		return
+	}
+	// Only remove the children which are still attached, they may have been removed outside of Terraform
+	var toRemove []string
+	for _, childName := range childNames {
+		if helper.StringInSlice(childName, parent.ChildStorageGroup) {
+			toRemove = append(toRemove, childName)
+		}
+	}
+	if len(toRemove) > 0 {
+		err = helper.RemoveChildStorageGroups(ctx, *r.client, parentName, toRemove, state.ForceRemove.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Error deleting storage group cascade", helper.GetErrorString(err, errStr))
+			return
+		}
+	}
+	tflog.Info(ctx, "delete storage group cascade completed", map[string]interface{}{
+		"removed": strings.Join(toRemove, ", "),
+	})
+}
+
+// ImportState imports the child storage groups of a parent storage group, the import ID is the name of the parent storage group.
+func (r *storageGroupCascadeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing storage group cascade")
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("parent_storage_group_name"), req.ID)...)
+	tflog.Info(ctx, "import storage group cascade completed")
+}
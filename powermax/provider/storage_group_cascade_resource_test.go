@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccStorageGroupCascadeResource(t *testing.T) {
+	var cascadeTerraformName = "powermax_storage_group_cascade.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + storageGroupCascadeConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cascadeTerraformName, "id", "tfacc_cascade_parent"),
+					resource.TestCheckResourceAttr(cascadeTerraformName, "child_storage_group_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(cascadeTerraformName, "child_storage_group_names.*", "tfacc_cascade_child1"),
+				),
+			},
+			// Add a child and remove another one
+			{
+				Config: ProviderConfig + storageGroupCascadeUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cascadeTerraformName, "child_storage_group_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(cascadeTerraformName, "child_storage_group_names.*", "tfacc_cascade_child2"),
+				),
+			},
+			// Import test
+			{
+				Config:            ProviderConfig + storageGroupCascadeUpdateConfig,
+				ResourceName:      cascadeTerraformName,
+				ImportState:       true,
+				ImportStateId:     "tfacc_cascade_parent",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStorageGroupCascadeResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.AddChildStorageGroups).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + storageGroupCascadeConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccStorageGroupCascadeResourceInvalidChild(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + storageGroupCascadeSelfConfig,
+				ExpectError: regexp.MustCompile(`.*cannot be its own child*.`),
+			},
+		},
+	})
+}
+
+var storageGroupCascadeStorageGroupsConfig = `
+resource "powermax_storagegroup" "cascade_parent" {
+	name   = "tfacc_cascade_parent"
+	srp_id = "SRP_1"
+}
+
+resource "powermax_storagegroup" "cascade_child1" {
+	name   = "tfacc_cascade_child1"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+}
+
+resource "powermax_storagegroup" "cascade_child2" {
+	name   = "tfacc_cascade_child2"
+	srp_id = "SRP_1"
+	slo    = "Silver"
+}
+`
+
+var storageGroupCascadeConfig = storageGroupCascadeStorageGroupsConfig + `
+resource "powermax_storage_group_cascade" "test" {
+	parent_storage_group_name = powermax_storagegroup.cascade_parent.name
+	child_storage_group_names = [powermax_storagegroup.cascade_child1.name]
+}
+`
+
+var storageGroupCascadeUpdateConfig = storageGroupCascadeStorageGroupsConfig + `
+resource "powermax_storage_group_cascade" "test" {
+	parent_storage_group_name = powermax_storagegroup.cascade_parent.name
+	child_storage_group_names = [powermax_storagegroup.cascade_child2.name]
+	force_remove = true
+}
+`
+
+var storageGroupCascadeSelfConfig = storageGroupCascadeStorageGroupsConfig + `
+resource "powermax_storage_group_cascade" "test" {
+	parent_storage_group_name = powermax_storagegroup.cascade_parent.name
+	child_storage_group_names = [powermax_storagegroup.cascade_parent.name]
+}
+`
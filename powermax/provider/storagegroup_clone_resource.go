@@ -0,0 +1,445 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &StorageGroupClone{}
+	_ resource.ResourceWithConfigure   = &StorageGroupClone{}
+	_ resource.ResourceWithImportState = &StorageGroupClone{}
+)
+
+// NewStorageGroupClone is a helper function to simplify the provider implementation.
+func NewStorageGroupClone() resource.Resource {
+	return &StorageGroupClone{}
+}
+
+// StorageGroupClone defines the resource implementation.
+type StorageGroupClone struct {
+	client *client.Client
+}
+
+// Schema Resource schema.
+func (r *StorageGroupClone) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for managing TimeFinder Clone sessions between storage groups in PowerMax array. A clone session makes a full copy of the volumes of a source storage group onto the volumes of a target storage group. The target storage group must exist and contain volumes matching the source volumes.",
+		Description:         "Resource for managing TimeFinder Clone sessions between storage groups in PowerMax array. A clone session makes a full copy of the volumes of a source storage group onto the volumes of a target storage group. The target storage group must exist and contain volumes matching the source volumes.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The ID of the clone session in the format 'storage_group_name.target_storage_group_name'.",
+				MarkdownDescription: "The ID of the clone session in the format 'storage_group_name.target_storage_group_name'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_group_name": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the source storage group.",
+				MarkdownDescription: "The name of the source storage group.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_storage_group_name": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the target storage group.",
+				MarkdownDescription: "The name of the target storage group.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"action": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The action to perform on the clone session. Actions: Activate (set the pairs to copy mode so the background copy runs), Recreate (incrementally re-establish the pairs), Restore (copy the target back to the source), Split (split the pairs). The action is performed when its value changes. (Update Supported)",
+				MarkdownDescription: "The action to perform on the clone session. Actions: Activate (set the pairs to copy mode so the background copy runs), Recreate (incrementally re-establish the pairs), Restore (copy the target back to the source), Split (split the pairs). The action is performed when its value changes. (Update Supported)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(helper.ActionCloneActivate, helper.ActionCloneRecreate, helper.ActionCloneRestore, helper.ActionCloneSplit),
+				},
+			},
+			"consistent": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Establish the clone session with consistency.",
+				MarkdownDescription: "Establish the clone session with consistency.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"vse": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Establish the clone session with the VSE option.",
+				MarkdownDescription: "Establish the clone session with the VSE option.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"force": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Attempts to force the clone operations even though one or more volumes may not be in the normal, expected state(s). (Update Supported)",
+				MarkdownDescription: "Attempts to force the clone operations even though one or more volumes may not be in the normal, expected state(s). (Update Supported)",
+			},
+			"star": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Acknowledge the volumes are in an SRDF/Star configuration. (Update Supported)",
+				MarkdownDescription: "Acknowledge the volumes are in an SRDF/Star configuration. (Update Supported)",
+			},
+			"skip": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Skips the source locks action. (Update Supported)",
+				MarkdownDescription: "Skips the source locks action. (Update Supported)",
+			},
+			"state": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Description:         "The distinct states of the clone volume pairs.",
+				MarkdownDescription: "The distinct states of the clone volume pairs.",
+			},
+			"volume_pair_count": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The number of clone volume pairs.",
+				MarkdownDescription: "The number of clone volume pairs.",
+			},
+			"modified_tracks": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The number of tracks still to be copied across all the clone volume pairs.",
+				MarkdownDescription: "The number of tracks still to be copied across all the clone volume pairs.",
+			},
+			"src_protected_tracks": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The number of protected source tracks across all the clone volume pairs.",
+				MarkdownDescription: "The number of protected source tracks across all the clone volume pairs.",
+			},
+			"src_modified_tracks": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The number of modified source tracks across all the clone volume pairs.",
+				MarkdownDescription: "The number of modified source tracks across all the clone volume pairs.",
+			},
+			"volume_pairs": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "The clone volume pairs of the clone session.",
+				MarkdownDescription: "The clone volume pairs of the clone session.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_volume_name": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The source volume of the pair.",
+							MarkdownDescription: "The source volume of the pair.",
+						},
+						"target_volume_name": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The target volume of the pair.",
+							MarkdownDescription: "The target volume of the pair.",
+						},
+						"state": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The state of the pair.",
+							MarkdownDescription: "The state of the pair.",
+						},
+						"modified_tracks": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "The number of tracks still to be copied for the pair.",
+							MarkdownDescription: "The number of tracks still to be copied for the pair.",
+						},
+						"src_protected_tracks": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "The number of protected source tracks of the pair.",
+							MarkdownDescription: "The number of protected source tracks of the pair.",
+						},
+						"src_modified_tracks": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "The number of modified source tracks of the pair.",
+							MarkdownDescription: "The number of modified source tracks of the pair.",
+						},
+						"background_copy": schema.BoolAttribute{
+							Computed:            true,
+							Description:         "Whether background copy is set for the pair.",
+							MarkdownDescription: "Whether background copy is set for the pair.",
+						},
+						"differential": schema.BoolAttribute{
+							Computed:            true,
+							Description:         "Whether the pair is differential.",
+							MarkdownDescription: "Whether the pair is differential.",
+						},
+						"precopy": schema.BoolAttribute{
+							Computed:            true,
+							Description:         "Whether precopy is set for the pair.",
+							MarkdownDescription: "Whether precopy is set for the pair.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Metadata Resource metadata.
+func (r *StorageGroupClone) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_group_clone"
+}
+
+// Configure StorageGroupClone.
+func (r *StorageGroupClone) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = pmaxClient
+}
+
+// Create StorageGroupClone.
+func (r *StorageGroupClone) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating storage group clone")
+
+	var plan models.StorageGroupCloneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cloneResponse, _, err := helper.EstablishStorageGroupClone(ctx, *r.client, plan)
+	if err != nil {
+		errStr := constants.CreateSgCloneDetailErrorMsg + plan.StorageGroupName.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating storage group clone", msgStr,
+		)
+		return
+	}
+	tflog.Debug(ctx, "establish storage group clone response", map[string]interface{}{
+		"cloneResponse": cloneResponse,
+	})
+
+	state := plan
+	// Establishing the session already recreates the pairs, any other action is performed straight after
+	if !plan.Action.IsNull() && plan.Action.ValueString() != helper.ActionCloneRecreate {
+		_, _, err = helper.ModifyStorageGroupClone(ctx, *r.client, plan)
+		if err != nil {
+			errStr := constants.UpdateSgCloneDetailsErrorMsg + plan.StorageGroupName.ValueString() + " with error: "
+			msgStr := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error creating storage group clone", msgStr,
+			)
+			// Save the established session without the action so that it is tracked
+			state.Action = types.StringNull()
+		}
+	}
+
+	errState := helper.UpdateStorageGroupCloneState(ctx, *r.client, &state)
+	if errState != nil {
+		errStr := constants.ReadSgCloneDetailsErrorMsg + plan.StorageGroupName.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(errState, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading storage group clone", msgStr,
+		)
+		// The session is established, save it so that it is tracked and refreshed on the next plan
+		helper.SetStorageGroupCloneMinimalState(&state)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create storage group clone completed")
+}
+
+// Read StorageGroupClone, the resource is removed when the clone session no longer exists.
+func (r *StorageGroupClone) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading storage group clone")
+	var state models.StorageGroupCloneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "getting storage group clone", map[string]interface{}{
+		"symmetrixID":        r.client.SymmetrixID,
+		"storageGroup":       state.StorageGroupName.ValueString(),
+		"targetStorageGroup": state.TargetStorageGroupName.ValueString(),
+	})
+	exists, err := helper.StorageGroupCloneExists(ctx, *r.client, state.StorageGroupName.ValueString(), state.TargetStorageGroupName.ValueString())
+	if err == nil && !exists {
+		tflog.Info(ctx, "storage group clone no longer exists")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err == nil {
+		err = helper.UpdateStorageGroupCloneState(ctx, *r.client, &state)
+	}
+	if err != nil {
+		errStr := constants.ReadSgCloneDetailsErrorMsg + state.StorageGroupName.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading storage group clone", msgStr,
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "read storage group clone completed")
+}
+
+// Update StorageGroupClone
+// Supported updates: action, force, star, skip.
+func (r *StorageGroupClone) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating storage group clone")
+	var plan, state models.StorageGroupCloneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Action.IsNull() && plan.Action.ValueString() != state.Action.ValueString() {
+		_, _, err := helper.ModifyStorageGroupClone(ctx, *r.client, plan)
+		if err != nil {
+			errStr := constants.UpdateSgCloneDetailsErrorMsg + plan.StorageGroupName.ValueString() + " with error: "
+			msgStr := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error updating storage group clone", msgStr,
+			)
+			return
+		}
+	}
+
+	err := helper.UpdateStorageGroupCloneState(ctx, *r.client, &plan)
+	if err != nil {
+		errStr := constants.ReadSgCloneDetailsErrorMsg + plan.StorageGroupName.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading storage group clone", msgStr,
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "update storage group clone completed")
+}
+
+// Delete StorageGroupClone.
+func (r *StorageGroupClone) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting storage group clone")
+	var state models.StorageGroupCloneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "calling terminate storage group clone on pmax client", map[string]interface{}{
+		"symmetrixID":        r.client.SymmetrixID,
+		"storageGroup":       state.StorageGroupName.ValueString(),
+		"targetStorageGroup": state.TargetStorageGroupName.ValueString(),
+	})
+	_, err := helper.TerminateStorageGroupClone(ctx, *r.client, state)
+	if err != nil {
+		errStr := constants.DeleteSgCloneDetailsErrorMsg + state.StorageGroupName.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error deleting storage group clone", msgStr,
+		)
+	}
+	tflog.Info(ctx, "delete storage group clone completed")
+}
+
+// ImportState import resource.
+func (r *StorageGroupClone) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing storage group clone")
+	ids := strings.Split(req.ID, ".")
+	if len(ids) != 2 {
+		resp.Diagnostics.AddError(
+			"Error importing storage group clone",
+			"The import ID must be 'storage_group_name.target_storage_group_name'",
+		)
+		return
+	}
+
+	state := models.StorageGroupCloneResourceModel{
+		StorageGroupName:       types.StringValue(ids[0]),
+		TargetStorageGroupName: types.StringValue(ids[1]),
+		Action:                 types.StringNull(),
+		Consistent:             types.BoolNull(),
+		Vse:                    types.BoolNull(),
+		Force:                  types.BoolNull(),
+		Star:                   types.BoolNull(),
+		Skip:                   types.BoolNull(),
+	}
+	err := helper.UpdateStorageGroupCloneState(ctx, *r.client, &state)
+	if err != nil {
+		errStr := constants.ImportSgCloneDetailsErrorMsg + req.ID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error importing storage group clone", msgStr,
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var createStorageGroupCloneConfig = `
+resource "powermax_storage_group_clone" "test_clone" {
+	storage_group_name = "tfacc_sg_clone_source"
+	target_storage_group_name = "tfacc_sg_clone_target"
+}
+`
+var updateStorageGroupCloneConfig = `
+resource "powermax_storage_group_clone" "test_clone" {
+	storage_group_name = "tfacc_sg_clone_source"
+	target_storage_group_name = "tfacc_sg_clone_target"
+	# This will be updated
+	action = "Split"
+}
+`
+
+var recreateStorageGroupCloneConfig = `
+resource "powermax_storage_group_clone" "test_clone" {
+	storage_group_name = "tfacc_sg_clone_source"
+	target_storage_group_name = "tfacc_sg_clone_target"
+	action = "Recreate"
+}
+`
+
+func TestAccStorageGroupCloneResource(t *testing.T) {
+	var cloneTerraformName = "powermax_storage_group_clone.test_clone"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + createStorageGroupCloneConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cloneTerraformName, "id", "tfacc_sg_clone_source.tfacc_sg_clone_target"),
+					resource.TestCheckResourceAttrSet(cloneTerraformName, "volume_pair_count"),
+					resource.TestCheckResourceAttrSet(cloneTerraformName, "state.#"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      cloneTerraformName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: ProviderConfig + updateStorageGroupCloneConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cloneTerraformName, "action", "Split"),
+				),
+			},
+			// A clone session terminated outside of Terraform is planned again
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.StorageGroupCloneExists).Return(false, nil).Build()
+				},
+				Config:             ProviderConfig + updateStorageGroupCloneConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Read test Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.GetStorageGroupClonePairs).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + updateStorageGroupCloneConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Modify Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.ModifyStorageGroupClone).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + recreateStorageGroupCloneConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// auto checks delete to clean up the test
+		},
+	})
+}
+
+func TestAccStorageGroupCloneResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.EstablishStorageGroupClone).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createStorageGroupCloneConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccStorageGroupCloneResourceCreateReadError(t *testing.T) {
+	var cloneTerraformName = "powermax_storage_group_clone.test_clone"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The established session is saved when it cannot be read
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.UpdateStorageGroupCloneState).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createStorageGroupCloneConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// so the next apply replaces the tracked session instead of establishing it a second time
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+				},
+				Config: ProviderConfig + createStorageGroupCloneConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(cloneTerraformName, "id", "tfacc_sg_clone_source.tfacc_sg_clone_target"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageGroupCloneResourceImportError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        ProviderConfig + createStorageGroupCloneConfig,
+				ResourceName:  "powermax_storage_group_clone.test_clone",
+				ImportState:   true,
+				ImportStateId: "tfacc_sg_clone_source",
+				ExpectError:   regexp.MustCompile(`.*The import ID must be*.`),
+			},
+		},
+	})
+}
@@ -208,7 +208,8 @@ func (d *StorageGroupDataSource) Schema(ctx context.Context, req datasource.Sche
 							Description:         "VP saved percentage figure",
 							MarkdownDescription: "VP saved percentage figure",
 						},
-						"tags": schema.StringAttribute{
+						"tags": schema.SetAttribute{
+							ElementType:         types.StringType,
 							Computed:            true,
 							Description:         "The tags associated with the storage group",
 							MarkdownDescription: "The tags associated with the storage group",
@@ -229,6 +230,17 @@ func (d *StorageGroupDataSource) Schema(ctx context.Context, req datasource.Sche
 							Description:         "The IDs of the volume associated with the storage group.",
 							MarkdownDescription: "The IDs of the volume associated with the storage group.",
 						},
+						"volume_attributes": schema.ListAttribute{
+							ElementType:         types.ObjectType{AttrTypes: helper.StorageGroupVolumeAttributeType},
+							Computed:            true,
+							Description:         "The volumes created with the storage group. Only known to the resource which created the storage group, always empty here.",
+							MarkdownDescription: "The volumes created with the storage group. Only known to the resource which created the storage group, always empty here.",
+						},
+						"allocate_capacity_for_each_vol": schema.BoolAttribute{
+							Computed:            true,
+							Description:         "Whether the volumes created with the storage group are fully allocated. Only known to the resource which created the storage group, always empty here.",
+							MarkdownDescription: "Whether the volumes created with the storage group are fully allocated. Only known to the resource which created the storage group, always empty here.",
+						},
 					},
 				},
 			},
@@ -240,6 +252,12 @@ func (d *StorageGroupDataSource) Schema(ctx context.Context, req datasource.Sche
 						Optional:    true,
 						ElementType: types.StringType,
 					},
+					"tags": schema.SetAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						Description:         "Only list the storage groups carrying any of the tags.",
+						MarkdownDescription: "Only list the storage groups carrying any of the tags.",
+					},
 				},
 			},
 		},
@@ -297,6 +315,28 @@ func (d *StorageGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 		}
 	}
 
+	// Only keep the storage groups carrying the tags
+	if data.StorageGroupFilter != nil && len(data.StorageGroupFilter.Tags) > 0 {
+		var tags []string
+		for _, tag := range data.StorageGroupFilter.Tags {
+			tags = append(tags, tag.ValueString())
+		}
+		taggedIDs, err := helper.GetTaggedStorageGroupNames(ctx, *d.client, tags)
+		if err != nil {
+			errStr := ""
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError("Error reading tagged storage groups:", message)
+			return
+		}
+		var filteredIDs []string
+		for _, sgID := range sgIDs {
+			if helper.StringInSlice(sgID, taggedIDs) {
+				filteredIDs = append(filteredIDs, sgID)
+			}
+		}
+		sgIDs = filteredIDs
+	}
+
 	// iterate sgIDs and GetStorageGroup with each id
 	for _, sgID := range sgIDs {
 		var sg models.StorageGroupResourceModel
@@ -64,6 +64,23 @@ func TestAccStorageGroupDataSource(t *testing.T) {
 	})
 }
 
+func TestAccStorageGroupDataSourceTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + SgTagsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.tagged", "storage_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.tagged", "storage_groups.0.name", "tfacc_sg_ds_tags"),
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.tagged", "storage_groups.0.tags.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccStorageGroupDataSourceErrorNotFound(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -129,3 +146,19 @@ var SgAllDataSourceConfig = `
 data "powermax_storagegroup" "test" {
 }
 `
+
+var SgTagsDataSourceConfig = `
+resource "powermax_storagegroup" "tagged" {
+	name   = "tfacc_sg_ds_tags"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+	tags   = ["tfacc_sg_ds_tag"]
+}
+
+data "powermax_storagegroup" "tagged" {
+	depends_on = [powermax_storagegroup.tagged]
+	filter {
+		tags = ["tfacc_sg_ds_tag"]
+	}
+}
+`
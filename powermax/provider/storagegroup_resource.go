@@ -19,22 +19,26 @@ package provider
 
 import (
 	"context"
-	"dell/powermax-go-client"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/helper"
 	"terraform-provider-powermax/powermax/models"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -42,6 +46,8 @@ import (
 var _ resource.Resource = &StorageGroup{}
 var _ resource.ResourceWithConfigure = &StorageGroup{}
 var _ resource.ResourceWithImportState = &StorageGroup{}
+var _ resource.ResourceWithValidateConfig = &StorageGroup{}
+var _ resource.ResourceWithUpgradeState = &StorageGroup{}
 
 // NewStorageGroup is a helper function to simplify the provider implementation.
 func NewStorageGroup() resource.Resource {
@@ -61,6 +67,8 @@ func (r *StorageGroup) Metadata(ctx context.Context, req resource.MetadataReques
 // Schema Resource schema.
 func (r *StorageGroup) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 changed tags from a comma separated string to a set
+		Version: 1,
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Resource for managing StorageGroups in PowerMax array. PowerMax storage groups are a collection of devices that are stored on the array. An application, a server, or a collection of servers use them.",
 		Description:         "Resource for managing StorageGroups in PowerMax array. PowerMax storage groups are a collection of devices that are stored on the array. An application, a server, or a collection of servers use them.",
@@ -103,8 +111,8 @@ func (r *StorageGroup) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"workload": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				Description:         "The workload associated with the storage group. (Update Supported)",
-				MarkdownDescription: "The workload associated with the storage group. (Update Supported)",
+				Description:         "The workload associated with the storage group, applied when the storage group is created. (Update Supported)",
+				MarkdownDescription: "The workload associated with the storage group, applied when the storage group is created. (Update Supported)",
 			},
 			"slo_compliance": schema.StringAttribute{
 				Computed:            true,
@@ -186,25 +194,52 @@ func (r *StorageGroup) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description:         "The snapshot policies associated with the storage group",
 				MarkdownDescription: "The snapshot policies associated with the storage group",
 			},
-			"host_io_limit": schema.ObjectAttribute{
-				Computed:            true,
+			"host_io_limit": schema.SingleNestedAttribute{
 				Optional:            true,
-				Description:         "Host IO limit of the storage group. (Update Supported)",
-				MarkdownDescription: "Host IO limit of the storage group. (Update Supported)",
-				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
-				},
-				AttributeTypes: map[string]attr.Type{
-					"host_io_limit_io_sec": types.StringType,
-					"host_io_limit_mb_sec": types.StringType,
-					"dynamic_distribution": types.StringType,
+				Description:         "Host IO limit of the storage group. Removing it removes the limits from the storage group. (Update Supported)",
+				MarkdownDescription: "Host IO limit of the storage group. Removing it removes the limits from the storage group. (Update Supported)",
+				Attributes: map[string]schema.Attribute{
+					"host_io_limit_io_sec": schema.StringAttribute{
+						Required:            true,
+						Description:         "The IOs per second host IO limit of the storage group, a multiple of 100 or NOLIMIT.",
+						MarkdownDescription: "The IOs per second host IO limit of the storage group, a multiple of 100 or NOLIMIT.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(
+								regexp.MustCompile(`^(NOLIMIT|[1-9][0-9]*00)$`),
+								"must be a multiple of 100 or NOLIMIT",
+							),
+						},
+					},
+					"host_io_limit_mb_sec": schema.StringAttribute{
+						Required:            true,
+						Description:         "The MBs per second host IO limit of the storage group, a positive number or NOLIMIT.",
+						MarkdownDescription: "The MBs per second host IO limit of the storage group, a positive number or NOLIMIT.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(
+								regexp.MustCompile(`^(NOLIMIT|[1-9][0-9]*)$`),
+								"must be a positive number or NOLIMIT",
+							),
+						},
+					},
+					"dynamic_distribution": schema.StringAttribute{
+						Required:            true,
+						Description:         "The dynamic distribution of the host IO limit, one of Never, Always or OnFailure.",
+						MarkdownDescription: "The dynamic distribution of the host IO limit, one of Never, Always or OnFailure.",
+						Validators: []validator.String{
+							stringvalidator.OneOf(
+								helper.DynamicDistributionNever,
+								helper.DynamicDistributionAlways,
+								helper.DynamicDistributionOnFailure,
+							),
+						},
+					},
 				},
 			},
 			"compression": schema.BoolAttribute{
 				Computed:            true,
 				Optional:            true,
-				Description:         "States whether compression is enabled on storage group. (Update Supported)",
-				MarkdownDescription: "States whether compression is enabled on storage group. (Update Supported)",
+				Description:         "States whether compression is enabled on storage group. When not set the storage group is created with the array default. (Update Supported)",
+				MarkdownDescription: "States whether compression is enabled on storage group. When not set the storage group is created with the array default. (Update Supported)",
 			},
 			"compression_ratio": schema.StringAttribute{
 				Computed:            true,
@@ -221,10 +256,24 @@ func (r *StorageGroup) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description:         "VP saved percentage figure",
 				MarkdownDescription: "VP saved percentage figure",
 			},
-			"tags": schema.StringAttribute{
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
 				Computed:            true,
-				Description:         "The tags associated with the storage group",
-				MarkdownDescription: "The tags associated with the storage group",
+				Description:         "The tags associated with the storage group. When not set the tags on the array are left as they are. (Update Supported)",
+				MarkdownDescription: "The tags associated with the storage group. When not set the tags on the array are left as they are. (Update Supported)",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthBetween(1, 64),
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^[^,]*$`),
+							"must not contain a comma",
+						),
+					),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"uuid": schema.StringAttribute{
 				Computed:            true,
@@ -243,6 +292,68 @@ func (r *StorageGroup) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description:         "The IDs of the volume associated with the storage group. Only pre-existing volumes are considered here. (Update Supported)",
 				MarkdownDescription: "The IDs of the volume associated with the storage group. Only pre-existing volumes are considered here. (Update Supported)",
 			},
+			"volume_attributes": schema.ListNestedAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "The volumes to create with the storage group. The created volumes are listed in volume_ids. Only used when the storage group is created, later changes are ignored with a warning.",
+				MarkdownDescription: "The volumes to create with the storage group. The created volumes are listed in volume_ids. Only used when the storage group is created, later changes are ignored with a warning.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("volume_ids")),
+				},
+				PlanModifiers: []planmodifier.List{
+					createOnlyModifier{resourceName: "storage group", hint: "Add or remove volumes with volume_ids instead."},
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"size": schema.NumberAttribute{
+							Required:            true,
+							Description:         "The size of each volume.",
+							MarkdownDescription: "The size of each volume.",
+						},
+						"cap_unit": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(helper.CapacityUnitGb),
+							Description:         "The Capacity Unit corresponding to the size.",
+							MarkdownDescription: "The Capacity Unit corresponding to the size.",
+							Validators: []validator.String{
+								stringvalidator.OneOf([]string{
+									helper.CapacityUnitMb,
+									helper.CapacityUnitGb,
+									helper.CapacityUnitTb,
+									helper.CapacityUnitCyl,
+								}...),
+							},
+						},
+						"num_of_vols": schema.Int64Attribute{
+							Required:            true,
+							Description:         "The number of volumes to create with this size.",
+							MarkdownDescription: "The number of volumes to create with this size.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"volume_identifier": schema.StringAttribute{
+							Optional:            true,
+							Description:         "The identifier of the created volumes.",
+							MarkdownDescription: "The identifier of the created volumes.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"allocate_capacity_for_each_vol": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Allocate the full capacity of the volumes created with the storage group (thick volumes). Only used when the storage group is created, later changes are ignored with a warning.",
+				MarkdownDescription: "Allocate the full capacity of the volumes created with the storage group (thick volumes). Only used when the storage group is created, later changes are ignored with a warning.",
+				PlanModifiers: []planmodifier.Bool{
+					createOnlyModifier{resourceName: "storage group", hint: "Add or remove volumes with volume_ids instead."},
+				},
+			},
 		},
 	}
 }
@@ -268,6 +379,26 @@ func (r *StorageGroup) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = pmaxClient
 }
 
+// ValidateConfig checks that a configured host io limit limits the storage group.
+func (r *StorageGroup) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config models.StorageGroupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	hostIOLimit := helper.ConstructHostIOLimit(config)
+	if hostIOLimit == nil || hostIOLimit.HostIOLimitIOSec.IsUnknown() || hostIOLimit.HostIOLimitMBSec.IsUnknown() {
+		return
+	}
+	if !helper.IsHostIOLimitSet(hostIOLimit.HostIOLimitIOSec.ValueString(), hostIOLimit.HostIOLimitMBSec.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host_io_limit"),
+			"Invalid host IO limit",
+			"host_io_limit_io_sec and host_io_limit_mb_sec can not both be NOLIMIT, remove host_io_limit to remove the limits of the storage group",
+		)
+	}
+}
+
 // Create a storage group.
 func (r *StorageGroup) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Info(ctx, "Creating Storage Group...")
@@ -309,6 +440,29 @@ func (r *StorageGroup) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Tag the storage group
+	if !plan.Tags.IsNull() && !plan.Tags.IsUnknown() && len(plan.Tags.Elements()) > 0 {
+		var planTags []string
+		resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &planTags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err = helper.UpdateStorageGroupTags(ctx, *r.client, plan.StorageGroupID.ValueString(), planTags, []string{})
+		if err != nil {
+			// The storage group may already hold volumes, so it is kept and saved with the tags it carries.
+			// An error would taint and recreate it, the missing tags are added on the next apply instead.
+			errStr := ""
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddWarning("Failed to tag storage group", message+"\nThe tags are added on the next apply.")
+		}
+	}
+
+	// Creation only attributes are not returned by the array
+	state.VolumeAttributes = plan.VolumeAttributes
+	state.AllocateCapacityForEachVol = plan.AllocateCapacityForEachVol
+	if state.AllocateCapacityForEachVol.IsUnknown() {
+		state.AllocateCapacityForEachVol = types.BoolNull()
+	}
 	err = helper.UpdateSgState(ctx, r.client, plan.StorageGroupID.ValueString(), &state)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating state for storage group", err.Error())
@@ -367,166 +521,23 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Read Storage Group ID from state in case of renaming
-	stateID := state.StorageGroupID.ValueString()
-	sgID := stateID
-	payload := r.client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, r.client.SymmetrixID, sgID)
-	updateName := false
 	// Storage Group update need to be done separately because only one payload is accepted by the REST API
-	// Rename
-	planID := plan.StorageGroupID.ValueString()
-	if stateID != planID {
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
-			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				RenameStorageGroupParam: &powermax.RenameStorageGroupParam{
-					NewStorageGroupName: planID,
-				},
-			},
-		})
-		_, _, err := payload.Execute()
-		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update Storage Group ID(name)", message)
-			tflog.Error(ctx, fmt.Sprintf("Failed to update Storage Group ID(name): %s", message))
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Update Storage Group ID(name): %s", planID))
-			sgID = planID
-			state.StorageGroupID = types.StringValue(planID)
-		}
-		updateName = true
-	}
-
-	// Recreate the modify storage group param with the new name on a rename job
-	if updateName {
-		payload = r.client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, r.client.SymmetrixID, planID)
-	}
-
-	// Edit Compression
-	planCompression := plan.Compression.ValueBool()
-	stateCompression := state.Compression.ValueBool()
-	if planCompression != stateCompression {
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
-			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				EditCompressionParam: &powermax.EditCompressionParam{
-					Compression: &planCompression,
-				},
-			},
-		})
-		_, _, err := payload.Execute()
-		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update compression:", message)
-			tflog.Error(ctx, fmt.Sprintf("Failed to update compression: %s", err.Error()))
-
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Update compression: %t", planCompression))
-			state.Compression = types.BoolValue(planCompression)
-		}
-	}
-
-	// SetHostIOLimit
-	if !plan.HostIOLimit.IsNull() && !plan.HostIOLimit.Equal(state.HostIOLimit) {
-		hostIOLimit := helper.ConstructHostIOLimit(plan)
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
-			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				SetHostIOLimitsParam: &powermax.SetHostIOLimitsParam{
-					HostIoLimitMbSec:    hostIOLimit.HostIOLimitMBSec.ValueStringPointer(),
-					HostIoLimitIoSec:    hostIOLimit.HostIOLimitIOSec.ValueStringPointer(),
-					DynamicDistribution: hostIOLimit.DynamicDistribution.ValueStringPointer(),
-				},
-			},
-		})
-		_, _, err := payload.Execute()
-		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update hostIOLimit:", message)
-			tflog.Error(ctx, fmt.Sprintf("Failed to update hostIOLimit: %s", err.Error()))
-
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Update hostIOLimit: %v", plan.HostIOLimit))
-		}
-	}
-
-	// Edit Workload
-	planWorkload := plan.Workload.ValueString()
-	stateWorkload := state.Workload.ValueString()
-	if planWorkload != stateWorkload {
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
-			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				EditStorageGroupWorkloadParam: &powermax.EditStorageGroupWorkloadParam{
-					WorkloadSelection: planWorkload,
-				},
-			},
-		})
-		_, _, err := payload.Execute()
-		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update workload:", message)
-			tflog.Error(ctx, fmt.Sprintf("Failed to update workload: %s", err.Error()))
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Update workload: %s", planWorkload))
-			state.Workload = types.StringValue(planWorkload)
-		}
-	}
-
-	// Edit Slo
-	planSLO := plan.Slo.ValueString()
-	stateSLO := state.Slo.ValueString()
-	if planSLO != stateSLO {
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
-			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				EditStorageGroupSLOParam: &powermax.EditStorageGroupSLOParam{
-					SloId: planSLO,
-				},
-			},
-		})
-		_, _, err := payload.Execute()
-		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update Slo:", message)
-			tflog.Error(ctx, fmt.Sprintf("Failed to update Slo: %s", err.Error()))
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Update Slo: %s", planSLO))
-			state.Slo = types.StringValue(planSLO)
-		}
-	}
-
-	// Edit Srp
-	planSRP := plan.Srp.ValueString()
-	stateSRP := state.Srp.ValueString()
-	if planSRP != stateSRP {
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
-			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				EditStorageGroupSRPParam: &powermax.EditStorageGroupSRPParam{
-					SrpId: planSRP,
-				},
-			},
-		})
-		_, _, err := payload.Execute()
-		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update Srp:", message)
-			tflog.Error(ctx, fmt.Sprintf("Failed to update Srp: %s", message))
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Update Srp: %s", planSLO))
-			state.Srp = types.StringValue(planSRP)
-		}
-	}
-
-	// Update Volume
-	err := helper.AddRemoveVolume(ctx, &plan, &state, r.client, sgID)
+	steps, err := helper.GetStorageGroupUpdatePlan(ctx, plan, state)
 	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update volume on storage group %s:", sgID), err.Error())
+		resp.Diagnostics.AddError("Failed to plan the storage group update", err.Error())
+		return
+	}
+	result := helper.ApplyStorageGroupUpdatePlan(ctx, r.client, steps, &state)
+	if result.Err != nil {
+		// Only the applied steps are saved, the others are applied again on the next apply
+		tflog.Error(ctx, fmt.Sprintf("Failed to update Storage Group %s: %s", state.StorageGroupID.ValueString(), result.Summary()))
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update Storage Group %s", state.StorageGroupID.ValueString()), result.Summary())
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 		return
 	}
+	tflog.Debug(ctx, fmt.Sprintf("Updated Storage Group %s: %v", state.StorageGroupID.ValueString(), result.Applied))
 
-	err = helper.UpdateSgState(ctx, r.client, sgID, &state)
+	err = helper.UpdateSgState(ctx, r.client, state.StorageGroupID.ValueString(), &state)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating state for storage group:", err.Error())
 		return
@@ -564,3 +575,32 @@ func (r *StorageGroup) Delete(ctx context.Context, req resource.DeleteRequest, r
 func (r *StorageGroup) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
+
+// UpgradeState upgrades the state of a storage group from the previous schema versions.
+func (r *StorageGroup) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// tags was a comma separated string in version 0, which had none of the create only volume attributes
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var rawState map[string]interface{}
+				if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade storage group state", err.Error())
+					return
+				}
+				tags, _ := rawState["tags"].(string)
+				rawState["tags"] = helper.ParseTags(tags)
+				for _, name := range []string{"volume_attributes", "allocate_capacity_for_each_vol"} {
+					if _, ok := rawState[name]; !ok {
+						rawState[name] = nil
+					}
+				}
+				upgradedState, err := json.Marshal(rawState)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade storage group state", err.Error())
+					return
+				}
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgradedState}
+			},
+		},
+	}
+}
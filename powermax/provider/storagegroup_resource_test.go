@@ -18,13 +18,21 @@ limitations under the License.
 package provider
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 	"terraform-provider-powermax/powermax/helper"
 	"testing"
 
 	. "github.com/bytedance/mockey"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccStorageGroupResourceA(t *testing.T) {
@@ -87,6 +95,28 @@ func TestAccStorageGroupResourceA(t *testing.T) {
 				// Remove volume ahead of storage group
 				Config: ProviderConfig + StorageGroupUpdateVolumeResourceConfig,
 			},
+			// Remove the host io limit
+			{
+				Config: ProviderConfig + StorageGroupRemoveHostIOLimitResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(storageGroupTerraformName, "host_io_limit.host_io_limit_io_sec"),
+					resource.TestCheckNoResourceAttr(storageGroupTerraformName, "host_io_limit.host_io_limit_mb_sec"),
+				),
+			},
+			// Set only the IOs per second limit again, then import it
+			{
+				Config: ProviderConfig + StorageGroupIOSecHostIOLimitResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "host_io_limit.host_io_limit_io_sec", "500"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "host_io_limit.host_io_limit_mb_sec", "NOLIMIT"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "host_io_limit.dynamic_distribution", "OnFailure"),
+				),
+			},
+			{
+				ResourceName:      storageGroupTerraformName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			// Read Mapping Error Check
 			{
 				PreConfig: func() {
@@ -168,6 +198,202 @@ func TestAccStorageGroupResourceNoHostIOLimit(t *testing.T) {
 					resource.TestCheckResourceAttr(storageGroupTerraformName, "compression", "true"),
 					resource.TestCheckResourceAttr(storageGroupTerraformName, "unprotected", "true"),
 					resource.TestCheckResourceAttr(storageGroupTerraformName, "cap_gb", "0"),
+					resource.TestCheckNoResourceAttr(storageGroupTerraformName, "host_io_limit.host_io_limit_io_sec"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageGroupResourceInvalidHostIOLimit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + StorageGroupInvalidIOSecResourceConfig,
+				ExpectError: regexp.MustCompile(".*must be a multiple of 100 or NOLIMIT*."),
+			},
+			{
+				Config:      ProviderConfig + StorageGroupInvalidDistributionResourceConfig,
+				ExpectError: regexp.MustCompile(".*Invalid Attribute Value Match*."),
+			},
+			{
+				Config:      ProviderConfig + StorageGroupNoLimitHostIOLimitResourceConfig,
+				ExpectError: regexp.MustCompile(".*Invalid host IO limit*."),
+			},
+		},
+	})
+}
+
+func TestAccStorageGroupResourceVolumeAttributes(t *testing.T) {
+	var storageGroupTerraformName = "powermax_storagegroup.test_volume_attributes"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// volume_attributes and volume_ids can not be set together
+			{
+				Config:      ProviderConfig + StorageGroupVolumeAttributesConflictConfig,
+				ExpectError: regexp.MustCompile(".*Invalid Attribute Combination*."),
+			},
+			// Create the storage group with its volumes in one call
+			{
+				Config: ProviderConfig + StorageGroupVolumeAttributesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "num_of_vols", "3"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "volume_ids.#", "3"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "compression", "false"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "workload", "None"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "volume_attributes.0.cap_unit", "GB"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "allocate_capacity_for_each_vol", "true"),
+				),
+			},
+			// Changes of volume_attributes are ignored, the storage group is neither recreated nor given new volumes
+			{
+				Config: ProviderConfig + strings.Replace(StorageGroupVolumeAttributesConfig, "num_of_vols       = 2", "num_of_vols       = 4", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "num_of_vols", "3"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "volume_ids.#", "3"),
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "volume_attributes.0.num_of_vols", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestStorageGroupResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &StorageGroup{}
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	tests := []struct {
+		tags     string
+		expected []string
+	}{
+		{`"a, b"`, []string{"a", "b"}},
+		{`""`, []string{}},
+	}
+	for _, test := range tests {
+		req := fwresource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(fmt.Sprintf(storageGroupResourceStateV0, test.tags))}}
+		resp := fwresource.UpgradeStateResponse{}
+		r.UpgradeState(ctx)[0].StateUpgrader(ctx, req, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected upgrade error for tags %s: %v", test.tags, resp.Diagnostics)
+		}
+
+		// The upgraded state must be a valid version 1 state
+		upgraded, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+		if err != nil {
+			t.Fatalf("the upgraded state does not match the schema for tags %s: %s", test.tags, err)
+		}
+		var attrs map[string]tftypes.Value
+		if err := upgraded.As(&attrs); err != nil {
+			t.Fatal(err)
+		}
+		var tagValues []tftypes.Value
+		if err := attrs["tags"].As(&tagValues); err != nil {
+			t.Fatal(err)
+		}
+		tags := []string{}
+		for _, tagValue := range tagValues {
+			var tag string
+			if err := tagValue.As(&tag); err != nil {
+				t.Fatal(err)
+			}
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		if !reflect.DeepEqual(tags, test.expected) {
+			t.Errorf("expected tags %v for %s, got %v", test.expected, test.tags, tags)
+		}
+	}
+}
+
+func TestAccStorageGroupResourceTags(t *testing.T) {
+	var storageGroupTerraformName = "powermax_storagegroup.test_tags"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create tagged
+			{
+				Config: ProviderConfig + fmt.Sprintf(StorageGroupTagsResourceConfig, `["tfacc_tag_a", "tfacc_tag_b"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr(storageGroupTerraformName, "tags.*", "tfacc_tag_a"),
+				),
+			},
+			// Replace a tag
+			{
+				Config: ProviderConfig + fmt.Sprintf(StorageGroupTagsResourceConfig, `["tfacc_tag_b", "tfacc_tag_c"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr(storageGroupTerraformName, "tags.*", "tfacc_tag_c"),
+				),
+			},
+			{
+				ResourceName:      storageGroupTerraformName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update error
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.UpdateStorageGroupTags).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + fmt.Sprintf(StorageGroupTagsResourceConfig, `[]`),
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Remove all tags
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+				},
+				Config: ProviderConfig + fmt.Sprintf(StorageGroupTagsResourceConfig, `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageGroupResourceCreateTagError(t *testing.T) {
+	var storageGroupTerraformName = "powermax_storagegroup.test_tags"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The storage group is kept without the tags when tagging fails
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.UpdateStorageGroupTags).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config: ProviderConfig + fmt.Sprintf(StorageGroupTagsResourceConfig, `["tfacc_tag_a"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "tags.#", "0"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// and tagged on the next apply
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+				},
+				Config: ProviderConfig + fmt.Sprintf(StorageGroupTagsResourceConfig, `["tfacc_tag_a"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(storageGroupTerraformName, "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(storageGroupTerraformName, "tags.*", "tfacc_tag_a"),
 				),
 			},
 		},
@@ -288,11 +514,155 @@ resource "powermax_storagegroup" "test_error_update" {
   	slo              = "slo-non-existent"
 	compression = false
 	host_io_limit = {
-    	host_io_limit_io_sec = "non-existent"
-    	host_io_limit_mb_sec = ""
-    	dynamic_distribution  = ""
+    	host_io_limit_io_sec = "100"
+    	host_io_limit_mb_sec = "NOLIMIT"
+    	dynamic_distribution  = "Always"
   	}
 	workload = "workload-non-existent"
 	volume_ids = ["non_existent_vol_id"]
 }
 `
+
+var StorageGroupVolumeAttributesConfig = `
+resource "powermax_storagegroup" "test_volume_attributes" {
+	name                           = "tfacc_sg_volume_attributes"
+	srp_id                         = "SRP_1"
+	slo                            = "Gold"
+	compression                    = false
+	allocate_capacity_for_each_vol = true
+	volume_attributes = [
+		{
+			size              = 1
+			num_of_vols       = 2
+			volume_identifier = "tfacc_sg_vol_data"
+		},
+		{
+			size              = 500
+			cap_unit          = "MB"
+			num_of_vols       = 1
+			volume_identifier = "tfacc_sg_vol_log"
+		},
+	]
+}
+`
+
+var StorageGroupVolumeAttributesConflictConfig = `
+resource "powermax_storagegroup" "test_volume_attributes" {
+	name        = "tfacc_sg_volume_attributes"
+	srp_id      = "SRP_1"
+	slo         = "Gold"
+	volume_ids  = ["005C6"]
+	volume_attributes = [
+		{
+			size        = 1
+			num_of_vols = 1
+		},
+	]
+}
+`
+
+var StorageGroupRemoveHostIOLimitResourceConfig = `
+resource "powermax_storagegroup" "test" {
+	name             = "tfacc_sg_rename"
+	slo              = "Silver"
+	srp_id           = "SRP_1"
+	compression      = false
+}
+`
+
+var StorageGroupIOSecHostIOLimitResourceConfig = `
+resource "powermax_storagegroup" "test" {
+	name             = "tfacc_sg_rename"
+	slo              = "Silver"
+	srp_id           = "SRP_1"
+	compression      = false
+	host_io_limit = {
+		host_io_limit_io_sec = "500"
+		host_io_limit_mb_sec = "NOLIMIT"
+		dynamic_distribution = "OnFailure"
+	}
+}
+`
+
+var StorageGroupInvalidIOSecResourceConfig = `
+resource "powermax_storagegroup" "test_invalid_host_io_limit" {
+	name             = "tfacc_sg_invalid_host_io_limit"
+	srp_id           = "SRP_1"
+	host_io_limit = {
+		host_io_limit_io_sec = "1050"
+		host_io_limit_mb_sec = "NOLIMIT"
+		dynamic_distribution = "Never"
+	}
+}
+`
+
+var StorageGroupInvalidDistributionResourceConfig = `
+resource "powermax_storagegroup" "test_invalid_host_io_limit" {
+	name             = "tfacc_sg_invalid_host_io_limit"
+	srp_id           = "SRP_1"
+	host_io_limit = {
+		host_io_limit_io_sec = "1000"
+		host_io_limit_mb_sec = "NOLIMIT"
+		dynamic_distribution = "Sometimes"
+	}
+}
+`
+
+var StorageGroupNoLimitHostIOLimitResourceConfig = `
+resource "powermax_storagegroup" "test_invalid_host_io_limit" {
+	name             = "tfacc_sg_invalid_host_io_limit"
+	srp_id           = "SRP_1"
+	host_io_limit = {
+		host_io_limit_io_sec = "NOLIMIT"
+		host_io_limit_mb_sec = "NOLIMIT"
+		dynamic_distribution = "Never"
+	}
+}
+`
+
+var StorageGroupTagsResourceConfig = `
+resource "powermax_storagegroup" "test_tags" {
+	name   = "tfacc_sg_tags_resource"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+	tags   = %s
+}
+`
+
+// storageGroupResourceStateV0 is a storage group state written by version 0 of the schema, with a placeholder for its tags
+var storageGroupResourceStateV0 = `{
+	"id": "tfacc_sg_upgrade",
+	"name": "tfacc_sg_upgrade",
+	"slo": "Gold",
+	"srp_id": "SRP_1",
+	"service_level": "Gold",
+	"workload": null,
+	"slo_compliance": "STABLE",
+	"num_of_vols": 1,
+	"num_of_child_sgs": 0,
+	"num_of_parent_sgs": 0,
+	"num_of_masking_views": 0,
+	"num_of_snapshots": 0,
+	"num_of_snapshot_policies": 0,
+	"cap_gb": 1,
+	"device_emulation": "FBA",
+	"type": "Standalone",
+	"unprotected": true,
+	"child_storage_group": [],
+	"parent_storage_group": [],
+	"maskingview": [],
+	"snapshot_policies": [],
+	"host_io_limit": {
+		"host_io_limit_io_sec": "1000",
+		"host_io_limit_mb_sec": "1000",
+		"dynamic_distribution": "Never"
+	},
+	"compression": true,
+	"compression_ratio": "1.0:1",
+	"compression_ratio_to_one": 1,
+	"vp_saved_percent": 100,
+	"tags": %s,
+	"uuid": "6a3d7cd1-3d4f-4f0e-9f8b-0d6d1b2c3e4f",
+	"unreducible_data_gb": 0,
+	"volume_ids": ["0012A"]
+}`
@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &tagsDataSource{}
+	_ datasource.DataSourceWithConfigure = &tagsDataSource{}
+)
+
+// NewTagsDataSource is a helper function to simplify the provider implementation.
+func NewTagsDataSource() datasource.DataSource {
+	return &tagsDataSource{}
+}
+
+// tagsDataSource is the data source implementation.
+type tagsDataSource struct {
+	client *client.Client
+}
+
+func (d *tagsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tags"
+}
+
+func (d *tagsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for reading the tags of PowerMax arrays and the objects which carry them. The tags of a storage group are managed with the tags attribute of the powermax_storagegroup resource.",
+		Description:         "Data source for reading the tags of PowerMax arrays and the objects which carry them. The tags of a storage group are managed with the tags attribute of the powermax_storagegroup resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"tags": schema.ListNestedAttribute{
+				Description:         "List of tags",
+				MarkdownDescription: "List of tags",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description:         "The name of the tag.",
+							MarkdownDescription: "The name of the tag.",
+							Computed:            true,
+						},
+						"array_ids": schema.ListAttribute{
+							Description:         "The IDs of the arrays carrying the tag.",
+							MarkdownDescription: "The IDs of the arrays carrying the tag.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"storage_groups": schema.ListNestedAttribute{
+							Description:         "The storage groups carrying the tag.",
+							MarkdownDescription: "The storage groups carrying the tag.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"storage_group_name": schema.StringAttribute{
+										Description:         "The name of the storage group.",
+										MarkdownDescription: "The name of the storage group.",
+										Computed:            true,
+									},
+									"array_id": schema.StringAttribute{
+										Description:         "The ID of the array of the storage group.",
+										MarkdownDescription: "The ID of the array of the storage group.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"names": schema.SetAttribute{
+						Description:         "The names of the tags to list.",
+						MarkdownDescription: "The names of the tags to list.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *tagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider is not config
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Config Failure",
+			fmt.Sprintf("Expected client, %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *tagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.TagsDataSourceModel
+	var plan models.TagsDataSourceModel
+	tflog.Info(ctx, "Attempting to read tags")
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tagNames []string
+	if plan.TagFilter != nil && len(plan.TagFilter.Names) > 0 {
+		for _, name := range plan.TagFilter.Names {
+			tagNames = append(tagNames, name.ValueString())
+		}
+	} else {
+		list, _, err := helper.ListTags(ctx, *d.client)
+		if err != nil {
+			errStr := constants.ReadTags + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the list of tags",
+				message,
+			)
+			return
+		}
+		tagNames = list.TagName
+	}
+
+	state.Tags = []models.TagDetailModel{}
+	for _, tagName := range tagNames {
+		tag, _, err := helper.GetTag(ctx, *d.client, tagName)
+		if err != nil {
+			errStr := constants.ReadTags + " with error: "
+			message := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error getting the tag details",
+				message,
+			)
+			return
+		}
+		var detail models.TagDetailModel
+		helper.UpdateTagDetailState(&detail, tagName, tag)
+		state.Tags = append(state.Tags, detail)
+	}
+	state.ID = types.StringValue("tags-datasource")
+	state.TagFilter = plan.TagFilter
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
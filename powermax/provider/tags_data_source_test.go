@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTagsDataSource(t *testing.T) {
+	var tagsTerraformName = "data.powermax_tags.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + tagsDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tagsTerraformName, "tags.#"),
+				),
+			},
+			{
+				Config: ProviderConfig + tagsFilterDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(tagsTerraformName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(tagsTerraformName, "tags.0.name", "tfacc_tag"),
+					resource.TestCheckResourceAttr(tagsTerraformName, "tags.0.storage_groups.#", "1"),
+					resource.TestCheckResourceAttr(tagsTerraformName, "tags.0.storage_groups.0.storage_group_name", "tfacc_sg_tags"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTagsDataSourceListError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.ListTags).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + tagsDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccTagsDataSourceDetailsError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetTag).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + tagsFilterDatasourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+var tagsDatasourceConfig = `
+data "powermax_tags" "test" {
+}
+`
+
+var tagsFilterDatasourceConfig = `
+resource "powermax_storagegroup" "tags" {
+	name   = "tfacc_sg_tags"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+	tags   = ["tfacc_tag"]
+}
+
+data "powermax_tags" "test" {
+	depends_on = [powermax_storagegroup.tags]
+	filter {
+		names = ["tfacc_tag"]
+	}
+}
+`
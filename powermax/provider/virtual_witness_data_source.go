@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VirtualWitnessDataSource{}
+var _ datasource.DataSourceWithConfigure = &VirtualWitnessDataSource{}
+
+// VirtualWitnessDataSource defines the data source implementation.
+type VirtualWitnessDataSource struct {
+	client *client.Client
+}
+
+// NewVirtualWitnessDataSource is a helper function to simplify the provider implementation.
+func NewVirtualWitnessDataSource() datasource.DataSource {
+	return &VirtualWitnessDataSource{}
+}
+
+// Metadata returns the metadata for the data source.
+func (d *VirtualWitnessDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_witness"
+}
+
+// Schema returns the schema for the data source.
+func (d *VirtualWitnessDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for reading Virtual Witnesses in PowerMax array. A virtual witness (vWitness) acts as the arbiter for SRDF/Metro sessions when the arrays lose contact with each other.",
+		Description:         "Data source for reading Virtual Witnesses in PowerMax array. A virtual witness (vWitness) acts as the arbiter for SRDF/Metro sessions when the arrays lose contact with each other.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"virtual_witnesses": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "List of virtual witness attributes",
+				MarkdownDescription: "List of virtual witness attributes",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Identifier",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The name of the virtual witness.",
+							MarkdownDescription: "The name of the virtual witness.",
+						},
+						"location": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The IP address or fully qualified domain name of the virtual witness.",
+							MarkdownDescription: "The IP address or fully qualified domain name of the virtual witness.",
+						},
+						"port": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "The port number the virtual witness listens on.",
+							MarkdownDescription: "The port number the virtual witness listens on.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:            true,
+							Description:         "Whether the virtual witness is enabled.",
+							MarkdownDescription: "Whether the virtual witness is enabled.",
+						},
+						"alive": schema.BoolAttribute{
+							Computed:            true,
+							Description:         "Whether the virtual witness is alive.",
+							MarkdownDescription: "Whether the virtual witness is alive.",
+						},
+						"in_use": schema.BoolAttribute{
+							Computed:            true,
+							Description:         "Whether the virtual witness is in use.",
+							MarkdownDescription: "Whether the virtual witness is in use.",
+						},
+						"duplicate": schema.BoolAttribute{
+							Computed:            true,
+							Description:         "Whether the virtual witness is a duplicate.",
+							MarkdownDescription: "Whether the virtual witness is a duplicate.",
+						},
+						"last_heartbeat_timestamp": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "The time of the last heartbeat received from the virtual witness.",
+							MarkdownDescription: "The time of the last heartbeat received from the virtual witness.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"names": schema.SetAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						Description:         "The names of the virtual witnesses to list.",
+						MarkdownDescription: "The names of the virtual witnesses to list.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the data source.
+func (d *VirtualWitnessDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxclient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = pmaxclient
+}
+
+func (d *VirtualWitnessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var vwPlan models.VirtualWitnessDataSourceModel
+	var vwState models.VirtualWitnessDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &vwPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var vwNames []string
+
+	vwList, _, err := helper.GetVirtualWitnessList(ctx, *d.client)
+	if err != nil {
+		errStr := ""
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Unable to Read PowerMax Virtual Witnesses", msgStr,
+		)
+		return
+	}
+	// Get virtual witness names from config or query all if not specified
+	if vwPlan.VirtualWitnessFilter == nil || len(vwPlan.VirtualWitnessFilter.Names) == 0 {
+		vwNames = vwList.GetName()
+	} else {
+		for _, vw := range vwPlan.VirtualWitnessFilter.Names {
+			if helper.StringInSlice(vw.ValueString(), vwList.GetName()) {
+				vwNames = append(vwNames, vw.ValueString())
+			}
+		}
+		if len(vwNames) != len(vwPlan.VirtualWitnessFilter.Names) {
+			resp.Diagnostics.AddError("Invalid name(s) provided.", "Name of already created virtual witness must be provided.")
+			return
+		}
+	}
+
+	virtualWitnesses := []models.VirtualWitness{}
+	for _, name := range vwNames {
+		vwResponse, _, err := helper.GetVirtualWitness(ctx, *d.client, name)
+		if err != nil || vwResponse == nil {
+			errStr := fmt.Sprintf("Error reading virtual witness with id %s", name)
+			msgStr := helper.GetErrorString(err, "")
+			resp.Diagnostics.AddError(errStr, msgStr)
+			return
+		}
+		var vw models.VirtualWitness
+		helper.UpdateVirtualWitnessState(&vw, vwResponse)
+		virtualWitnesses = append(virtualWitnesses, vw)
+	}
+	vwState.VirtualWitnesses = virtualWitnesses
+	vwState.ID = types.StringValue("virtual-witness-datasource")
+	vwState.VirtualWitnessFilter = vwPlan.VirtualWitnessFilter
+
+	tflog.Trace(ctx, "read virtual witness data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &vwState)...)
+}
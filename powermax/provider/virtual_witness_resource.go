@@ -0,0 +1,351 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &VirtualWitness{}
+	_ resource.ResourceWithConfigure   = &VirtualWitness{}
+	_ resource.ResourceWithImportState = &VirtualWitness{}
+)
+
+// NewVirtualWitness is a helper function to simplify the provider implementation.
+func NewVirtualWitness() resource.Resource {
+	return &VirtualWitness{}
+}
+
+// VirtualWitness defines the resource implementation.
+type VirtualWitness struct {
+	client *client.Client
+}
+
+// Schema Resource schema.
+func (r *VirtualWitness) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for managing Virtual Witnesses in PowerMax array. A virtual witness (vWitness) is a witness instance running on a host outside of the arrays that acts as the arbiter for SRDF/Metro sessions when the arrays lose contact with each other.",
+		Description:         "Resource for managing Virtual Witnesses in PowerMax array. A virtual witness (vWitness) is a witness instance running on a host outside of the arrays that acts as the arbiter for SRDF/Metro sessions when the arrays lose contact with each other.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The ID of the virtual witness.",
+				MarkdownDescription: "The ID of the virtual witness.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the virtual witness. Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed.",
+				MarkdownDescription: "The name of the virtual witness. Only alphanumeric characters, underscores ( _ ), and hyphens (-) are allowed.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(64),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_-]*$`),
+						"must contain only alphanumeric characters and _-",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Required:            true,
+				Description:         "The IP address or fully qualified domain name of the virtual witness.",
+				MarkdownDescription: "The IP address or fully qualified domain name of the virtual witness.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"port": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "The port number the virtual witness listens on. Defaults to the array default port if not set.",
+				MarkdownDescription: "The port number the virtual witness listens on. Defaults to the array default port if not set.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				Description:         "Whether the virtual witness is enabled. Defaults to true. (Update Supported)",
+				MarkdownDescription: "Whether the virtual witness is enabled. Defaults to true. (Update Supported)",
+			},
+			"alive": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Whether the virtual witness is alive.",
+				MarkdownDescription: "Whether the virtual witness is alive.",
+			},
+			"in_use": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Whether the virtual witness is in use.",
+				MarkdownDescription: "Whether the virtual witness is in use.",
+			},
+			"duplicate": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Whether the virtual witness is a duplicate.",
+				MarkdownDescription: "Whether the virtual witness is a duplicate.",
+			},
+			"last_heartbeat_timestamp": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "The time of the last heartbeat received from the virtual witness.",
+				MarkdownDescription: "The time of the last heartbeat received from the virtual witness.",
+			},
+		},
+	}
+}
+
+// Metadata Resource metadata.
+func (r *VirtualWitness) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_witness"
+}
+
+// Configure VirtualWitness.
+func (r *VirtualWitness) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = pmaxClient
+}
+
+// Create VirtualWitness.
+func (r *VirtualWitness) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating virtual witness")
+
+	var plan models.VirtualWitness
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vwResponse, _, err := helper.CreateVirtualWitness(ctx, *r.client, plan)
+	if err != nil {
+		errStr := constants.CreateVirtualWitnessDetailErrorMsg + plan.Name.ValueString() + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error creating virtual witness", msgStr,
+		)
+		return
+	}
+	tflog.Debug(ctx, "create virtual witness response", map[string]interface{}{
+		"vwResponse": vwResponse,
+	})
+
+	// A newly added witness is enabled, so only act when the plan asks for it to be disabled
+	if vwResponse.Enabled != plan.Enabled.ValueBool() {
+		vwResponse, _, err = helper.ModifyVirtualWitness(ctx, *r.client, plan.Name.ValueString(), plan.Enabled.ValueBool())
+		if err != nil {
+			errStr := constants.UpdateVirtualWitnessDetailsErrorMsg + plan.Name.ValueString() + " with error: "
+			msgStr := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error creating virtual witness", msgStr,
+			)
+			// The witness exists on the array at this point, save it so that it can be cleaned up
+			vwState := models.VirtualWitness{}
+			vwResponse, _, err = helper.GetVirtualWitness(ctx, *r.client, plan.Name.ValueString())
+			if err == nil {
+				helper.UpdateVirtualWitnessState(&vwState, vwResponse)
+				resp.Diagnostics.Append(resp.State.Set(ctx, vwState)...)
+			}
+			return
+		}
+	}
+
+	vwState := models.VirtualWitness{}
+	helper.UpdateVirtualWitnessState(&vwState, vwResponse)
+
+	diags = resp.State.Set(ctx, vwState)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "create virtual witness completed")
+}
+
+// Read VirtualWitness.
+func (r *VirtualWitness) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading virtual witness")
+	var vwState models.VirtualWitness
+	diags := req.State.Get(ctx, &vwState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vwID := vwState.ID.ValueString()
+	tflog.Debug(ctx, "getting virtual witness by ID", map[string]interface{}{
+		"symmetrixID":      r.client.SymmetrixID,
+		"virtualWitnessID": vwID,
+	})
+	vwResponse, _, err := helper.GetVirtualWitness(ctx, *r.client, vwID)
+	if err != nil {
+		errStr := constants.ReadVirtualWitnessDetailsErrorMsg + vwID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading virtual witness", msgStr,
+		)
+		return
+	}
+
+	helper.UpdateVirtualWitnessState(&vwState, vwResponse)
+
+	diags = resp.State.Set(ctx, vwState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "read virtual witness completed")
+}
+
+// Update VirtualWitness
+// Supported updates: enabled.
+func (r *VirtualWitness) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating virtual witness")
+	var vwPlan, vwState models.VirtualWitness
+	diags := req.State.Get(ctx, &vwState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &vwPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vwID := vwState.ID.ValueString()
+	if vwPlan.Enabled.ValueBool() != vwState.Enabled.ValueBool() {
+		_, _, err := helper.ModifyVirtualWitness(ctx, *r.client, vwID, vwPlan.Enabled.ValueBool())
+		if err != nil {
+			errStr := constants.UpdateVirtualWitnessDetailsErrorMsg + vwID + " with error: "
+			msgStr := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error updating virtual witness", msgStr,
+			)
+			return
+		}
+	}
+
+	vwResponse, _, err := helper.GetVirtualWitness(ctx, *r.client, vwID)
+	if err != nil {
+		errStr := constants.ReadVirtualWitnessDetailsErrorMsg + vwID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error reading virtual witness", msgStr,
+		)
+		return
+	}
+
+	helper.UpdateVirtualWitnessState(&vwState, vwResponse)
+
+	diags = resp.State.Set(ctx, vwState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "update virtual witness completed")
+}
+
+// Delete VirtualWitness.
+func (r *VirtualWitness) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting virtual witness")
+	var vwState models.VirtualWitness
+	diags := req.State.Get(ctx, &vwState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	vwID := vwState.ID.ValueString()
+	tflog.Debug(ctx, "calling delete virtual witness on pmax client", map[string]interface{}{
+		"symmetrixID":      r.client.SymmetrixID,
+		"virtualWitnessID": vwID,
+	})
+
+	// The array only removes a witness that has been disabled
+	if vwState.Enabled.ValueBool() {
+		_, _, err := helper.ModifyVirtualWitness(ctx, *r.client, vwID, false)
+		if err != nil {
+			errStr := constants.DeleteVirtualWitnessDetailsErrorMsg + vwID + " with error: "
+			msgStr := helper.GetErrorString(err, errStr)
+			resp.Diagnostics.AddError(
+				"Error deleting virtual witness", msgStr,
+			)
+			return
+		}
+	}
+
+	_, err := helper.DeleteVirtualWitness(ctx, *r.client, vwID)
+	if err != nil {
+		errStr := constants.DeleteVirtualWitnessDetailsErrorMsg + vwID + " with error: "
+		msgStr := helper.GetErrorString(err, errStr)
+		resp.Diagnostics.AddError(
+			"Error deleting virtual witness", msgStr,
+		)
+	}
+	tflog.Info(ctx, "delete virtual witness completed")
+}
+
+// ImportState import resource.
+func (r *VirtualWitness) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing virtual witness state")
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var createVirtualWitnessConfig = `
+resource "powermax_virtual_witness" "test_virtual_witness" {
+	name = "tfacc_vw"
+	location = "10.0.0.1"
+}
+`
+var updateVirtualWitnessConfig = `
+resource "powermax_virtual_witness" "test_virtual_witness" {
+	name = "tfacc_vw"
+	location = "10.0.0.1"
+	# This will be updated
+	enabled = false
+}
+`
+
+func TestAccVirtualWitnessResource(t *testing.T) {
+	var virtualWitnessTerraformName = "powermax_virtual_witness.test_virtual_witness"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + createVirtualWitnessConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(virtualWitnessTerraformName, "name", "tfacc_vw"),
+					resource.TestCheckResourceAttr(virtualWitnessTerraformName, "location", "10.0.0.1"),
+					resource.TestCheckResourceAttr(virtualWitnessTerraformName, "enabled", "true"),
+					resource.TestCheckResourceAttrSet(virtualWitnessTerraformName, "port"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      virtualWitnessTerraformName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: ProviderConfig + updateVirtualWitnessConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(virtualWitnessTerraformName, "name", "tfacc_vw"),
+					resource.TestCheckResourceAttr(virtualWitnessTerraformName, "enabled", "false"),
+				),
+			},
+			// Read test Error
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.GetVirtualWitness).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createVirtualWitnessConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// Modify Error
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+					FunctionMocker = Mock(helper.ModifyVirtualWitness).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createVirtualWitnessConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+			// auto checks delete to clean up the test
+		},
+	})
+}
+
+func TestAccVirtualWitnessResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateVirtualWitness).Return(nil, nil, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + createVirtualWitnessConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
@@ -473,6 +473,12 @@ func (d *volumeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						MarkdownDescription: "Volumes that correspond to Namespace Globally Unique Identifier that uses the EUI64 16-byte designator format.",
 						Optional:            true,
 					},
+					"tags": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Description:         "Volumes in the storage groups carrying any of the tags.",
+						MarkdownDescription: "Volumes in the storage groups carrying any of the tags.",
+						Optional:            true,
+					},
 				},
 			},
 		},
@@ -515,7 +521,11 @@ func (d *volumeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		)
 		return
 	}
-	state.Volumes, err = helper.UpdateVolumeState(ctx, d.client, param)
+	if state.VolumeFilter != nil && len(state.VolumeFilter.Tags.Elements()) > 0 {
+		state.Volumes, err = helper.UpdateTaggedVolumeState(ctx, d.client, param, state.VolumeFilter)
+	} else {
+		state.Volumes, err = helper.UpdateVolumeState(ctx, d.client, param)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to update volume state",
@@ -46,6 +46,22 @@ func TestAccVolumeDatasource(t *testing.T) {
 	})
 }
 
+func TestAccVolumeDatasourceTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + VolumeDatasourceTagsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powermax_volume.volume_datasource_tags", "volumes.#", "2"),
+					resource.TestCheckResourceAttr("data.powermax_volume.volume_datasource_tags", "volumes.0.volume_identifier", "tfacc_ds_vol_tags"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVolumeDatasourceInvalidFilter(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -93,3 +109,26 @@ data "powermax_volume" "volume_datasource_test" {
 	}
 }
 `
+
+var VolumeDatasourceTagsConfig = `
+resource "powermax_storagegroup" "tagged" {
+	name   = "tfacc_ds_vol_sg_tags"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+	tags   = ["tfacc_ds_vol_tag"]
+	volume_attributes = [
+		{
+			size              = 1
+			num_of_vols       = 2
+			volume_identifier = "tfacc_ds_vol_tags"
+		},
+	]
+}
+
+data "powermax_volume" "volume_datasource_tags" {
+	depends_on = [powermax_storagegroup.tagged]
+	filter {
+		tags = ["tfacc_ds_vol_tag"]
+	}
+}
+`
@@ -26,11 +26,17 @@ import (
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/helper"
 	"terraform-provider-powermax/powermax/models"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -86,10 +92,77 @@ func (r volumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"sg_name": schema.StringAttribute{
-				Description:         "The name of the storage group. sg_name is required while creating the volume.",
-				MarkdownDescription: "The name of the storage group. sg_name is required while creating the volume.",
+				Description:         "The name of the storage group. sg_name is required while creating the volume. Changing it moves the volume to the new storage group without recreating it. (Update Supported)",
+				MarkdownDescription: "The name of the storage group. sg_name is required while creating the volume. Changing it moves the volume to the new storage group without recreating it. (Update Supported)",
 				Required:            true,
 			},
+			"additional_sg_names": schema.SetAttribute{
+				Description:         "The names of other storage groups the volume is also a member of, for volumes shared between hosts such as cluster quorum disks. Removing a storage group from the list removes the volume from it, the volume is kept. (Update Supported)",
+				MarkdownDescription: "The names of other storage groups the volume is also a member of, for volumes shared between hosts such as cluster quorum disks. Removing a storage group from the list removes the volume from it, the volume is kept. (Update Supported)",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"force_move": schema.BoolAttribute{
+				Description:         "Force moving the volume to another storage group when the storage groups are in masking views. (Update Supported)",
+				MarkdownDescription: "Force moving the volume to another storage group when the storage groups are in masking views. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"free_before_delete": schema.BoolAttribute{
+				Description:         "Free all the allocations of the volume before deleting it, and wait until none of its capacity is allocated. The volume is removed from its storage groups first, and added back to them when it cannot be freed. (Update Supported)",
+				MarkdownDescription: "Free all the allocations of the volume before deleting it, and wait until none of its capacity is allocated. The volume is removed from its storage groups first, and added back to them when it cannot be freed. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"free_timeout_minutes": schema.Int64Attribute{
+				Description:         "The number of minutes to wait for the allocations of the volume to be freed when free_before_delete is set, defaults to 30. (Update Supported)",
+				MarkdownDescription: "The number of minutes to wait for the allocations of the volume to be freed when free_before_delete is set, defaults to 30. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(30),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"remote_storage_groups": schema.ListNestedAttribute{
+				Description:         "The storage groups of the SRDF remote arrays to create the paired volumes in, when sg_name is protected by SRDF. At most two remote arrays, one per SRDF leg. Only used when the volume is created, later changes are ignored with a warning.",
+				MarkdownDescription: "The storage groups of the SRDF remote arrays to create the paired volumes in, when `sg_name` is protected by SRDF. At most two remote arrays, one per SRDF leg. Only used when the volume is created, later changes are ignored with a warning.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 2),
+				},
+				PlanModifiers: []planmodifier.List{
+					createOnlyModifier{resourceName: "volume", hint: "Manage the paired volumes on the remote arrays instead."},
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"symmetrix_id": schema.StringAttribute{
+							Description:         "The ID of the SRDF remote array.",
+							MarkdownDescription: "The ID of the SRDF remote array.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"storage_group_names": schema.ListAttribute{
+							Description:         "The storage groups of the remote array to add the paired volume to.",
+							MarkdownDescription: "The storage groups of the remote array to add the paired volume to.",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+							},
+						},
+					},
+				},
+			},
 			"size": schema.NumberAttribute{
 				Description:         "The size of the volume. (Update Supported)",
 				MarkdownDescription: "The size of the volume. (Update Supported)",
@@ -116,9 +189,21 @@ func (r volumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "The type of the volume.",
 			},
 			"emulation": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				Description:         "The emulation of the volume Enumeration values.",
-				MarkdownDescription: "The emulation of the volume Enumeration values.",
+				Description:         "The emulation of the volume, FBA, CKD-3390 or CKD-3380. Defaults to FBA. CKD volumes are sized in cylinders, cap_unit must be CYL. The emulation must match the volumes already in sg_name, and CKD volumes need FICON splits on the array.",
+				MarkdownDescription: "The emulation of the volume, FBA, CKD-3390 or CKD-3380. Defaults to FBA. CKD volumes are sized in cylinders, cap_unit must be CYL. The emulation must match the volumes already in sg_name, and CKD volumes need FICON splits on the array.",
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{
+						helper.EmulationFBA,
+						helper.EmulationCKD3390,
+						helper.EmulationCKD3380,
+					}...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"ssid": schema.StringAttribute{
 				Computed:            true,
@@ -322,6 +407,22 @@ func (r volumeResource) Create(ctx context.Context, request resource.CreateReque
 		}
 	}
 
+	if err := helper.ValidateVolumeEmulation(plan.Emulation.ValueString(), plan.CapUnit.ValueString()); err != nil {
+		response.Diagnostics.AddError(
+			"Error creating volume",
+			fmt.Sprintf("Could not create volume %s with error: %s", plan.VolumeIdentifier.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if err := helper.ValidateVolumeStorageGroups(ctx, plan); err != nil {
+		response.Diagnostics.AddError(
+			"Error creating volume",
+			fmt.Sprintf("Could not create volume %s with error: %s", plan.VolumeIdentifier.ValueString(), err.Error()),
+		)
+		return
+	}
+
 	if plan.StorageGroupName.ValueString() == "" {
 		response.Diagnostics.AddError(
 			"Error creating volume",
@@ -330,6 +431,14 @@ func (r volumeResource) Create(ctx context.Context, request resource.CreateReque
 		return
 	}
 
+	if err := helper.ValidateVolumeCapabilities(ctx, *r.client, plan); err != nil {
+		errStr := ""
+		message := helper.GetErrorString(err, errStr)
+		response.Diagnostics.AddError("Error creating volume",
+			fmt.Sprintf("Could not create volume %s with error: %s", plan.VolumeIdentifier.ValueString(), message))
+		return
+	}
+
 	volResponse, _, err := helper.CreateVolume(ctx, *r.client, plan)
 	if err != nil {
 		errStr := ""
@@ -383,6 +492,19 @@ func (r volumeResource) Create(ctx context.Context, request resource.CreateReque
 		return
 	}
 
+	if len(plan.AdditionalStorageGroupNames.Elements()) > 0 {
+		err = helper.AddVolumeToAdditionalStorageGroups(ctx, *r.client, plan, vol.VolumeId, helper.GetVolumeSgNames(vol))
+		if err != nil {
+			// Keep going so that the volume is saved in the state
+			response.Diagnostics.AddError("Error creating volume",
+				fmt.Sprintf("Could not add volume %s to its additional storage groups with error: %s", plan.VolumeIdentifier.ValueString(), err.Error()))
+		}
+		volTemp, _, err := helper.GetVolume(ctx, *r.client, vol.VolumeId)
+		if err == nil {
+			vol = volTemp
+		}
+	}
+
 	tflog.Debug(ctx, "updating create volume state", map[string]interface{}{
 		"volResponse": volResponse,
 		"vol":         vol,
@@ -485,6 +607,14 @@ func (r volumeResource) Update(ctx context.Context, request resource.UpdateReque
 		}
 	}
 
+	if err := helper.ValidateVolumeStorageGroups(ctx, planVol); err != nil {
+		response.Diagnostics.AddError(
+			"Error updating volume",
+			fmt.Sprintf("Could not update volume %s with error: %s", planVol.VolumeIdentifier.ValueString(), err.Error()),
+		)
+		return
+	}
+
 	tflog.Debug(ctx, "calling update volume on pmax client", map[string]interface{}{
 		"planVol":  planVol,
 		"stateVol": stateVol,
@@ -557,6 +687,8 @@ func (r volumeResource) Delete(ctx context.Context, request resource.DeleteReque
 		response.Diagnostics.Append(diags...)
 	}
 
+	// Storage groups the volume was removed from, to put it back when it cannot be freed
+	removedSgNames := make([]string, 0)
 	for _, associatedSG := range sgAssociatedWithVolume {
 		tflog.Debug(ctx, "calling get storage group on pmax client", map[string]interface{}{
 			"symmetrixID":    r.client.SymmetrixID,
@@ -595,6 +727,32 @@ func (r volumeResource) Delete(ctx context.Context, request resource.DeleteReque
 
 				return
 			}
+			removedSgNames = append(removedSgNames, associatedSG.StorageGroupName.ValueString())
+		}
+	}
+	if volumeState.FreeBeforeDelete.ValueBool() {
+		tflog.Info(ctx, "freeing volume before delete", map[string]interface{}{
+			"volumeID": volumeID,
+		})
+		err := helper.FreeVolume(ctx, *r.client, volumeID)
+		if err == nil {
+			timeout := time.Duration(volumeState.FreeTimeoutMinutes.ValueInt64()) * time.Minute
+			err = helper.WaitForVolumeFree(ctx, *r.client, volumeID, timeout)
+		}
+		// The volume is put back in its storage groups and kept in the state, so that it is freed and deleted again on the next destroy
+		if err != nil {
+			errStr := ""
+			message := helper.GetErrorString(err, errStr)
+			for _, sgName := range removedSgNames {
+				if addErr := helper.AddVolumeToStorageGroup(ctx, *r.client, volumeID, sgName); addErr != nil {
+					message += fmt.Sprintf(", and could not add it back to storage group %s with error: %s", sgName, helper.GetErrorString(addErr, ""))
+				}
+			}
+			response.Diagnostics.AddError(
+				"Error freeing volume",
+				fmt.Sprintf("Could not free Volume ID: %s with error: %s", volumeID, message),
+			)
+			return
 		}
 	}
 	tflog.Debug(ctx, "calling delete volume on pmax client", map[string]interface{}{
@@ -611,7 +769,7 @@ func (r volumeResource) Delete(ctx context.Context, request resource.DeleteReque
 			fmt.Sprintf("Could not remove Volume ID: %s with error: %s ",
 				volumeID, message),
 		)
-
+		return
 	}
 	response.State.RemoveResource(ctx)
 	tflog.Info(ctx, "delete volume completed")
@@ -20,12 +20,14 @@ package provider
 import (
 	"dell/powermax-go-client"
 	"fmt"
+	"os"
 	"regexp"
 	"terraform-provider-powermax/powermax/helper"
 	"testing"
 
 	. "github.com/bytedance/mockey"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/assert"
 )
@@ -63,6 +65,8 @@ func TestAccVolumeResourceA(t *testing.T) {
 					resource.TestCheckResourceAttr("powermax_volume.volume_test", "snapvx_source", "false"),
 					resource.TestCheckResourceAttr("powermax_volume.volume_test", "snapvx_target", "false"),
 					resource.TestCheckResourceAttr("powermax_volume.volume_test", "has_effective_wwn", "false"),
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "free_before_delete", "false"),
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "free_timeout_minutes", "30"),
 				),
 			},
 			// ImportState testing
@@ -90,6 +94,104 @@ func TestAccVolumeResourceA(t *testing.T) {
 	})
 }
 
+func TestAccVolumeResourceMoveStorageGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create in a storage group and share it with another one
+			{
+				Config: ProviderConfig + VolumeConfigSharedSG,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "sg_name", "tfacc_vol_move_sg1"),
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "additional_sg_names.#", "1"),
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "num_of_storage_groups", "2"),
+				),
+			},
+			// Move the volume to the third storage group, the volume ID does not change
+			{
+				Config: ProviderConfig + VolumeConfigMovedSG,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "sg_name", "tfacc_vol_move_sg3"),
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "additional_sg_names.#", "0"),
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "num_of_storage_groups", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("powermax_volume.volume_test", "storage_groups.*", map[string]string{
+						"storage_group_name": "tfacc_vol_move_sg3",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVolumeResourceMoveError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// sg_name can not also be an additional storage group
+			{
+				Config:      ProviderConfig + VolumeConfigDuplicateSG,
+				ExpectError: regexp.MustCompile(`.*is both sg_name and in additional_sg_names*.`),
+			},
+			{
+				Config: ProviderConfig + VolumeConfigSharedSG,
+			},
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.MoveVolumeToStorageGroup).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + VolumeConfigMovedSG,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccVolumeResourceFreeBeforeDelete(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + VolumeConfigFreeBeforeDelete,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "free_before_delete", "true"),
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "free_timeout_minutes", "5"),
+				),
+			},
+			// The volume is kept when it could not be freed
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.FreeVolume).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig,
+				ExpectError: regexp.MustCompile(`.*Error freeing volume*.`),
+			},
+			// and is back in its storage group
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+				},
+				Config: ProviderConfig + VolumeConfigFreeBeforeDelete,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_volume.volume_test", "storage_groups.#", "1"),
+				),
+			},
+			{
+				Config: ProviderConfig,
+			},
+		},
+	})
+}
+
 func TestAccVolumeResourceReadError(t *testing.T) {
 	createResponse := powermax.StorageGroup{
 		StorageGroupId: "123",
@@ -145,6 +247,11 @@ func TestAccVolumeResource_Invalid_Config(t *testing.T) {
 				Config:      ProviderConfig + VolumeConfigInvalidSG,
 				ExpectError: regexp.MustCompile("Error creating volume"),
 			},
+			// Config with a CKD volume not sized in cylinders
+			{
+				Config:      ProviderConfig + VolumeConfigInvalidCKDUnit,
+				ExpectError: regexp.MustCompile("must be sized with cap_unit 'CYL'"),
+			},
 		},
 	})
 }
@@ -189,6 +296,32 @@ func TestAccVolumeResourceCreateError(t *testing.T) {
 	})
 }
 
+func TestAccVolumeResourceCapabilityError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A CKD volume can not join a storage group of FBA volumes
+			{
+				Config:      ProviderConfig + VolumeConfigMixedEmulation,
+				ExpectError: regexp.MustCompile(`.*contains FBA volumes|has no FICON splits*.`),
+			},
+			// The paired volumes can not be created on the local array
+			{
+				Config:      ProviderConfig + VolumeConfigLocalRemoteStorageGroup,
+				ExpectError: regexp.MustCompile(`.*is the local array*.`),
+			},
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.ValidateVolumeCapabilities).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + VolumeResourceConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
 func TestAccVolumeResourceListError(t *testing.T) {
 	createResponse := powermax.StorageGroup{
 		StorageGroupId: "123",
@@ -308,6 +441,17 @@ resource "powermax_volume" "volume_test" {
 }
 `, resourceVolName, resourceVolSGName)
 
+var VolumeConfigFreeBeforeDelete = fmt.Sprintf(`
+resource "powermax_volume" "volume_test" {
+	vol_name = "%s_free"
+	size = 1
+	cap_unit = "GB"
+	sg_name = "%s"
+	free_before_delete = true
+	free_timeout_minutes = 5
+}
+`, resourceVolName, resourceVolSGName)
+
 var VolumeUpdateNameSizeMobility = fmt.Sprintf(`
 resource "powermax_volume" "volume_test" {
 	vol_name = "%s_2"
@@ -344,6 +488,100 @@ resource "powermax_volume" "volume_test" {
 }
 `, resourceVolName, resourceVolSGName)
 
+var VolumeMoveStorageGroupsConfig = `
+resource "powermax_storagegroup" "move_sg1" {
+	name   = "tfacc_vol_move_sg1"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+}
+
+resource "powermax_storagegroup" "move_sg2" {
+	name   = "tfacc_vol_move_sg2"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+}
+
+resource "powermax_storagegroup" "move_sg3" {
+	name   = "tfacc_vol_move_sg3"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+}
+`
+
+var VolumeConfigSharedSG = VolumeMoveStorageGroupsConfig + fmt.Sprintf(`
+resource "powermax_volume" "volume_test" {
+	vol_name = "%s_move"
+	size = 1
+	cap_unit = "GB"
+	sg_name = powermax_storagegroup.move_sg1.name
+	additional_sg_names = [powermax_storagegroup.move_sg2.name]
+}
+`, resourceVolName)
+
+var VolumeConfigMovedSG = VolumeMoveStorageGroupsConfig + fmt.Sprintf(`
+resource "powermax_volume" "volume_test" {
+	vol_name = "%s_move"
+	size = 1
+	cap_unit = "GB"
+	sg_name = powermax_storagegroup.move_sg3.name
+	additional_sg_names = []
+	force_move = true
+}
+`, resourceVolName)
+
+var VolumeConfigDuplicateSG = VolumeMoveStorageGroupsConfig + fmt.Sprintf(`
+resource "powermax_volume" "volume_test" {
+	vol_name = "%s_move"
+	size = 1
+	cap_unit = "GB"
+	sg_name = powermax_storagegroup.move_sg1.name
+	additional_sg_names = [powermax_storagegroup.move_sg1.name]
+}
+`, resourceVolName)
+
+var VolumeConfigInvalidCKDUnit = fmt.Sprintf(`
+resource "powermax_volume" "volume_test" {
+	vol_name = "%s"
+	sg_name = "%s"
+	size = 1
+	cap_unit = "GB"
+	emulation = "CKD-3390"
+}
+`, resourceVolName, resourceVolSGName)
+
+var VolumeConfigMixedEmulation = VolumeMoveStorageGroupsConfig + fmt.Sprintf(`
+resource "powermax_volume" "volume_fba" {
+	vol_name = "%s_fba"
+	size = 1
+	cap_unit = "GB"
+	sg_name = powermax_storagegroup.move_sg1.name
+}
+
+resource "powermax_volume" "volume_test" {
+	vol_name = "%s_ckd"
+	size = 100
+	cap_unit = "CYL"
+	emulation = "CKD-3390"
+	sg_name = powermax_storagegroup.move_sg1.name
+	depends_on = [powermax_volume.volume_fba]
+}
+`, resourceVolName, resourceVolName)
+
+var VolumeConfigLocalRemoteStorageGroup = fmt.Sprintf(`
+resource "powermax_volume" "volume_test" {
+	vol_name = "%s_rdf"
+	size = 1
+	cap_unit = "GB"
+	sg_name = "%s"
+	remote_storage_groups = [
+		{
+			symmetrix_id = "%s"
+			storage_group_names = ["%s"]
+		}
+	]
+}
+`, resourceVolName, resourceVolSGName, os.Getenv("POWERMAX_SERIAL_NUMBER"), resourceVolSGName)
+
 var VolumeConfigInvalidSG = fmt.Sprintf(`
 resource "powermax_volume" "volume_test" {
 	vol_name = "%s"
@@ -0,0 +1,533 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &volumeSetResource{}
+	_ resource.ResourceWithConfigure   = &volumeSetResource{}
+	_ resource.ResourceWithImportState = &volumeSetResource{}
+	_ resource.ResourceWithModifyPlan  = &volumeSetResource{}
+)
+
+// NewVolumeSetResource is a helper function to simplify the provider implementation.
+func NewVolumeSetResource() resource.Resource {
+	return &volumeSetResource{}
+}
+
+// volumeSetResource is the resource implementation.
+type volumeSetResource struct {
+	client *client.Client
+}
+
+// Metadata returns the resource type name.
+func (r *volumeSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *volumeSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for managing a set of identical volumes in a storage group of PowerMax array. The volumes are created in a single request and named after a pattern, such as `ora_data_%03d` for ora_data_001, ora_data_002 and so on. The set can be grown, shrunk and expanded as a whole.",
+		Description:         "Resource for managing a set of identical volumes in a storage group of PowerMax array. The volumes are created in a single request and named after a pattern, such as `ora_data_%03d` for ora_data_001, ora_data_002 and so on. The set can be grown, shrunk and expanded as a whole.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "The ID of the volume set, in the format 'sg_name.name_pattern'.",
+				MarkdownDescription: "The ID of the volume set, in the format 'sg_name.name_pattern'.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sg_name": schema.StringAttribute{
+				Description:         "The name of the storage group to create the volumes in.",
+				MarkdownDescription: "The name of the storage group to create the volumes in.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_pattern": schema.StringAttribute{
+				Description:         "The naming pattern of the volumes, with exactly one integer verb for the index of the volume, such as ora_data_%03d. Only alphanumeric characters, underscores ( _ ) and hyphens ( - ) besides the verb. When the pattern ends with a plain %d verb, such as ora_data_%d, the array names the new volumes when it creates them, other patterns take one rename request per new volume. Volumes which could not be renamed are renamed on the next apply.",
+				MarkdownDescription: "The naming pattern of the volumes, with exactly one integer verb for the index of the volume, such as `ora_data_%03d`. Only alphanumeric characters, underscores ( _ ) and hyphens ( - ) besides the verb. When the pattern ends with a plain `%d` verb, such as `ora_data_%d`, the array names the new volumes when it creates them, other patterns take one rename request per new volume. Volumes which could not be renamed are renamed on the next apply.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(64),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_-]*%(0[1-9][0-9]*)?d[a-zA-Z0-9_-]*$`),
+						"must contain only alphanumeric characters and _- and exactly one integer verb such as %d or %03d",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"volume_count": schema.Int64Attribute{
+				Description:         "The number of volumes in the set. Volumes are added at the end of the set and removed from the end of the set. (Update Supported)",
+				MarkdownDescription: "The number of volumes in the set. Volumes are added at the end of the set and removed from the end of the set. (Update Supported)",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"start_index": schema.Int64Attribute{
+				Description:         "The index of the first volume of the set. Defaults to 1.",
+				MarkdownDescription: "The index of the first volume of the set. Defaults to 1.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.NumberAttribute{
+				Description:         "The size of each volume. Volumes can only be expanded. (Update Supported)",
+				MarkdownDescription: "The size of each volume. Volumes can only be expanded. (Update Supported)",
+				Required:            true,
+			},
+			"cap_unit": schema.StringAttribute{
+				Description:         "The Capacity Unit corresponding to the size. (Update Supported)",
+				MarkdownDescription: "The Capacity Unit corresponding to the size. (Update Supported)",
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString(helper.CapacityUnitGb),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{
+						helper.CapacityUnitMb,
+						helper.CapacityUnitGb,
+						helper.CapacityUnitTb,
+						helper.CapacityUnitCyl,
+					}...),
+				},
+			},
+			"emulation": schema.StringAttribute{
+				Description:         "The emulation of the volumes, FBA, CKD-3390 or CKD-3380. Defaults to FBA. CKD volumes are sized in cylinders, cap_unit must be CYL.",
+				MarkdownDescription: "The emulation of the volumes, FBA, CKD-3390 or CKD-3380. Defaults to FBA. CKD volumes are sized in cylinders, cap_unit must be CYL.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(helper.EmulationFBA),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{
+						helper.EmulationFBA,
+						helper.EmulationCKD3390,
+						helper.EmulationCKD3380,
+					}...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mobility_id_enabled": schema.BoolAttribute{
+				Description:         "States whether mobility ID is enabled on the volumes. (Update Supported)",
+				MarkdownDescription: "States whether mobility ID is enabled on the volumes. (Update Supported)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"volumes": schema.ListNestedAttribute{
+				Description:         "The volumes of the set, ordered by index.",
+				MarkdownDescription: "The volumes of the set, ordered by index.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"index": schema.Int64Attribute{
+							Description:         "The index of the volume in the set.",
+							MarkdownDescription: "The index of the volume in the set.",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							Description:         "The ID of the volume.",
+							MarkdownDescription: "The ID of the volume.",
+							Computed:            true,
+						},
+						"volume_identifier": schema.StringAttribute{
+							Description:         "The name of the volume.",
+							MarkdownDescription: "The name of the volume.",
+							Computed:            true,
+						},
+						"wwn": schema.StringAttribute{
+							Description:         "The WWN of the volume.",
+							MarkdownDescription: "The WWN of the volume.",
+							Computed:            true,
+						},
+						"cap_gb": schema.NumberAttribute{
+							Description:         "The capacity of the volume in GB.",
+							MarkdownDescription: "The capacity of the volume in GB.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *volumeSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// validateVolumeSetSize checks that the size is an integer when the capacity unit is CYL, which CKD volumes must use.
+func validateVolumeSetSize(plan models.VolumeSetResourceModel) error {
+	size, _ := plan.Size.ValueBigFloat().Float64()
+	if plan.CapUnit.ValueString() == helper.CapacityUnitCyl && size != float64(int(size)) {
+		return fmt.Errorf("invalid config, size type 'CYL' must be integer")
+	}
+	return helper.ValidateVolumeEmulation(plan.Emulation.ValueString(), plan.CapUnit.ValueString())
+}
+
+// applyVolumeSet brings the volumes of the set in line with the plan and returns the volumes of the set, including the ones
+// created before an error, and the errors which occurred. The existing volumes are expanded first, so that the new volumes
+// are created at the planned size.
+func (r *volumeSetResource) applyVolumeSet(ctx context.Context, plan models.VolumeSetResourceModel, members []models.VolumeSetMember, expand, setMobilityID bool) ([]models.VolumeSetMember, []string) {
+	var errorMessages []string
+	pattern := plan.NamePattern.ValueString()
+	toCreate, toDelete := helper.GetVolumeSetIndexChanges(members, plan.StartIndex.ValueInt64(), plan.Count.ValueInt64())
+
+	deleteIDs := make(map[string]bool)
+	for _, member := range toDelete {
+		deleteIDs[member.ID.ValueString()] = true
+	}
+	kept := make([]models.VolumeSetMember, 0, len(members))
+	for _, member := range members {
+		if !deleteIDs[member.ID.ValueString()] {
+			kept = append(kept, member)
+		}
+	}
+
+	for _, member := range kept {
+		volumeID := member.ID.ValueString()
+		if expand {
+			err := helper.ExpandVolume(ctx, *r.client, volumeID, plan.Size.ValueBigFloat().String(), plan.CapUnit.ValueString())
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("Failed to expand volume %s: %s", volumeID, helper.GetErrorString(err, "")))
+			}
+		}
+		if setMobilityID {
+			err := helper.SetVolumeMobilityID(ctx, *r.client, volumeID, plan.MobilityIDEnabled.ValueBool())
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("Failed to modify mobility of volume %s: %s", volumeID, helper.GetErrorString(err, "")))
+			}
+		}
+	}
+
+	if len(toDelete) > 0 {
+		var volumeIDs []string
+		for _, member := range toDelete {
+			volumeIDs = append(volumeIDs, member.ID.ValueString())
+		}
+		err := helper.DeleteVolumeSetVolumes(ctx, *r.client, volumeIDs)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("Failed to delete volumes: %s", err.Error()))
+			// Keep the volumes in the state, the ones which were deleted are dropped on read
+			kept = append(kept, toDelete...)
+		}
+	}
+
+	if len(toCreate) > 0 {
+		volumeIDs, named, err := helper.CreateVolumeSetVolumes(ctx, *r.client, plan, toCreate)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("Failed to create volumes: %s", helper.GetErrorString(err, "")))
+		}
+		for i, volumeID := range volumeIDs {
+			if i >= len(toCreate) {
+				break
+			}
+			name := helper.VolumeSetName(pattern, toCreate[i])
+			kept = append(kept, models.VolumeSetMember{
+				Index: types.Int64Value(toCreate[i]),
+				ID:    types.StringValue(volumeID),
+			})
+			// Volumes named by the array, and the ones whose rename fails, are renamed once the state is refreshed
+			if named {
+				continue
+			}
+			err := helper.RenameVolume(ctx, *r.client, volumeID, name)
+			if err != nil {
+				tflog.Warn(ctx, "failed to rename volume of volume set", map[string]interface{}{"volumeID": volumeID, "name": name, "error": helper.GetErrorString(err, "")})
+			}
+		}
+	}
+	helper.SortVolumeSetMembers(kept)
+	return kept, errorMessages
+}
+
+// renameVolumeSetMembers renames the volumes of the refreshed state which are not named after the pattern,
+// which retries the renames that failed and fixes the names given by the array, and refreshes the state again if needed.
+// The volumes which still cannot be renamed are reported, and renamed on the next apply.
+func (r *volumeSetResource) renameVolumeSetMembers(ctx context.Context, plan *models.VolumeSetResourceModel) ([]string, diag.Diagnostics) {
+	var errorMessages []string
+	members, diags := helper.GetVolumeSetMembers(ctx, plan.Volumes)
+	if diags.HasError() {
+		return errorMessages, diags
+	}
+	toRename := helper.GetVolumeSetMembersToRename(plan.NamePattern.ValueString(), members)
+	if len(toRename) == 0 {
+		return errorMessages, diags
+	}
+	for _, member := range toRename {
+		name := helper.VolumeSetName(plan.NamePattern.ValueString(), member.Index.ValueInt64())
+		err := helper.RenameVolume(ctx, *r.client, member.ID.ValueString(), name)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("Failed to rename volume %s to %s: %s", member.ID.ValueString(), name, helper.GetErrorString(err, "")))
+		}
+	}
+	diags.Append(helper.UpdateVolumeSetState(ctx, *r.client, plan, members)...)
+	return errorMessages, diags
+}
+
+// ModifyPlan marks the volumes as unknown when some of them are not named after the pattern,
+// such as volumes whose rename failed, so that the next apply renames them.
+func (r *volumeSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var state models.VolumeSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	members, diags := helper.GetVolumeSetMembers(ctx, state.Volumes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(helper.GetVolumeSetMembersToRename(state.NamePattern.ValueString(), members)) > 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("volumes"), types.ListUnknown(helper.VolumeSetMemberType))...)
+	}
+}
+
+// Create creates the volumes of the set in a single request and names them after the pattern.
+func (r *volumeSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating volume set")
+	var plan models.VolumeSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := validateVolumeSetSize(plan); err != nil {
+		resp.Diagnostics.AddError("Error creating volume set", constants.CreateVolumeSetDetailErrorMsg+plan.NamePattern.ValueString()+" with error: "+err.Error())
+		return
+	}
+
+	members, errorMessages := r.applyVolumeSet(ctx, plan, []models.VolumeSetMember{}, false, false)
+	if len(members) == 0 {
+		resp.Diagnostics.AddError("Error creating volume set", constants.CreateVolumeSetDetailErrorMsg+plan.NamePattern.ValueString()+" with error: "+strings.Join(errorMessages, ",\n"))
+		return
+	}
+
+	resp.Diagnostics.Append(helper.UpdateVolumeSetState(ctx, *r.client, &plan, members)...)
+	renameErrors, diags := r.renameVolumeSetMembers(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	errorMessages = append(errorMessages, renameErrors...)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	// An error would taint the set and recreate all of its volumes, the missing and misnamed volumes are fixed on the next apply instead
+	if len(errorMessages) > 0 {
+		resp.Diagnostics.AddWarning("Volume set partially created", constants.CreateVolumeSetDetailErrorMsg+plan.NamePattern.ValueString()+" with error: "+strings.Join(errorMessages, ",\n")+
+			"\nThe volume set is fixed on the next apply.")
+	}
+	tflog.Info(ctx, "create volume set completed")
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *volumeSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading volume set")
+	var state models.VolumeSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := helper.GetVolumeSetMembers(ctx, state.Volumes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(helper.UpdateVolumeSetState(ctx, *r.client, &state, members)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, "read volume set completed")
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Supported updates: volume_count, size, cap_unit, mobility_id_enabled.
+func (r *volumeSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating volume set")
+	var plan, state models.VolumeSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errStr := constants.UpdateVolumeSetDetailErrorMsg + state.NamePattern.ValueString() + " with error: "
+	if err := validateVolumeSetSize(plan); err != nil {
+		resp.Diagnostics.AddError("Error updating volume set", errStr+err.Error())
+		return
+	}
+	sizeChanged := plan.Size.ValueBigFloat().Cmp(state.Size.ValueBigFloat()) != 0 || plan.CapUnit.ValueString() != state.CapUnit.ValueString()
+	if plan.CapUnit.ValueString() == state.CapUnit.ValueString() && plan.Size.ValueBigFloat().Cmp(state.Size.ValueBigFloat()) < 0 {
+		resp.Diagnostics.AddError("Error updating volume set", errStr+"volumes cannot be shrunk, the size can only be increased")
+		return
+	}
+
+	members, diags := helper.GetVolumeSetMembers(ctx, state.Volumes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setMobilityID := plan.MobilityIDEnabled.ValueBool() != state.MobilityIDEnabled.ValueBool()
+	members, errorMessages := r.applyVolumeSet(ctx, plan, members, sizeChanged, setMobilityID)
+
+	resp.Diagnostics.Append(helper.UpdateVolumeSetState(ctx, *r.client, &plan, members)...)
+	renameErrors, diags := r.renameVolumeSetMembers(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if len(renameErrors) > 0 {
+		resp.Diagnostics.AddWarning("Volumes of volume set not renamed", errStr+strings.Join(renameErrors, ",\n")+"\nThe volumes are renamed on the next apply.")
+	}
+	if len(errorMessages) > 0 {
+		resp.Diagnostics.AddError("Error updating volume set", errStr+strings.Join(errorMessages, ",\n"))
+		return
+	}
+	tflog.Info(ctx, "update volume set completed")
+}
+
+// Delete removes the volumes of the set from their storage groups, deletes them and removes the Terraform state on success.
+func (r *volumeSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting volume set")
+	var state models.VolumeSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := helper.GetVolumeSetMembers(ctx, state.Volumes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var volumeIDs []string
+	for _, member := range members {
+		volumeIDs = append(volumeIDs, member.ID.ValueString())
+	}
+	err := helper.DeleteVolumeSetVolumes(ctx, *r.client, volumeIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting volume set", constants.DeleteVolumeSetDetailErrorMsg+state.NamePattern.ValueString()+" with error: "+err.Error())
+		return
+	}
+	tflog.Info(ctx, "delete volume set completed")
+}
+
+// ImportState imports the volumes of a storage group named after a pattern, the import ID is 'sg_name.name_pattern'.
+func (r *volumeSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing volume set")
+	sgName, pattern, err := helper.ParseVolumeSetID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing volume set", err.Error())
+		return
+	}
+	members, err := helper.FindVolumeSetMembers(ctx, *r.client, sgName, pattern)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing volume set", constants.ReadVolumeSetDetailErrorMsg+pattern+" with error: "+helper.GetErrorString(err, ""))
+		return
+	}
+	if len(members) == 0 {
+		resp.Diagnostics.AddError("Error importing volume set", fmt.Sprintf("no volume of storage group %s is named after the pattern %s", sgName, pattern))
+		return
+	}
+	state := models.VolumeSetResourceModel{
+		StorageGroupName:  types.StringValue(sgName),
+		NamePattern:       types.StringValue(pattern),
+		StartIndex:        types.Int64Null(),
+		CapUnit:           types.StringNull(),
+		Emulation:         types.StringValue(helper.EmulationFBA),
+		MobilityIDEnabled: types.BoolValue(false),
+		Size:              types.NumberNull(),
+	}
+	resp.Diagnostics.Append(helper.UpdateVolumeSetState(ctx, *r.client, &state, members)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	tflog.Info(ctx, "import volume set completed")
+}
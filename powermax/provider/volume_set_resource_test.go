@@ -0,0 +1,277 @@
+/*
+Copyright (c) 2022-2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-powermax/powermax/helper"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVolumeSetResource(t *testing.T) {
+	var volumeSetTerraformName = "powermax_volume_set.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read test
+			{
+				Config: ProviderConfig + volumeSetConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "id", resourceVolSGName+".tfacc_vs_%03d"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volume_count", "3"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.#", "3"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.0.index", "1"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.0.volume_identifier", "tfacc_vs_001"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.2.volume_identifier", "tfacc_vs_003"),
+				),
+			},
+			// Grow the set and expand all volumes
+			{
+				Config: ProviderConfig + volumeSetGrowConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volume_count", "5"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.#", "5"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.4.volume_identifier", "tfacc_vs_005"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.0.cap_gb", "2"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.4.cap_gb", "2"),
+				),
+			},
+			// Shrink the set
+			{
+				Config: ProviderConfig + volumeSetShrinkConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volume_count", "2"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.#", "2"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.1.volume_identifier", "tfacc_vs_002"),
+				),
+			},
+			// Import test
+			{
+				Config:            ProviderConfig + volumeSetShrinkConfig,
+				ResourceName:      volumeSetTerraformName,
+				ImportState:       true,
+				ImportStateId:     resourceVolSGName + ".tfacc_vs_%03d",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccVolumeSetResourceShrinkSizeError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + volumeSetGrowConfig,
+			},
+			{
+				Config:      ProviderConfig + volumeSetConfig,
+				ExpectError: regexp.MustCompile(`.*volumes cannot be shrunk*.`),
+			},
+		},
+	})
+}
+
+func TestAccVolumeSetResourceCreateError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.CreateVolumeSetVolumes).Return(nil, false, fmt.Errorf("mock error")).Build()
+				},
+				Config:      ProviderConfig + volumeSetConfig,
+				ExpectError: regexp.MustCompile(`.*mock error*.`),
+			},
+		},
+	})
+}
+
+func TestAccVolumeSetResourceAppendNumber(t *testing.T) {
+	var volumeSetTerraformName = "powermax_volume_set.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The array names the volumes of a pattern ending with %d
+			{
+				Config: ProviderConfig + volumeSetAppendNumberConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.#", "3"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.0.volume_identifier", "tfacc_vsn_1"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.2.volume_identifier", "tfacc_vsn_3"),
+				),
+			},
+			{
+				Config: ProviderConfig + strings.Replace(volumeSetAppendNumberConfig, "volume_count = 3", "volume_count = 5", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.#", "5"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.4.volume_identifier", "tfacc_vsn_5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVolumeSetResourceRenameRepair(t *testing.T) {
+	var volumeSetTerraformName = "powermax_volume_set.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig + volumeSetConfig,
+			},
+			// The new volumes keep their temporary identifier when they cannot be renamed, with a warning
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.RenameVolume).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config:             ProviderConfig + volumeSetGrowConfig,
+				ExpectNonEmptyPlan: true,
+			},
+			// and are renamed on the next apply
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+				},
+				Config: ProviderConfig + volumeSetGrowConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.#", "5"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.3.volume_identifier", "tfacc_vs_004"),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.4.volume_identifier", "tfacc_vs_005"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVolumeSetResourceCreateRenameRepair(t *testing.T) {
+	var volumeSetTerraformName = "powermax_volume_set.test"
+	var volumeID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A failed rename does not taint the new volume set
+			{
+				PreConfig: func() {
+					FunctionMocker = Mock(helper.RenameVolume).Return(fmt.Errorf("mock error")).Build()
+				},
+				Config: ProviderConfig + volumeSetConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith(volumeSetTerraformName, "volumes.0.id", func(value string) error {
+						volumeID = value
+						return nil
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// so the next apply renames the volumes instead of recreating them
+			{
+				PreConfig: func() {
+					if FunctionMocker != nil {
+						FunctionMocker.UnPatch()
+					}
+				},
+				Config: ProviderConfig + volumeSetConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith(volumeSetTerraformName, "volumes.0.id", func(value string) error {
+						if value != volumeID {
+							return fmt.Errorf("expected volume %s to be kept, got %s", volumeID, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr(volumeSetTerraformName, "volumes.0.volume_identifier", "tfacc_vs_001"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVolumeSetResourceInvalidPattern(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      ProviderConfig + volumeSetInvalidPatternConfig,
+				ExpectError: regexp.MustCompile(`.*exactly one integer verb*.`),
+			},
+		},
+	})
+}
+
+var volumeSetConfig = fmt.Sprintf(`
+resource "powermax_volume_set" "test" {
+	sg_name = "%s"
+	name_pattern = "tfacc_vs_%%03d"
+	volume_count = 3
+	size = 1
+	cap_unit = "GB"
+}
+`, resourceVolSGName)
+
+var volumeSetGrowConfig = fmt.Sprintf(`
+resource "powermax_volume_set" "test" {
+	sg_name = "%s"
+	name_pattern = "tfacc_vs_%%03d"
+	volume_count = 5
+	size = 2
+	cap_unit = "GB"
+}
+`, resourceVolSGName)
+
+var volumeSetShrinkConfig = fmt.Sprintf(`
+resource "powermax_volume_set" "test" {
+	sg_name = "%s"
+	name_pattern = "tfacc_vs_%%03d"
+	volume_count = 2
+	size = 2
+	cap_unit = "GB"
+}
+`, resourceVolSGName)
+
+var volumeSetAppendNumberConfig = fmt.Sprintf(`
+resource "powermax_volume_set" "test" {
+	sg_name = "%s"
+	name_pattern = "tfacc_vsn_%%d"
+	volume_count = 3
+	size = 1
+	cap_unit = "GB"
+}
+`, resourceVolSGName)
+
+var volumeSetInvalidPatternConfig = fmt.Sprintf(`
+resource "powermax_volume_set" "test" {
+	sg_name = "%s"
+	name_pattern = "tfacc_vs_%%d_%%d"
+	volume_count = 2
+	size = 1
+}
+`, resourceVolSGName)